@@ -0,0 +1,4 @@
+// Package tickersvcv1 — сгенерированный gRPC API (tickersvc.proto); сервер — internal/adapter/controller/grpc.
+package tickersvcv1
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative tickersvc.proto
@@ -0,0 +1,1593 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.7
+// 	protoc        v5.29.3
+// source: tickersvc.proto
+
+// gRPC API tickersvc: те же данные, что и REST (/api/lists, /api/assets, /api/markets),
+// но строками списков, а не текстом "SPOT, FUTURES". Ключ — как у HTTP: metadata x-api-key
+// или authorization: Bearer <key>.
+
+package tickersvcv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Row struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Spot          string                 `protobuf:"bytes,1,opt,name=spot,proto3" json:"spot,omitempty"`
+	Futures       string                 `protobuf:"bytes,2,opt,name=futures,proto3" json:"futures,omitempty"` // "" — фьючерса нет ("none" в текстовых списках)
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`   // биржа-источник
+	Base          string                 `protobuf:"bytes,4,opt,name=base,proto3" json:"base,omitempty"`
+	Quote         string                 `protobuf:"bytes,5,opt,name=quote,proto3" json:"quote,omitempty"`
+	VolumeUsd     *float64               `protobuf:"fixed64,6,opt,name=volume_usd,json=volumeUsd,proto3,oneof" json:"volume_usd,omitempty"` // 24h-оборот спота в USD
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Row) Reset() {
+	*x = Row{}
+	mi := &file_tickersvc_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Row) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Row) ProtoMessage() {}
+
+func (x *Row) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Row.ProtoReflect.Descriptor instead.
+func (*Row) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Row) GetSpot() string {
+	if x != nil {
+		return x.Spot
+	}
+	return ""
+}
+
+func (x *Row) GetFutures() string {
+	if x != nil {
+		return x.Futures
+	}
+	return ""
+}
+
+func (x *Row) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Row) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *Row) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *Row) GetVolumeUsd() float64 {
+	if x != nil && x.VolumeUsd != nil {
+		return *x.VolumeUsd
+	}
+	return 0
+}
+
+type ListMeta struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // target|segment
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Target        string                 `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`   // "" для сегментов
+	Segment       string                 `protobuf:"bytes,5,opt,name=segment,proto3" json:"segment,omitempty"` // "" для target-списков
+	Expr          string                 `protobuf:"bytes,6,opt,name=expr,proto3" json:"expr,omitempty"`       // выражение сегмента
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Count         int32                  `protobuf:"varint,8,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMeta) Reset() {
+	*x = ListMeta{}
+	mi := &file_tickersvc_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMeta) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMeta) ProtoMessage() {}
+
+func (x *ListMeta) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMeta.ProtoReflect.Descriptor instead.
+func (*ListMeta) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ListMeta) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *ListMeta) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ListMeta) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ListMeta) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ListMeta) GetSegment() string {
+	if x != nil {
+		return x.Segment
+	}
+	return ""
+}
+
+func (x *ListMeta) GetExpr() string {
+	if x != nil {
+		return x.Expr
+	}
+	return ""
+}
+
+func (x *ListMeta) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *ListMeta) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// RowsFilter — как query-параметры /api/lists: q, has_futures, quote, min_volume_usd, sort, limit, cursor.
+type RowsFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Q             string                 `protobuf:"bytes,1,opt,name=q,proto3" json:"q,omitempty"`
+	HasFutures    *bool                  `protobuf:"varint,2,opt,name=has_futures,json=hasFutures,proto3,oneof" json:"has_futures,omitempty"`
+	Quotes        []string               `protobuf:"bytes,3,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	MinVolumeUsd  float64                `protobuf:"fixed64,4,opt,name=min_volume_usd,json=minVolumeUsd,proto3" json:"min_volume_usd,omitempty"`
+	Sort          string                 `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"` // spot|volume
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RowsFilter) Reset() {
+	*x = RowsFilter{}
+	mi := &file_tickersvc_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RowsFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RowsFilter) ProtoMessage() {}
+
+func (x *RowsFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RowsFilter.ProtoReflect.Descriptor instead.
+func (*RowsFilter) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RowsFilter) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+func (x *RowsFilter) GetHasFutures() bool {
+	if x != nil && x.HasFutures != nil {
+		return *x.HasFutures
+	}
+	return false
+}
+
+func (x *RowsFilter) GetQuotes() []string {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+func (x *RowsFilter) GetMinVolumeUsd() float64 {
+	if x != nil {
+		return x.MinVolumeUsd
+	}
+	return 0
+}
+
+func (x *RowsFilter) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *RowsFilter) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RowsFilter) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Generation    int64                  `protobuf:"varint,2,opt,name=generation,proto3" json:"generation,omitempty"` // 0 — текущее
+	Notation      string                 `protobuf:"bytes,3,opt,name=notation,proto3" json:"notation,omitempty"`      // raw|tradingview|ccxt
+	Filter        *RowsFilter            `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetListRequest) Reset() {
+	*x = GetListRequest{}
+	mi := &file_tickersvc_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListRequest) ProtoMessage() {}
+
+func (x *GetListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListRequest.ProtoReflect.Descriptor instead.
+func (*GetListRequest) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetListRequest) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *GetListRequest) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *GetListRequest) GetNotation() string {
+	if x != nil {
+		return x.Notation
+	}
+	return ""
+}
+
+func (x *GetListRequest) GetFilter() *RowsFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type GetListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Meta          *ListMeta              `protobuf:"bytes,1,opt,name=meta,proto3" json:"meta,omitempty"`
+	Rows          []*Row                 `protobuf:"bytes,2,rep,name=rows,proto3" json:"rows,omitempty"`
+	Generation    int64                  `protobuf:"varint,3,opt,name=generation,proto3" json:"generation,omitempty"`
+	Total         int32                  `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"` // только с filter
+	NextCursor    string                 `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetListResponse) Reset() {
+	*x = GetListResponse{}
+	mi := &file_tickersvc_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListResponse) ProtoMessage() {}
+
+func (x *GetListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListResponse.ProtoReflect.Descriptor instead.
+func (*GetListResponse) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetListResponse) GetMeta() *ListMeta {
+	if x != nil {
+		return x.Meta
+	}
+	return nil
+}
+
+func (x *GetListResponse) GetRows() []*Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *GetListResponse) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *GetListResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetListResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ListListsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Target        string                 `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"` // фильтры; пусто — все
+	Source        string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	Kind          string                 `protobuf:"bytes,3,opt,name=kind,proto3" json:"kind,omitempty"`              // target|segment
+	Generation    int64                  `protobuf:"varint,4,opt,name=generation,proto3" json:"generation,omitempty"` // 0 — текущее
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListListsRequest) Reset() {
+	*x = ListListsRequest{}
+	mi := &file_tickersvc_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListListsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListListsRequest) ProtoMessage() {}
+
+func (x *ListListsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListListsRequest.ProtoReflect.Descriptor instead.
+func (*ListListsRequest) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListListsRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ListListsRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ListListsRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *ListListsRequest) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+type ListListsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lists         []*ListMeta            `protobuf:"bytes,1,rep,name=lists,proto3" json:"lists,omitempty"`
+	Generation    int64                  `protobuf:"varint,2,opt,name=generation,proto3" json:"generation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListListsResponse) Reset() {
+	*x = ListListsResponse{}
+	mi := &file_tickersvc_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListListsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListListsResponse) ProtoMessage() {}
+
+func (x *ListListsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListListsResponse.ProtoReflect.Descriptor instead.
+func (*ListListsResponse) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListListsResponse) GetLists() []*ListMeta {
+	if x != nil {
+		return x.Lists
+	}
+	return nil
+}
+
+func (x *ListListsResponse) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+type GetAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          string                 `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"` // тикер или алиас (MATIC → POL)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssetRequest) Reset() {
+	*x = GetAssetRequest{}
+	mi := &file_tickersvc_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssetRequest) ProtoMessage() {}
+
+func (x *GetAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssetRequest.ProtoReflect.Descriptor instead.
+func (*GetAssetRequest) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetAssetRequest) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+type Specs struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// десятичные — строками, как у бирж
+	ContractSize  string `protobuf:"bytes,1,opt,name=contract_size,json=contractSize,proto3" json:"contract_size,omitempty"`
+	TickSize      string `protobuf:"bytes,2,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	LotSize       string `protobuf:"bytes,3,opt,name=lot_size,json=lotSize,proto3" json:"lot_size,omitempty"`
+	MinQty        string `protobuf:"bytes,4,opt,name=min_qty,json=minQty,proto3" json:"min_qty,omitempty"`
+	MinNotional   string `protobuf:"bytes,5,opt,name=min_notional,json=minNotional,proto3" json:"min_notional,omitempty"`
+	MaxLeverage   string `protobuf:"bytes,6,opt,name=max_leverage,json=maxLeverage,proto3" json:"max_leverage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Specs) Reset() {
+	*x = Specs{}
+	mi := &file_tickersvc_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Specs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Specs) ProtoMessage() {}
+
+func (x *Specs) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Specs.ProtoReflect.Descriptor instead.
+func (*Specs) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Specs) GetContractSize() string {
+	if x != nil {
+		return x.ContractSize
+	}
+	return ""
+}
+
+func (x *Specs) GetTickSize() string {
+	if x != nil {
+		return x.TickSize
+	}
+	return ""
+}
+
+func (x *Specs) GetLotSize() string {
+	if x != nil {
+		return x.LotSize
+	}
+	return ""
+}
+
+func (x *Specs) GetMinQty() string {
+	if x != nil {
+		return x.MinQty
+	}
+	return ""
+}
+
+func (x *Specs) GetMinNotional() string {
+	if x != nil {
+		return x.MinNotional
+	}
+	return ""
+}
+
+func (x *Specs) GetMaxLeverage() string {
+	if x != nil {
+		return x.MaxLeverage
+	}
+	return ""
+}
+
+type Market struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exchange      string                 `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // spot|futures
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Base          string                 `protobuf:"bytes,4,opt,name=base,proto3" json:"base,omitempty"`
+	Quote         string                 `protobuf:"bytes,5,opt,name=quote,proto3" json:"quote,omitempty"`
+	Multiplier    int64                  `protobuf:"varint,6,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	Contract      string                 `protobuf:"bytes,7,opt,name=contract,proto3" json:"contract,omitempty"` // linear_perp|inverse_perp|delivery
+	Settle        string                 `protobuf:"bytes,8,opt,name=settle,proto3" json:"settle,omitempty"`
+	Expiry        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	Active        bool                   `protobuf:"varint,10,opt,name=active,proto3" json:"active,omitempty"`
+	ListedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=listed_at,json=listedAt,proto3" json:"listed_at,omitempty"`
+	DelistedAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=delisted_at,json=delistedAt,proto3" json:"delisted_at,omitempty"`
+	Specs         *Specs                 `protobuf:"bytes,13,opt,name=specs,proto3" json:"specs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Market) Reset() {
+	*x = Market{}
+	mi := &file_tickersvc_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Market) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Market) ProtoMessage() {}
+
+func (x *Market) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Market.ProtoReflect.Descriptor instead.
+func (*Market) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Market) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *Market) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Market) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Market) GetBase() string {
+	if x != nil {
+		return x.Base
+	}
+	return ""
+}
+
+func (x *Market) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *Market) GetMultiplier() int64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+func (x *Market) GetContract() string {
+	if x != nil {
+		return x.Contract
+	}
+	return ""
+}
+
+func (x *Market) GetSettle() string {
+	if x != nil {
+		return x.Settle
+	}
+	return ""
+}
+
+func (x *Market) GetExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Expiry
+	}
+	return nil
+}
+
+func (x *Market) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Market) GetListedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ListedAt
+	}
+	return nil
+}
+
+func (x *Market) GetDelistedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DelistedAt
+	}
+	return nil
+}
+
+func (x *Market) GetSpecs() *Specs {
+	if x != nil {
+		return x.Specs
+	}
+	return nil
+}
+
+type Membership struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slug          string                 `protobuf:"bytes,1,opt,name=slug,proto3" json:"slug,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Target        string                 `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	Segment       string                 `protobuf:"bytes,5,opt,name=segment,proto3" json:"segment,omitempty"`
+	Spot          string                 `protobuf:"bytes,6,opt,name=spot,proto3" json:"spot,omitempty"`
+	Futures       string                 `protobuf:"bytes,7,opt,name=futures,proto3" json:"futures,omitempty"` // "" — нет
+	Since         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Membership) Reset() {
+	*x = Membership{}
+	mi := &file_tickersvc_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Membership) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Membership) ProtoMessage() {}
+
+func (x *Membership) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Membership.ProtoReflect.Descriptor instead.
+func (*Membership) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Membership) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *Membership) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Membership) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Membership) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *Membership) GetSegment() string {
+	if x != nil {
+		return x.Segment
+	}
+	return ""
+}
+
+func (x *Membership) GetSpot() string {
+	if x != nil {
+		return x.Spot
+	}
+	return ""
+}
+
+func (x *Membership) GetFutures() string {
+	if x != nil {
+		return x.Futures
+	}
+	return ""
+}
+
+func (x *Membership) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+type Asset struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Asset         string                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Tickers       []string               `protobuf:"bytes,2,rep,name=tickers,proto3" json:"tickers,omitempty"`
+	Markets       []*Market              `protobuf:"bytes,3,rep,name=markets,proto3" json:"markets,omitempty"`
+	Lists         []*Membership          `protobuf:"bytes,4,rep,name=lists,proto3" json:"lists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Asset) Reset() {
+	*x = Asset{}
+	mi := &file_tickersvc_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Asset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Asset) ProtoMessage() {}
+
+func (x *Asset) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Asset.ProtoReflect.Descriptor instead.
+func (*Asset) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Asset) GetAsset() string {
+	if x != nil {
+		return x.Asset
+	}
+	return ""
+}
+
+func (x *Asset) GetTickers() []string {
+	if x != nil {
+		return x.Tickers
+	}
+	return nil
+}
+
+func (x *Asset) GetMarkets() []*Market {
+	if x != nil {
+		return x.Markets
+	}
+	return nil
+}
+
+func (x *Asset) GetLists() []*Membership {
+	if x != nil {
+		return x.Lists
+	}
+	return nil
+}
+
+type GetMarketsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// один символ: exchange + symbol (остальные фильтры, кроме type, не применяются)
+	Exchange      string   `protobuf:"bytes,1,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Symbol        string   `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Exchanges     []string `protobuf:"bytes,3,rep,name=exchanges,proto3" json:"exchanges,omitempty"`
+	Bases         []string `protobuf:"bytes,4,rep,name=bases,proto3" json:"bases,omitempty"`
+	Quotes        []string `protobuf:"bytes,5,rep,name=quotes,proto3" json:"quotes,omitempty"`
+	Type          string   `protobuf:"bytes,6,opt,name=type,proto3" json:"type,omitempty"` // spot|futures
+	Contracts     []string `protobuf:"bytes,7,rep,name=contracts,proto3" json:"contracts,omitempty"`
+	Active        *bool    `protobuf:"varint,8,opt,name=active,proto3,oneof" json:"active,omitempty"`
+	Sort          string   `protobuf:"bytes,9,opt,name=sort,proto3" json:"sort,omitempty"` // symbol|base|listed_at|delisted_at|id
+	Desc          bool     `protobuf:"varint,10,opt,name=desc,proto3" json:"desc,omitempty"`
+	Limit         int32    `protobuf:"varint,11,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string   `protobuf:"bytes,12,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMarketsRequest) Reset() {
+	*x = GetMarketsRequest{}
+	mi := &file_tickersvc_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMarketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMarketsRequest) ProtoMessage() {}
+
+func (x *GetMarketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMarketsRequest.ProtoReflect.Descriptor instead.
+func (*GetMarketsRequest) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetMarketsRequest) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *GetMarketsRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetMarketsRequest) GetExchanges() []string {
+	if x != nil {
+		return x.Exchanges
+	}
+	return nil
+}
+
+func (x *GetMarketsRequest) GetBases() []string {
+	if x != nil {
+		return x.Bases
+	}
+	return nil
+}
+
+func (x *GetMarketsRequest) GetQuotes() []string {
+	if x != nil {
+		return x.Quotes
+	}
+	return nil
+}
+
+func (x *GetMarketsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GetMarketsRequest) GetContracts() []string {
+	if x != nil {
+		return x.Contracts
+	}
+	return nil
+}
+
+func (x *GetMarketsRequest) GetActive() bool {
+	if x != nil && x.Active != nil {
+		return *x.Active
+	}
+	return false
+}
+
+func (x *GetMarketsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *GetMarketsRequest) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+func (x *GetMarketsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetMarketsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type GetMarketsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Markets       []*Market              `protobuf:"bytes,1,rep,name=markets,proto3" json:"markets,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMarketsResponse) Reset() {
+	*x = GetMarketsResponse{}
+	mi := &file_tickersvc_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMarketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMarketsResponse) ProtoMessage() {}
+
+func (x *GetMarketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMarketsResponse.ProtoReflect.Descriptor instead.
+func (*GetMarketsResponse) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetMarketsResponse) GetMarkets() []*Market {
+	if x != nil {
+		return x.Markets
+	}
+	return nil
+}
+
+func (x *GetMarketsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type WatchListsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Slugs         []string               `protobuf:"bytes,1,rep,name=slugs,proto3" json:"slugs,omitempty"`      // пусто и без target — все списки
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`    // все списки цели
+	Initial       bool                   `protobuf:"varint,3,opt,name=initial,proto3" json:"initial,omitempty"` // сначала снимок текущих строк
+	Notation      string                 `protobuf:"bytes,4,opt,name=notation,proto3" json:"notation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchListsRequest) Reset() {
+	*x = WatchListsRequest{}
+	mi := &file_tickersvc_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchListsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchListsRequest) ProtoMessage() {}
+
+func (x *WatchListsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchListsRequest.ProtoReflect.Descriptor instead.
+func (*WatchListsRequest) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WatchListsRequest) GetSlugs() []string {
+	if x != nil {
+		return x.Slugs
+	}
+	return nil
+}
+
+func (x *WatchListsRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *WatchListsRequest) GetInitial() bool {
+	if x != nil {
+		return x.Initial
+	}
+	return false
+}
+
+func (x *WatchListsRequest) GetNotation() string {
+	if x != nil {
+		return x.Notation
+	}
+	return ""
+}
+
+type ListChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Generation    int64                  `protobuf:"varint,1,opt,name=generation,proto3" json:"generation,omitempty"`
+	Slug          string                 `protobuf:"bytes,2,opt,name=slug,proto3" json:"slug,omitempty"`
+	Snapshot      bool                   `protobuf:"varint,3,opt,name=snapshot,proto3" json:"snapshot,omitempty"` // rows — весь список (initial)
+	Rows          []*Row                 `protobuf:"bytes,4,rep,name=rows,proto3" json:"rows,omitempty"`          // только в снимке
+	Added         []*Row                 `protobuf:"bytes,5,rep,name=added,proto3" json:"added,omitempty"`
+	Removed       []*Row                 `protobuf:"bytes,6,rep,name=removed,proto3" json:"removed,omitempty"`
+	Changed       []*Row                 `protobuf:"bytes,7,rep,name=changed,proto3" json:"changed,omitempty"` // сменился фьючерс
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChange) Reset() {
+	*x = ListChange{}
+	mi := &file_tickersvc_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChange) ProtoMessage() {}
+
+func (x *ListChange) ProtoReflect() protoreflect.Message {
+	mi := &file_tickersvc_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChange.ProtoReflect.Descriptor instead.
+func (*ListChange) Descriptor() ([]byte, []int) {
+	return file_tickersvc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListChange) GetGeneration() int64 {
+	if x != nil {
+		return x.Generation
+	}
+	return 0
+}
+
+func (x *ListChange) GetSlug() string {
+	if x != nil {
+		return x.Slug
+	}
+	return ""
+}
+
+func (x *ListChange) GetSnapshot() bool {
+	if x != nil {
+		return x.Snapshot
+	}
+	return false
+}
+
+func (x *ListChange) GetRows() []*Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *ListChange) GetAdded() []*Row {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *ListChange) GetRemoved() []*Row {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *ListChange) GetChanged() []*Row {
+	if x != nil {
+		return x.Changed
+	}
+	return nil
+}
+
+var File_tickersvc_proto protoreflect.FileDescriptor
+
+const file_tickersvc_proto_rawDesc = "" +
+	"\n" +
+	"\x0ftickersvc.proto\x12\ftickersvc.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa8\x01\n" +
+	"\x03Row\x12\x12\n" +
+	"\x04spot\x18\x01 \x01(\tR\x04spot\x12\x18\n" +
+	"\afutures\x18\x02 \x01(\tR\afutures\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x12\n" +
+	"\x04base\x18\x04 \x01(\tR\x04base\x12\x14\n" +
+	"\x05quote\x18\x05 \x01(\tR\x05quote\x12\"\n" +
+	"\n" +
+	"volume_usd\x18\x06 \x01(\x01H\x00R\tvolumeUsd\x88\x01\x01B\r\n" +
+	"\v_volume_usd\"\xe1\x01\n" +
+	"\bListMeta\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x16\n" +
+	"\x06target\x18\x04 \x01(\tR\x06target\x12\x18\n" +
+	"\asegment\x18\x05 \x01(\tR\asegment\x12\x12\n" +
+	"\x04expr\x18\x06 \x01(\tR\x04expr\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05count\x18\b \x01(\x05R\x05count\"\xd0\x01\n" +
+	"\n" +
+	"RowsFilter\x12\f\n" +
+	"\x01q\x18\x01 \x01(\tR\x01q\x12$\n" +
+	"\vhas_futures\x18\x02 \x01(\bH\x00R\n" +
+	"hasFutures\x88\x01\x01\x12\x16\n" +
+	"\x06quotes\x18\x03 \x03(\tR\x06quotes\x12$\n" +
+	"\x0emin_volume_usd\x18\x04 \x01(\x01R\fminVolumeUsd\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursorB\x0e\n" +
+	"\f_has_futures\"\x92\x01\n" +
+	"\x0eGetListRequest\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x02 \x01(\x03R\n" +
+	"generation\x12\x1a\n" +
+	"\bnotation\x18\x03 \x01(\tR\bnotation\x120\n" +
+	"\x06filter\x18\x04 \x01(\v2\x18.tickersvc.v1.RowsFilterR\x06filter\"\xbb\x01\n" +
+	"\x0fGetListResponse\x12*\n" +
+	"\x04meta\x18\x01 \x01(\v2\x16.tickersvc.v1.ListMetaR\x04meta\x12%\n" +
+	"\x04rows\x18\x02 \x03(\v2\x11.tickersvc.v1.RowR\x04rows\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x03 \x01(\x03R\n" +
+	"generation\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\"v\n" +
+	"\x10ListListsRequest\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x12\n" +
+	"\x04kind\x18\x03 \x01(\tR\x04kind\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x04 \x01(\x03R\n" +
+	"generation\"a\n" +
+	"\x11ListListsResponse\x12,\n" +
+	"\x05lists\x18\x01 \x03(\v2\x16.tickersvc.v1.ListMetaR\x05lists\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x02 \x01(\x03R\n" +
+	"generation\"%\n" +
+	"\x0fGetAssetRequest\x12\x12\n" +
+	"\x04base\x18\x01 \x01(\tR\x04base\"\xc3\x01\n" +
+	"\x05Specs\x12#\n" +
+	"\rcontract_size\x18\x01 \x01(\tR\fcontractSize\x12\x1b\n" +
+	"\ttick_size\x18\x02 \x01(\tR\btickSize\x12\x19\n" +
+	"\blot_size\x18\x03 \x01(\tR\alotSize\x12\x17\n" +
+	"\amin_qty\x18\x04 \x01(\tR\x06minQty\x12!\n" +
+	"\fmin_notional\x18\x05 \x01(\tR\vminNotional\x12!\n" +
+	"\fmax_leverage\x18\x06 \x01(\tR\vmaxLeverage\"\xbb\x03\n" +
+	"\x06Market\x12\x1a\n" +
+	"\bexchange\x18\x01 \x01(\tR\bexchange\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04base\x18\x04 \x01(\tR\x04base\x12\x14\n" +
+	"\x05quote\x18\x05 \x01(\tR\x05quote\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x06 \x01(\x03R\n" +
+	"multiplier\x12\x1a\n" +
+	"\bcontract\x18\a \x01(\tR\bcontract\x12\x16\n" +
+	"\x06settle\x18\b \x01(\tR\x06settle\x122\n" +
+	"\x06expiry\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x06expiry\x12\x16\n" +
+	"\x06active\x18\n" +
+	" \x01(\bR\x06active\x127\n" +
+	"\tlisted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\blistedAt\x12;\n" +
+	"\vdelisted_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"delistedAt\x12)\n" +
+	"\x05specs\x18\r \x01(\v2\x13.tickersvc.v1.SpecsR\x05specs\"\xde\x01\n" +
+	"\n" +
+	"Membership\x12\x12\n" +
+	"\x04slug\x18\x01 \x01(\tR\x04slug\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x16\n" +
+	"\x06target\x18\x04 \x01(\tR\x06target\x12\x18\n" +
+	"\asegment\x18\x05 \x01(\tR\asegment\x12\x12\n" +
+	"\x04spot\x18\x06 \x01(\tR\x04spot\x12\x18\n" +
+	"\afutures\x18\a \x01(\tR\afutures\x120\n" +
+	"\x05since\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05since\"\x97\x01\n" +
+	"\x05Asset\x12\x14\n" +
+	"\x05asset\x18\x01 \x01(\tR\x05asset\x12\x18\n" +
+	"\atickers\x18\x02 \x03(\tR\atickers\x12.\n" +
+	"\amarkets\x18\x03 \x03(\v2\x14.tickersvc.v1.MarketR\amarkets\x12.\n" +
+	"\x05lists\x18\x04 \x03(\v2\x18.tickersvc.v1.MembershipR\x05lists\"\xc3\x02\n" +
+	"\x11GetMarketsRequest\x12\x1a\n" +
+	"\bexchange\x18\x01 \x01(\tR\bexchange\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12\x1c\n" +
+	"\texchanges\x18\x03 \x03(\tR\texchanges\x12\x14\n" +
+	"\x05bases\x18\x04 \x03(\tR\x05bases\x12\x16\n" +
+	"\x06quotes\x18\x05 \x03(\tR\x06quotes\x12\x12\n" +
+	"\x04type\x18\x06 \x01(\tR\x04type\x12\x1c\n" +
+	"\tcontracts\x18\a \x03(\tR\tcontracts\x12\x1b\n" +
+	"\x06active\x18\b \x01(\bH\x00R\x06active\x88\x01\x01\x12\x12\n" +
+	"\x04sort\x18\t \x01(\tR\x04sort\x12\x12\n" +
+	"\x04desc\x18\n" +
+	" \x01(\bR\x04desc\x12\x14\n" +
+	"\x05limit\x18\v \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\f \x01(\tR\x06cursorB\t\n" +
+	"\a_active\"e\n" +
+	"\x12GetMarketsResponse\x12.\n" +
+	"\amarkets\x18\x01 \x03(\v2\x14.tickersvc.v1.MarketR\amarkets\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"w\n" +
+	"\x11WatchListsRequest\x12\x14\n" +
+	"\x05slugs\x18\x01 \x03(\tR\x05slugs\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12\x18\n" +
+	"\ainitial\x18\x03 \x01(\bR\ainitial\x12\x1a\n" +
+	"\bnotation\x18\x04 \x01(\tR\bnotation\"\x86\x02\n" +
+	"\n" +
+	"ListChange\x12\x1e\n" +
+	"\n" +
+	"generation\x18\x01 \x01(\x03R\n" +
+	"generation\x12\x12\n" +
+	"\x04slug\x18\x02 \x01(\tR\x04slug\x12\x1a\n" +
+	"\bsnapshot\x18\x03 \x01(\bR\bsnapshot\x12%\n" +
+	"\x04rows\x18\x04 \x03(\v2\x11.tickersvc.v1.RowR\x04rows\x12'\n" +
+	"\x05added\x18\x05 \x03(\v2\x11.tickersvc.v1.RowR\x05added\x12+\n" +
+	"\aremoved\x18\x06 \x03(\v2\x11.tickersvc.v1.RowR\aremoved\x12+\n" +
+	"\achanged\x18\a \x03(\v2\x11.tickersvc.v1.RowR\achanged2\x81\x03\n" +
+	"\rTickerService\x12F\n" +
+	"\aGetList\x12\x1c.tickersvc.v1.GetListRequest\x1a\x1d.tickersvc.v1.GetListResponse\x12L\n" +
+	"\tListLists\x12\x1e.tickersvc.v1.ListListsRequest\x1a\x1f.tickersvc.v1.ListListsResponse\x12>\n" +
+	"\bGetAsset\x12\x1d.tickersvc.v1.GetAssetRequest\x1a\x13.tickersvc.v1.Asset\x12O\n" +
+	"\n" +
+	"GetMarkets\x12\x1f.tickersvc.v1.GetMarketsRequest\x1a .tickersvc.v1.GetMarketsResponse\x12I\n" +
+	"\n" +
+	"WatchLists\x12\x1f.tickersvc.v1.WatchListsRequest\x1a\x18.tickersvc.v1.ListChange0\x01BFZDgithub.com/berezovskyivalerii/tickersvc/api/tickersvc/v1;tickersvcv1b\x06proto3"
+
+var (
+	file_tickersvc_proto_rawDescOnce sync.Once
+	file_tickersvc_proto_rawDescData []byte
+)
+
+func file_tickersvc_proto_rawDescGZIP() []byte {
+	file_tickersvc_proto_rawDescOnce.Do(func() {
+		file_tickersvc_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_tickersvc_proto_rawDesc), len(file_tickersvc_proto_rawDesc)))
+	})
+	return file_tickersvc_proto_rawDescData
+}
+
+var file_tickersvc_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_tickersvc_proto_goTypes = []any{
+	(*Row)(nil),                   // 0: tickersvc.v1.Row
+	(*ListMeta)(nil),              // 1: tickersvc.v1.ListMeta
+	(*RowsFilter)(nil),            // 2: tickersvc.v1.RowsFilter
+	(*GetListRequest)(nil),        // 3: tickersvc.v1.GetListRequest
+	(*GetListResponse)(nil),       // 4: tickersvc.v1.GetListResponse
+	(*ListListsRequest)(nil),      // 5: tickersvc.v1.ListListsRequest
+	(*ListListsResponse)(nil),     // 6: tickersvc.v1.ListListsResponse
+	(*GetAssetRequest)(nil),       // 7: tickersvc.v1.GetAssetRequest
+	(*Specs)(nil),                 // 8: tickersvc.v1.Specs
+	(*Market)(nil),                // 9: tickersvc.v1.Market
+	(*Membership)(nil),            // 10: tickersvc.v1.Membership
+	(*Asset)(nil),                 // 11: tickersvc.v1.Asset
+	(*GetMarketsRequest)(nil),     // 12: tickersvc.v1.GetMarketsRequest
+	(*GetMarketsResponse)(nil),    // 13: tickersvc.v1.GetMarketsResponse
+	(*WatchListsRequest)(nil),     // 14: tickersvc.v1.WatchListsRequest
+	(*ListChange)(nil),            // 15: tickersvc.v1.ListChange
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+}
+var file_tickersvc_proto_depIdxs = []int32{
+	16, // 0: tickersvc.v1.ListMeta.updated_at:type_name -> google.protobuf.Timestamp
+	2,  // 1: tickersvc.v1.GetListRequest.filter:type_name -> tickersvc.v1.RowsFilter
+	1,  // 2: tickersvc.v1.GetListResponse.meta:type_name -> tickersvc.v1.ListMeta
+	0,  // 3: tickersvc.v1.GetListResponse.rows:type_name -> tickersvc.v1.Row
+	1,  // 4: tickersvc.v1.ListListsResponse.lists:type_name -> tickersvc.v1.ListMeta
+	16, // 5: tickersvc.v1.Market.expiry:type_name -> google.protobuf.Timestamp
+	16, // 6: tickersvc.v1.Market.listed_at:type_name -> google.protobuf.Timestamp
+	16, // 7: tickersvc.v1.Market.delisted_at:type_name -> google.protobuf.Timestamp
+	8,  // 8: tickersvc.v1.Market.specs:type_name -> tickersvc.v1.Specs
+	16, // 9: tickersvc.v1.Membership.since:type_name -> google.protobuf.Timestamp
+	9,  // 10: tickersvc.v1.Asset.markets:type_name -> tickersvc.v1.Market
+	10, // 11: tickersvc.v1.Asset.lists:type_name -> tickersvc.v1.Membership
+	9,  // 12: tickersvc.v1.GetMarketsResponse.markets:type_name -> tickersvc.v1.Market
+	0,  // 13: tickersvc.v1.ListChange.rows:type_name -> tickersvc.v1.Row
+	0,  // 14: tickersvc.v1.ListChange.added:type_name -> tickersvc.v1.Row
+	0,  // 15: tickersvc.v1.ListChange.removed:type_name -> tickersvc.v1.Row
+	0,  // 16: tickersvc.v1.ListChange.changed:type_name -> tickersvc.v1.Row
+	3,  // 17: tickersvc.v1.TickerService.GetList:input_type -> tickersvc.v1.GetListRequest
+	5,  // 18: tickersvc.v1.TickerService.ListLists:input_type -> tickersvc.v1.ListListsRequest
+	7,  // 19: tickersvc.v1.TickerService.GetAsset:input_type -> tickersvc.v1.GetAssetRequest
+	12, // 20: tickersvc.v1.TickerService.GetMarkets:input_type -> tickersvc.v1.GetMarketsRequest
+	14, // 21: tickersvc.v1.TickerService.WatchLists:input_type -> tickersvc.v1.WatchListsRequest
+	4,  // 22: tickersvc.v1.TickerService.GetList:output_type -> tickersvc.v1.GetListResponse
+	6,  // 23: tickersvc.v1.TickerService.ListLists:output_type -> tickersvc.v1.ListListsResponse
+	11, // 24: tickersvc.v1.TickerService.GetAsset:output_type -> tickersvc.v1.Asset
+	13, // 25: tickersvc.v1.TickerService.GetMarkets:output_type -> tickersvc.v1.GetMarketsResponse
+	15, // 26: tickersvc.v1.TickerService.WatchLists:output_type -> tickersvc.v1.ListChange
+	22, // [22:27] is the sub-list for method output_type
+	17, // [17:22] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
+}
+
+func init() { file_tickersvc_proto_init() }
+func file_tickersvc_proto_init() {
+	if File_tickersvc_proto != nil {
+		return
+	}
+	file_tickersvc_proto_msgTypes[0].OneofWrappers = []any{}
+	file_tickersvc_proto_msgTypes[2].OneofWrappers = []any{}
+	file_tickersvc_proto_msgTypes[12].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_tickersvc_proto_rawDesc), len(file_tickersvc_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_tickersvc_proto_goTypes,
+		DependencyIndexes: file_tickersvc_proto_depIdxs,
+		MessageInfos:      file_tickersvc_proto_msgTypes,
+	}.Build()
+	File_tickersvc_proto = out.File
+	file_tickersvc_proto_goTypes = nil
+	file_tickersvc_proto_depIdxs = nil
+}
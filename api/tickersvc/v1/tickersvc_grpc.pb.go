@@ -0,0 +1,291 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: tickersvc.proto
+
+// gRPC API tickersvc: те же данные, что и REST (/api/lists, /api/assets, /api/markets),
+// но строками списков, а не текстом "SPOT, FUTURES". Ключ — как у HTTP: metadata x-api-key
+// или authorization: Bearer <key>.
+
+package tickersvcv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	TickerService_GetList_FullMethodName    = "/tickersvc.v1.TickerService/GetList"
+	TickerService_ListLists_FullMethodName  = "/tickersvc.v1.TickerService/ListLists"
+	TickerService_GetAsset_FullMethodName   = "/tickersvc.v1.TickerService/GetAsset"
+	TickerService_GetMarkets_FullMethodName = "/tickersvc.v1.TickerService/GetMarkets"
+	TickerService_WatchLists_FullMethodName = "/tickersvc.v1.TickerService/WatchLists"
+)
+
+// TickerServiceClient is the client API for TickerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TickerServiceClient interface {
+	// Строки одного списка или сегмента (как GET /api/lists/:slug); с filter — страница.
+	GetList(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (*GetListResponse, error)
+	// Метаданные списков и сегментов текущего (или заданного) поколения.
+	ListLists(ctx context.Context, in *ListListsRequest, opts ...grpc.CallOption) (*ListListsResponse, error)
+	// Карточка актива (как GET /api/assets/:base).
+	GetAsset(ctx context.Context, in *GetAssetRequest, opts ...grpc.CallOption) (*Asset, error)
+	// Рынки: фильтры и курсор (GET /api/markets) или один символ биржи (GET /api/markets/:exchange/:symbol).
+	GetMarkets(ctx context.Context, in *GetMarketsRequest, opts ...grpc.CallOption) (*GetMarketsResponse, error)
+	// Изменения списков по каждому новому поколению; первым сообщением — снимок (initial).
+	WatchLists(ctx context.Context, in *WatchListsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListChange], error)
+}
+
+type tickerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTickerServiceClient(cc grpc.ClientConnInterface) TickerServiceClient {
+	return &tickerServiceClient{cc}
+}
+
+func (c *tickerServiceClient) GetList(ctx context.Context, in *GetListRequest, opts ...grpc.CallOption) (*GetListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetListResponse)
+	err := c.cc.Invoke(ctx, TickerService_GetList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tickerServiceClient) ListLists(ctx context.Context, in *ListListsRequest, opts ...grpc.CallOption) (*ListListsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListListsResponse)
+	err := c.cc.Invoke(ctx, TickerService_ListLists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tickerServiceClient) GetAsset(ctx context.Context, in *GetAssetRequest, opts ...grpc.CallOption) (*Asset, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Asset)
+	err := c.cc.Invoke(ctx, TickerService_GetAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tickerServiceClient) GetMarkets(ctx context.Context, in *GetMarketsRequest, opts ...grpc.CallOption) (*GetMarketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMarketsResponse)
+	err := c.cc.Invoke(ctx, TickerService_GetMarkets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tickerServiceClient) WatchLists(ctx context.Context, in *WatchListsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ListChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TickerService_ServiceDesc.Streams[0], TickerService_WatchLists_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchListsRequest, ListChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TickerService_WatchListsClient = grpc.ServerStreamingClient[ListChange]
+
+// TickerServiceServer is the server API for TickerService service.
+// All implementations must embed UnimplementedTickerServiceServer
+// for forward compatibility.
+type TickerServiceServer interface {
+	// Строки одного списка или сегмента (как GET /api/lists/:slug); с filter — страница.
+	GetList(context.Context, *GetListRequest) (*GetListResponse, error)
+	// Метаданные списков и сегментов текущего (или заданного) поколения.
+	ListLists(context.Context, *ListListsRequest) (*ListListsResponse, error)
+	// Карточка актива (как GET /api/assets/:base).
+	GetAsset(context.Context, *GetAssetRequest) (*Asset, error)
+	// Рынки: фильтры и курсор (GET /api/markets) или один символ биржи (GET /api/markets/:exchange/:symbol).
+	GetMarkets(context.Context, *GetMarketsRequest) (*GetMarketsResponse, error)
+	// Изменения списков по каждому новому поколению; первым сообщением — снимок (initial).
+	WatchLists(*WatchListsRequest, grpc.ServerStreamingServer[ListChange]) error
+	mustEmbedUnimplementedTickerServiceServer()
+}
+
+// UnimplementedTickerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTickerServiceServer struct{}
+
+func (UnimplementedTickerServiceServer) GetList(context.Context, *GetListRequest) (*GetListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetList not implemented")
+}
+func (UnimplementedTickerServiceServer) ListLists(context.Context, *ListListsRequest) (*ListListsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLists not implemented")
+}
+func (UnimplementedTickerServiceServer) GetAsset(context.Context, *GetAssetRequest) (*Asset, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAsset not implemented")
+}
+func (UnimplementedTickerServiceServer) GetMarkets(context.Context, *GetMarketsRequest) (*GetMarketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMarkets not implemented")
+}
+func (UnimplementedTickerServiceServer) WatchLists(*WatchListsRequest, grpc.ServerStreamingServer[ListChange]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchLists not implemented")
+}
+func (UnimplementedTickerServiceServer) mustEmbedUnimplementedTickerServiceServer() {}
+func (UnimplementedTickerServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeTickerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TickerServiceServer will
+// result in compilation errors.
+type UnsafeTickerServiceServer interface {
+	mustEmbedUnimplementedTickerServiceServer()
+}
+
+func RegisterTickerServiceServer(s grpc.ServiceRegistrar, srv TickerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTickerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TickerService_ServiceDesc, srv)
+}
+
+func _TickerService_GetList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TickerServiceServer).GetList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TickerService_GetList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TickerServiceServer).GetList(ctx, req.(*GetListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TickerService_ListLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TickerServiceServer).ListLists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TickerService_ListLists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TickerServiceServer).ListLists(ctx, req.(*ListListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TickerService_GetAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TickerServiceServer).GetAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TickerService_GetAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TickerServiceServer).GetAsset(ctx, req.(*GetAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TickerService_GetMarkets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMarketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TickerServiceServer).GetMarkets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TickerService_GetMarkets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TickerServiceServer).GetMarkets(ctx, req.(*GetMarketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TickerService_WatchLists_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchListsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TickerServiceServer).WatchLists(m, &grpc.GenericServerStream[WatchListsRequest, ListChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TickerService_WatchListsServer = grpc.ServerStreamingServer[ListChange]
+
+// TickerService_ServiceDesc is the grpc.ServiceDesc for TickerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var TickerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tickersvc.v1.TickerService",
+	HandlerType: (*TickerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetList",
+			Handler:    _TickerService_GetList_Handler,
+		},
+		{
+			MethodName: "ListLists",
+			Handler:    _TickerService_ListLists_Handler,
+		},
+		{
+			MethodName: "GetAsset",
+			Handler:    _TickerService_GetAsset_Handler,
+		},
+		{
+			MethodName: "GetMarkets",
+			Handler:    _TickerService_GetMarkets_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchLists",
+			Handler:       _TickerService_WatchLists_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "tickersvc.proto",
+}
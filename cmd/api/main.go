@@ -1,20 +1,72 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
 
 	"github.com/berezovskyivalerii/tickersvc/internal/app"
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
 )
 
+// shutdownTimeout — сколько ждём незавершённые запросы и стримы при остановке
+const shutdownTimeout = 10 * time.Second
+
 func main() {
-	port := os.Getenv("PORT")
-	if port == "" { port = "8080" }
+	path := flag.String("config", os.Getenv("CONFIG_FILE"), "path to YAML/JSON config (env overrides file)")
+	flag.Parse()
+
+	cfg, err := config.Load(*path)
+	if err != nil {
+		log.Fatalf("config: %v", err)
+	}
 
-	r, err := app.Build()
+	r, gs, err := app.Build(config.NewStore(*path, cfg))
 	if err != nil { log.Fatal(err) }
 
-	if err := r.Run(":" + port); err != nil {
+	// SIGHUP занят перечитыванием конфига, останавливаемся по SIGINT/SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{Addr: ":" + strconv.Itoa(cfg.HTTP.Port), Handler: r}
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+
+	select {
+	case err := <-errc:
 		log.Fatal(err)
+	case <-ctx.Done():
+	}
+
+	sctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(sctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("http shutdown: %v", err)
+	}
+	if gs != nil {
+		stopGRPC(sctx, gs)
+	}
+}
+
+// stopGRPC ждёт активные RPC; WatchLists бесконечен, поэтому по таймауту рвём соединения.
+func stopGRPC(ctx context.Context, gs *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		gs.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		gs.Stop()
 	}
 }
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+
+	pgrepo "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/postgres"
+	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	marketsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+// как ck_list_defs_mode для list_defs.segment
+var nameRe = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+func (c *cli) defs(ctx context.Context, args []string) error {
+	if len(args) == 0 || args[0] != "add" {
+		return usageErr("defs: want add")
+	}
+	return c.defsAdd(ctx, args[1:])
+}
+
+// defs add [slug] -source s (-target t | -segment name -expr "...") [-futures-kinds k1,k2]
+// slug по умолчанию — <source>_to_<target> или <source>_<segment>. Строки появятся после `tickerctl rebuild`.
+func (c *cli) defsAdd(ctx context.Context, args []string) error {
+	fs := c.flags("defs add")
+	source := fs.String("source", "", "source exchange slug")
+	target := fs.String("target", "", "target exchange slug (target list)")
+	segment := fs.String("segment", "", "segment name (segment list)")
+	expr := fs.String("expr", "", `segment expression, e.g. "S & U & !(C | H)"`)
+	kindsRaw := fs.String("futures-kinds", "", "linear_perp,inverse_perp,delivery (default linear_perp)")
+	pos, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) > 1 {
+		return usageErr("defs add: want at most one slug")
+	}
+	src := strings.ToLower(strings.TrimSpace(*source))
+	tgt := strings.ToLower(strings.TrimSpace(*target))
+	seg := strings.ToLower(strings.TrimSpace(*segment))
+	if src == "" {
+		return usageErr("defs add: -source is required")
+	}
+	if (tgt == "") == (seg == "") {
+		return usageErr("defs add: exactly one of -target or -segment is required")
+	}
+	if tgt != "" && *expr != "" {
+		return usageErr("defs add: -expr applies to segments only")
+	}
+	var kinds []marketsdom.ContractKind
+	if strings.TrimSpace(*kindsRaw) != "" {
+		if kinds, err = marketsdom.ParseContractKinds(*kindsRaw); err != nil {
+			return usageErr("defs add: %v", err)
+		}
+	}
+
+	kind, slug := "target", src+"_to_"+tgt
+	if seg != "" {
+		kind, slug = "segment", src+"_"+seg
+		if !nameRe.MatchString(seg) {
+			return usageErr("defs add: segment name must match %s", nameRe)
+		}
+		if strings.TrimSpace(*expr) == "" {
+			return usageErr("defs add: -expr is required for a segment")
+		}
+		if _, err := listsuc.ValidateSegmentExpr(*expr); err != nil {
+			return usageErr("defs add: expr: %v", err)
+		}
+	}
+	if len(pos) == 1 {
+		slug = strings.ToLower(pos[0])
+	}
+	if !nameRe.MatchString(slug) {
+		return usageErr("defs add: slug must match %s", nameRe)
+	}
+
+	db, err := c.open()
+	if err != nil {
+		return err
+	}
+	repo := pgrepo.NewListDefsRepo(db)
+	var id int16
+	if kind == "segment" {
+		id, err = repo.CreateSegment(ctx, listsdom.SegmentDef{
+			Slug: slug, SourceSlug: src, Segment: seg, Expr: strings.TrimSpace(*expr), FuturesKinds: kinds,
+		})
+	} else {
+		id, err = repo.Create(ctx, listsdom.Def{Slug: slug, SourceSlug: src, TargetSlug: tgt, FuturesKinds: kinds})
+	}
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSLUG\tKIND")
+	fmt.Fprintf(w, "%d\t%s\t%s\n", id, slug, kind)
+	_ = w.Flush()
+	rebuild := "lists -source " + src + " -target " + tgt
+	if kind == "segment" {
+		rebuild = "segments -source " + src
+	}
+	fmt.Fprintf(&b, "\nrows appear after: tickerctl rebuild %s\n", rebuild)
+	return c.emit(map[string]any{"id": id, "slug": slug, "kind": kind}, b.String())
+}
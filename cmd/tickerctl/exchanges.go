@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	pgrepo "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/postgres"
+)
+
+// exchanges [list] | exchanges enable|disable <slug>... — exchanges.is_active.
+// Запущенный API берёт набор фетчеров при старте: изменения подхватятся после рестарта.
+func (c *cli) exchanges(ctx context.Context, args []string) error {
+	fs := c.flags("exchanges")
+	pos, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	var on bool
+	switch {
+	case len(pos) == 0 || pos[0] == "list":
+		if len(pos) > 1 {
+			return usageErr("exchanges list: unexpected argument %q", pos[1])
+		}
+	case pos[0] == "enable" || pos[0] == "disable":
+		if len(pos) == 1 {
+			return usageErr("exchanges %s: want at least one slug", pos[0])
+		}
+		on = pos[0] == "enable"
+	default:
+		return usageErr("exchanges: unknown subcommand %q", pos[0])
+	}
+
+	db, err := c.open()
+	if err != nil {
+		return err
+	}
+	repo := pgrepo.NewExchangesRepo(db)
+	if len(pos) > 1 {
+		for _, slug := range pos[1:] {
+			if err := repo.SetActiveBySlug(ctx, strings.ToLower(slug), on); err != nil {
+				return err
+			}
+		}
+	}
+	list, err := repo.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	type item struct {
+		ID     int16  `json:"id"`
+		Slug   string `json:"slug"`
+		Name   string `json:"name"`
+		Active bool   `json:"active"`
+	}
+	items := make([]item, 0, len(list))
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSLUG\tNAME\tACTIVE")
+	for _, e := range list {
+		items = append(items, item(e))
+		fmt.Fprintf(w, "%d\t%s\t%s\t%t\n", e.ID, e.Slug, e.Name, e.Active)
+	}
+	_ = w.Flush()
+	if len(pos) > 1 {
+		b.WriteString("\nthe running API picks up the change after a restart\n")
+	}
+	return c.emit(items, b.String())
+}
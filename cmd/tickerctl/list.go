@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	pgrepo "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/postgres"
+	listsfmt "github.com/berezovskyivalerii/tickersvc/internal/adapter/presenter/lists"
+	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	"github.com/berezovskyivalerii/tickersvc/internal/pkg/symbols"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+func (c *cli) list(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageErr("list: want show or diff")
+	}
+	switch args[0] {
+	case "show":
+		return c.listShow(ctx, args[1:])
+	case "diff":
+		return c.listDiff(ctx, args[1:])
+	}
+	return usageErr("list: unknown subcommand %q", args[0])
+}
+
+// list show <slug> [-generation N] [-notation n] — строки списка (по умолчанию текущее поколение).
+func (c *cli) listShow(ctx context.Context, args []string) error {
+	fs := c.flags("list show")
+	gen := fs.Int64("generation", 0, "generation id (default: current)")
+	notation := fs.String("notation", "raw", "raw | tradingview | ccxt")
+	pos, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return usageErr("list show: want exactly one slug")
+	}
+	n, err := symbols.ParseNotation(*notation)
+	if err != nil {
+		return usageErr("list show: %v", err)
+	}
+
+	db, err := c.open()
+	if err != nil {
+		return err
+	}
+	q := pgrepo.NewListsQueryRepo(db)
+	g, err := q.Generation(ctx, *gen)
+	if err != nil {
+		return err
+	}
+	ctx = listsdom.WithGeneration(ctx, g.ID)
+	meta, err := q.GetMeta(ctx, pos[0])
+	if err != nil {
+		return fmt.Errorf("%s: %w", pos[0], err)
+	}
+	rows, err := q.GetRowsBySlug(ctx, pos[0])
+	if err != nil {
+		return err
+	}
+	recs := listsfmt.FromRows("", listsfmt.Notate(rows, n))
+
+	m := listsfmt.FromMeta(meta)
+	m.Count = len(recs)
+	doc := struct {
+		Generation int64             `json:"generation,omitempty"`
+		Meta       listsfmt.Meta     `json:"meta"`
+		Items      []listsfmt.Record `json:"items"`
+	}{g.ID, m, recs}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s  %s  %s", meta.Slug, meta.Kind, describe(meta))
+	if g.ID != 0 {
+		fmt.Fprintf(&b, "  generation %d", g.ID)
+	}
+	fmt.Fprintf(&b, "  %d rows\n\n", len(recs))
+	w := tabwriter.NewWriter(&b, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "SPOT\tFUTURES\tVOLUME_USD")
+	for _, r := range recs {
+		vol := "-"
+		if r.VolumeUSD != nil {
+			vol = strconv.FormatFloat(*r.VolumeUSD, 'f', 0, 64)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.Spot, r.Futures, vol)
+	}
+	_ = w.Flush()
+	return c.emit(doc, b.String())
+}
+
+func describe(m listsdom.Meta) string {
+	if m.Kind == "segment" {
+		return fmt.Sprintf("%s %s = %s", m.SourceSlug, m.Segment, m.Expr)
+	}
+	return m.SourceSlug + " → " + m.TargetSlug
+}
+
+// list diff <slug> [-from N] [-to M]
+// Без -from — что изменит пересборка по текущим рынкам (как POST /api/preview со slug),
+// с -from — разница двух опубликованных поколений (-to по умолчанию текущее).
+func (c *cli) listDiff(ctx context.Context, args []string) error {
+	fs := c.flags("list diff")
+	from := fs.Int64("from", 0, "old generation id (default: diff against a fresh rebuild)")
+	to := fs.Int64("to", 0, "new generation id (default: current; needs -from)")
+	pos, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return usageErr("list diff: want exactly one slug")
+	}
+	if *to != 0 && *from == 0 {
+		return usageErr("list diff: -to needs -from")
+	}
+	slug := pos[0]
+
+	db, err := c.open()
+	if err != nil {
+		return err
+	}
+	q := pgrepo.NewListsQueryRepo(db)
+
+	var d listsuc.PreviewDiff
+	if *from == 0 {
+		if d, err = c.rebuildDiff(ctx, q, slug); err != nil {
+			return err
+		}
+	} else {
+		side := func(id int64) ([]listsuc.Row, error) {
+			if _, err := q.Generation(ctx, id); err != nil {
+				return nil, err
+			}
+			gctx := listsdom.WithGeneration(ctx, id)
+			if _, err := q.GetMeta(gctx, slug); err != nil {
+				return nil, fmt.Errorf("%s: %w", slug, err)
+			}
+			rows, err := q.GetRowsBySlug(gctx, slug)
+			return listsuc.FromDomainRows(rows), err
+		}
+		old, err := side(*from)
+		if err != nil {
+			return err
+		}
+		cur, err := side(*to)
+		if err != nil {
+			return err
+		}
+		d = listsuc.DiffRows(old, cur)
+	}
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 2, 2, ' ', 0)
+	for _, r := range d.Added {
+		fmt.Fprintf(w, "+\t%s\t%s\n", r.Spot, r.Futures)
+	}
+	for _, r := range d.Removed {
+		fmt.Fprintf(w, "-\t%s\t%s\n", r.Spot, r.Futures)
+	}
+	for _, ch := range d.Changed {
+		fmt.Fprintf(w, "~\t%s\t%s → %s\n", ch.Spot, ch.From, ch.To)
+	}
+	_ = w.Flush()
+	fmt.Fprintf(&b, "%s: +%d -%d ~%d\n", slug, len(d.Added), len(d.Removed), len(d.Changed))
+	return c.emit(diffJSON(slug, *from, *to, d), b.String())
+}
+
+// rebuildDiff строит список по его правилу из list_defs и сравнивает с сохранённым.
+func (c *cli) rebuildDiff(ctx context.Context, q *pgrepo.ListsQueryRepo, slug string) (listsuc.PreviewDiff, error) {
+	meta, err := q.GetMeta(ctx, slug)
+	if err != nil {
+		return listsuc.PreviewDiff{}, fmt.Errorf("%s: %w", slug, err)
+	}
+	defs := pgrepo.NewListDefsRepo(c.db)
+	spec := listsuc.PreviewSpec{Source: meta.SourceSlug, Slug: slug}
+	if meta.Kind == "segment" {
+		spec.Segment = meta.Expr
+		segs, err := defs.SegmentDefs(ctx)
+		if err != nil {
+			return listsuc.PreviewDiff{}, err
+		}
+		for _, d := range segs {
+			if d.Slug == slug {
+				spec.FuturesKinds = d.FuturesKinds
+			}
+		}
+	} else {
+		spec.Target = meta.TargetSlug
+		ds, err := defs.Find(ctx, &meta.SourceSlug, &meta.TargetSlug)
+		if err != nil {
+			return listsuc.PreviewDiff{}, err
+		}
+		for _, d := range ds {
+			if d.Slug == slug {
+				spec.FuturesKinds = d.FuturesKinds
+			}
+		}
+	}
+
+	uc := &listsuc.Interactor{
+		Defs:    defs,
+		Markets: pgrepo.NewMarketsRepo(c.db),
+		Lists:   pgrepo.NewListsRepo(c.db),
+		Aliases: pgrepo.NewAliasesRepo(c.db),
+	}
+	p, err := uc.Preview(ctx, spec)
+	if err != nil {
+		return listsuc.PreviewDiff{}, err
+	}
+	return *p.Diff, nil
+}
+
+type diffRow struct {
+	Spot    string `json:"spot"`
+	Futures string `json:"futures"`
+}
+
+type diffChange struct {
+	Spot string `json:"spot"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// diffJSON — diff в форме ответа /api/preview.
+func diffJSON(slug string, from, to int64, d listsuc.PreviewDiff) any {
+	rows := func(in []listsuc.Row) []diffRow {
+		out := make([]diffRow, 0, len(in))
+		for _, r := range in {
+			out = append(out, diffRow(r))
+		}
+		return out
+	}
+	ch := make([]diffChange, 0, len(d.Changed))
+	for _, c := range d.Changed {
+		ch = append(ch, diffChange(c))
+	}
+	return struct {
+		Slug    string       `json:"slug"`
+		From    int64        `json:"from,omitempty"`
+		To      int64        `json:"to,omitempty"`
+		Added   []diffRow    `json:"added"`
+		Removed []diffRow    `json:"removed"`
+		Changed []diffChange `json:"changed"`
+	}{slug, from, to, rows(d.Added), rows(d.Removed), ch}
+}
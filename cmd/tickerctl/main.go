@@ -0,0 +1,176 @@
+// tickerctl — утилита оператора: синк рынков, пересборка и просмотр списков, биржи и list_defs
+// напрямую через use case-ы и DB_DSN, без запущенного API.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	"github.com/berezovskyivalerii/tickersvc/internal/infra/store"
+)
+
+const usage = `usage: tickerctl [-config file] [-json] <command> [flags]
+
+commands:
+  sync [-exchange binance,okx]                  синк рынков (все активные биржи или выбранные)
+  rebuild lists|segments [-source s] [-target t] пересобрать списки/сегменты одним поколением
+  list show <slug> [-generation N] [-notation n] строки списка
+  list diff <slug> [-from N] [-to M]             без -from: что изменит пересборка; с -from: разница поколений
+  exchanges [list]                              биржи и is_active
+  exchanges enable|disable <slug>...            включить/выключить биржу
+  defs add <slug> -source s (-target t | -segment name -expr "S & U") [-futures-kinds k1,k2]
+
+DB_DSN и остальное — как у API (env или -config); ADMIN_API_KEY не нужен.
+-json — вывод JSON вместо таблицы (можно и после команды).
+`
+
+// errUsage — неверные аргументы: печатается usage, код выхода 2.
+var errUsage = errors.New("usage")
+
+func usageErr(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", errUsage, fmt.Sprintf(format, args...))
+}
+
+type cli struct {
+	cfgPath string
+	json    bool
+	out     io.Writer
+
+	cfg config.Config
+	db  *sql.DB
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	c := &cli{out: stdout}
+	fs := flag.NewFlagSet("tickerctl", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&c.cfgPath, "config", os.Getenv("CONFIG_FILE"), "path to YAML/JSON config (env overrides file)")
+	fs.BoolVar(&c.json, "json", false, "JSON output")
+	err := flagErr(fs.Parse(args))
+	if err == nil {
+		err = c.dispatch(ctx, fs.Args())
+	}
+	if c.db != nil {
+		c.db.Close()
+	}
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, flag.ErrHelp):
+		fmt.Fprint(stdout, usage)
+		return 0
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(stderr, "tickerctl: %v\n\n%s", err, usage)
+		return 2
+	}
+	fmt.Fprintf(stderr, "tickerctl: %v\n", err)
+	return 1
+}
+
+// flagErr — ошибка разбора флагов как errUsage (-h остаётся flag.ErrHelp).
+func flagErr(err error) error {
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return usageErr("%v", err)
+}
+
+func (c *cli) dispatch(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return usageErr("missing command")
+	}
+	cmd, rest := args[0], args[1:]
+	switch cmd {
+	case "sync":
+		return c.sync(ctx, rest)
+	case "rebuild":
+		return c.rebuild(ctx, rest)
+	case "list":
+		return c.list(ctx, rest)
+	case "exchanges":
+		return c.exchanges(ctx, rest)
+	case "defs":
+		return c.defs(ctx, rest)
+	case "help":
+		return flag.ErrHelp
+	}
+	return usageErr("unknown command %q", cmd)
+}
+
+// flags — FlagSet подкоманды с общим -json.
+func (c *cli) flags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.BoolVar(&c.json, "json", c.json, "JSON output")
+	return fs
+}
+
+// parse разбирает флаги вперемешку с позиционными аргументами (`list show slug -json`).
+func parse(fs *flag.FlagSet, args []string) ([]string, error) {
+	var pos []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, flagErr(err)
+		}
+		if fs.NArg() == 0 {
+			return pos, nil
+		}
+		pos = append(pos, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// open — конфигурация и БД по первому требованию: ошибки аргументов видны и без DB_DSN.
+func (c *cli) open() (*sql.DB, error) {
+	if c.db != nil {
+		return c.db, nil
+	}
+	cfg, err := config.LoadTool(c.cfgPath)
+	if err != nil {
+		return nil, fmt.Errorf("config: %w", err)
+	}
+	config.SetActive(config.NewStore(c.cfgPath, cfg)) // LoadQuotes, опции HTTP-клиентов бирж
+	db, err := store.OpenPostgres(cfg.DB.DSN)
+	if err != nil {
+		return nil, err
+	}
+	c.cfg, c.db = cfg, db
+	return db, nil
+}
+
+// emit: -json — v как JSON, иначе готовая таблица.
+func (c *cli) emit(v any, table string) error {
+	if c.json {
+		enc := json.NewEncoder(c.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+	_, err := io.WriteString(c.out, table)
+	return err
+}
+
+func csv(v string) []string {
+	var out []string
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
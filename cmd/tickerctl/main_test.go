@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	marketsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// Ошибки аргументов ловятся до открытия БД: без DB_DSN код выхода всё равно 2, а не 1.
+func TestRun_UsageErrors(t *testing.T) {
+	t.Setenv("DB_DSN", "")
+	t.Setenv("CONFIG_FILE", "")
+	cases := [][]string{
+		{},
+		{"nope"},
+		{"-bogus", "sync"},
+		{"sync", "extra"},
+		{"sync", "-nope"},
+		{"rebuild"},
+		{"rebuild", "everything"},
+		{"rebuild", "segments", "-target", "upbit"},
+		{"list"},
+		{"list", "show"},
+		{"list", "show", "a", "b"},
+		{"list", "show", "okx_to_upbit", "-notation", "weird"},
+		{"list", "diff", "okx_to_upbit", "-to", "3"},
+		{"exchanges", "enable"},
+		{"exchanges", "toggle", "okx"},
+		{"defs"},
+		{"defs", "add", "-target", "upbit"},
+		{"defs", "add", "-source", "okx"},
+		{"defs", "add", "-source", "okx", "-target", "upbit", "-segment", "x"},
+		{"defs", "add", "-source", "okx", "-segment", "seg9"},
+		{"defs", "add", "-source", "okx", "-segment", "seg9", "-expr", "S & Z"},
+		{"defs", "add", "-source", "okx", "-segment", "Bad-Name", "-expr", "S & U"},
+		{"defs", "add", "-source", "okx", "-target", "upbit", "-futures-kinds", "swap"},
+		{"defs", "add", "Bad Slug", "-source", "okx", "-target", "upbit"},
+	}
+	for _, args := range cases {
+		var stdout, stderr bytes.Buffer
+		if code := run(context.Background(), args, &stdout, &stderr); code != 2 {
+			t.Errorf("%q: exit %d, want 2 (stderr: %s)", args, code, stderr.String())
+		}
+		if !strings.Contains(stderr.String(), "usage: tickerctl") {
+			t.Errorf("%q: no usage in stderr: %s", args, stderr.String())
+		}
+	}
+}
+
+func TestRun_Help(t *testing.T) {
+	for _, args := range [][]string{{"help"}, {"-h"}} {
+		var stdout bytes.Buffer
+		if code := run(context.Background(), args, &stdout, io.Discard); code != 0 {
+			t.Errorf("%q: exit %d", args, code)
+		}
+		if !strings.HasPrefix(stdout.String(), "usage: tickerctl") {
+			t.Errorf("%q: stdout %q", args, stdout.String())
+		}
+	}
+}
+
+// Без DB_DSN корректная команда падает на конфиге с кодом 1.
+func TestRun_NoDSN(t *testing.T) {
+	t.Setenv("DB_DSN", "")
+	t.Setenv("CONFIG_FILE", "")
+	var stderr bytes.Buffer
+	if code := run(context.Background(), []string{"exchanges"}, io.Discard, &stderr); code != 1 {
+		t.Fatalf("exit %d, want 1 (stderr: %s)", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "config:") {
+		t.Errorf("stderr: %s", stderr.String())
+	}
+}
+
+func TestParse_Interleaved(t *testing.T) {
+	var c cli
+	fs := c.flags("list show")
+	n := fs.String("notation", "raw", "")
+	pos, err := parse(fs, []string{"okx_to_upbit", "-json", "-notation", "ccxt", "extra"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(pos, []string{"okx_to_upbit", "extra"}) || *n != "ccxt" || !c.json {
+		t.Fatalf("pos=%v notation=%s json=%v", pos, *n, c.json)
+	}
+	if _, err := parse(c.flags("x"), []string{"-h"}); err != flag.ErrHelp {
+		t.Fatalf("-h: %v", err)
+	}
+}
+
+type fakeFetcher struct {
+	id   int16
+	name string
+}
+
+func (f fakeFetcher) ExchangeID() int16 { return f.id }
+func (f fakeFetcher) Name() string      { return f.name }
+func (fakeFetcher) FetchSpot(context.Context) ([]marketsdom.Item, error) {
+	return nil, nil
+}
+func (fakeFetcher) FetchFutures(context.Context) ([]marketsdom.Item, error) {
+	return nil, nil
+}
+
+func TestPick(t *testing.T) {
+	all := []marketsdom.Fetcher{fakeFetcher{1, "binance"}, fakeFetcher{3, "okx"}, fakeFetcher{5, "upbit"}}
+
+	got, err := pick(all, nil)
+	if err != nil || len(got) != 3 {
+		t.Fatalf("all: %v %v", got, err)
+	}
+	got, err = pick(all, csv(" UPBIT, okx,"))
+	if err != nil || len(got) != 2 || got[0].Name() != "upbit" || got[1].Name() != "okx" {
+		t.Fatalf("subset: %v %v", got, err)
+	}
+	_, err = pick(all, []string{"bybit"})
+	if err == nil || !strings.Contains(err.Error(), "binance, okx, upbit") {
+		t.Fatalf("inactive: %v", err)
+	}
+}
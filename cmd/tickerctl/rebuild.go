@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+
+	pgrepo "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/postgres"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+// rebuild lists|segments|all [-source a,b] [-target t] — пересборка из текущих рынков без синка
+// (как POST /update с force=1, только без markets_sync). Совпавшие с сохранёнными списки не переписываются.
+func (c *cli) rebuild(ctx context.Context, args []string) error {
+	fs := c.flags("rebuild")
+	source := fs.String("source", "", "source exchange(s): lists use the first one, segments all of them")
+	target := fs.String("target", "", "target exchange (lists only)")
+	pos, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) != 1 {
+		return usageErr("rebuild: want lists, segments or all")
+	}
+	var spec listsuc.RebuildSpec
+	switch pos[0] {
+	case "lists":
+		spec.Targets = true
+	case "segments":
+		spec.Segments = true
+	case "all":
+		spec.Targets, spec.Segments = true, true
+	default:
+		return usageErr("rebuild: unknown kind %q (lists, segments or all)", pos[0])
+	}
+	if *target != "" && !spec.Targets {
+		return usageErr("rebuild: -target applies to lists only")
+	}
+	spec.SegmentSources = csv(*source)
+	if len(spec.SegmentSources) > 0 {
+		spec.Source = &spec.SegmentSources[0]
+	}
+	if t := csv(*target); len(t) > 0 {
+		spec.Target = &t[0]
+	}
+
+	db, err := c.open()
+	if err != nil {
+		return err
+	}
+	uc := &listsuc.Interactor{
+		Defs:    pgrepo.NewListDefsRepo(db),
+		Markets: pgrepo.NewMarketsRepo(db),
+		Lists:   pgrepo.NewListsRepo(db),
+		Aliases: pgrepo.NewAliasesRepo(db),
+	}
+	res, err := uc.RebuildAll(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	// тот же JSON, что у /update, без markets_sync
+	resp := map[string]any{}
+	if spec.Targets {
+		resp["lists_updated"] = res.Lists
+	}
+	if spec.Segments {
+		resp["segments_updated"] = res.Segments
+	}
+	if len(res.Skipped) > 0 {
+		resp["skipped"] = res.Skipped
+	}
+	if res.Generation.ID != 0 {
+		resp["generation"] = res.Generation.ID
+	}
+	return c.emit(resp, listsuc.FormatRebuild(res))
+}
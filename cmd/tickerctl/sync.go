@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	pgrepo "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/postgres"
+	"github.com/berezovskyivalerii/tickersvc/internal/app"
+	marketsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	marketsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/markets"
+)
+
+// sync [-exchange a,b] — синк рынков (как POST /admin/markets/sync), без пересборки списков.
+func (c *cli) sync(ctx context.Context, args []string) error {
+	fs := c.flags("sync")
+	only := fs.String("exchange", "", "comma-separated exchange slugs (default: all active)")
+	pos, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(pos) > 0 {
+		return usageErr("sync: unexpected argument %q", pos[0])
+	}
+
+	db, err := c.open()
+	if err != nil {
+		return err
+	}
+	active, err := pgrepo.NewExchangesRepo(db).ActiveMap(ctx)
+	if err != nil {
+		return err
+	}
+	fetchers, err := pick(app.Fetchers(c.cfg, active), csv(*only))
+	if err != nil {
+		return err
+	}
+
+	orc := &marketsuc.Orchestrator{
+		Repo:     pgrepo.NewMarketsRepo(db),
+		Fetchers: fetchers,
+		Timeout:  45 * time.Second,
+	}
+	sum, runErr := orc.RunAll(ctx)
+
+	id2name := make(map[int16]string, len(fetchers))
+	for _, f := range fetchers {
+		id2name[f.ExchangeID()] = f.Name()
+	}
+	// итог печатаем и при ошибке: часть бирж могла синкнуться
+	if err := c.emit(map[string]any{"summary": sum}, marketsuc.FormatSummary(sum, id2name)); err != nil {
+		return err
+	}
+	return runErr
+}
+
+// pick — фетчеры выбранных бирж; пусто — все. Выключенная или исключённая биржа — ошибка.
+func pick(all []marketsdom.Fetcher, names []string) ([]marketsdom.Fetcher, error) {
+	if len(names) == 0 {
+		return all, nil
+	}
+	by := make(map[string]marketsdom.Fetcher, len(all))
+	avail := make([]string, 0, len(all))
+	for _, f := range all {
+		by[f.Name()] = f
+		avail = append(avail, f.Name())
+	}
+	sort.Strings(avail)
+	out := make([]marketsdom.Fetcher, 0, len(names))
+	for _, n := range names {
+		f, ok := by[n]
+		if !ok {
+			return nil, fmt.Errorf("sync: exchange %q is unknown or inactive (active: %s)", n, strings.Join(avail, ", "))
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
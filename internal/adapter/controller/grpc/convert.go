@@ -0,0 +1,93 @@
+package grpcctrl
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	pb "github.com/berezovskyivalerii/tickersvc/api/tickersvc/v1"
+	ad "github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+func ts(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func tsPtr(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return ts(*t)
+}
+
+func str(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
+
+func toRow(r ldom.Row) *pb.Row {
+	return &pb.Row{
+		Spot: r.Spot, Futures: str(r.Futures), Source: r.Source, Base: r.Base, Quote: r.Quote, VolumeUsd: r.VolumeUSD,
+	}
+}
+
+func toRows(rows []ldom.Row) []*pb.Row {
+	out := make([]*pb.Row, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, toRow(r))
+	}
+	return out
+}
+
+func toMeta(m ldom.Meta) *pb.ListMeta {
+	return &pb.ListMeta{
+		Slug: m.Slug, Kind: m.Kind, Source: m.SourceSlug, Target: m.TargetSlug, Segment: m.Segment, Expr: m.Expr,
+		UpdatedAt: ts(m.UpdatedAt), Count: int32(m.Count),
+	}
+}
+
+func toMarket(m dm.Market) *pb.Market {
+	return &pb.Market{
+		Exchange:   m.Exchange,
+		Type:       string(m.Type),
+		Symbol:     m.Symbol,
+		Base:       m.Base,
+		Quote:      m.Quote,
+		Multiplier: m.Mult(),
+		Contract:   string(m.Kind()),
+		Settle:     m.Settle,
+		Expiry:     tsPtr(m.Expiry),
+		Active:     m.Active,
+		ListedAt:   ts(m.ListedAt),
+		DelistedAt: tsPtr(m.DelistedAt),
+		Specs: &pb.Specs{
+			ContractSize: string(m.Specs.ContractSize),
+			TickSize:     string(m.Specs.TickSize),
+			LotSize:      string(m.Specs.LotSize),
+			MinQty:       string(m.Specs.MinQty),
+			MinNotional:  string(m.Specs.MinNotional),
+			MaxLeverage:  string(m.Specs.MaxLeverage),
+		},
+	}
+}
+
+func toAsset(v ad.View) *pb.Asset {
+	out := &pb.Asset{Asset: v.Asset, Tickers: v.Tickers}
+	for _, m := range v.Markets {
+		out.Markets = append(out.Markets, toMarket(m))
+	}
+	for _, l := range v.Lists {
+		out.Lists = append(out.Lists, &pb.Membership{
+			Slug: l.Slug, Kind: l.Kind, Source: l.SourceSlug, Target: l.TargetSlug, Segment: l.Segment,
+			Spot: l.Spot, Futures: str(l.Futures), Since: ts(l.Since),
+		})
+	}
+	return out
+}
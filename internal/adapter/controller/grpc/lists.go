@@ -0,0 +1,116 @@
+package grpcctrl
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/berezovskyivalerii/tickersvc/api/tickersvc/v1"
+	listsfmt "github.com/berezovskyivalerii/tickersvc/internal/adapter/presenter/lists"
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	"github.com/berezovskyivalerii/tickersvc/internal/pkg/symbols"
+)
+
+func notation(s string) (symbols.Notation, error) {
+	n, err := symbols.ParseNotation(s)
+	if err != nil {
+		return "", status.Error(codes.InvalidArgument, "notation must be one of: raw, tradingview, ccxt")
+	}
+	return n, nil
+}
+
+// rowsFilter — те же правила, что у query-параметров /api/lists.
+func rowsFilter(f *pb.RowsFilter) (ldom.RowsFilter, error) {
+	out := ldom.RowsFilter{
+		Q:          strings.TrimSpace(f.GetQ()),
+		HasFutures: f.HasFutures,
+		Cursor:     strings.TrimSpace(f.GetCursor()),
+		Limit:      int(f.GetLimit()),
+	}
+	for _, q := range f.GetQuotes() {
+		if q = strings.TrimSpace(q); q != "" {
+			out.Quotes = append(out.Quotes, strings.ToUpper(q))
+		}
+	}
+	if v := f.GetMinVolumeUsd(); v < 0 || math.IsInf(v, 0) || math.IsNaN(v) {
+		return out, status.Error(codes.InvalidArgument, "min_volume_usd must be a non-negative number")
+	}
+	out.MinVolume = f.GetMinVolumeUsd()
+	switch v := strings.ToLower(strings.TrimSpace(f.GetSort())); v {
+	case "", ldom.SortSpot, ldom.SortVolume:
+		out.Sort = v
+	default:
+		return out, status.Error(codes.InvalidArgument, "sort must be one of: spot, volume")
+	}
+	if out.Limit < 0 {
+		return out, status.Error(codes.InvalidArgument, "limit must be a positive integer")
+	}
+	return out, nil
+}
+
+func (s *Server) GetList(ctx context.Context, req *pb.GetListRequest) (*pb.GetListResponse, error) {
+	slug := strings.TrimSpace(req.GetSlug())
+	if slug == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing slug")
+	}
+	n, err := notation(req.GetNotation())
+	if err != nil {
+		return nil, err
+	}
+	ctx, gen, err := s.pin(ctx, req.GetGeneration())
+	if err != nil {
+		return nil, err
+	}
+	meta, err := s.Lists.GetMeta(ctx, slug)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	out := &pb.GetListResponse{Meta: toMeta(meta), Generation: gen}
+
+	var rows []ldom.Row
+	if req.GetFilter() != nil {
+		f, err := rowsFilter(req.GetFilter())
+		if err != nil {
+			return nil, err
+		}
+		page, err := s.Lists.FindRowsBySlug(ctx, slug, f)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		rows, out.Total, out.NextCursor = page.Rows, int32(page.Total), page.NextCursor
+	} else if rows, err = s.Lists.GetRowsBySlug(ctx, slug); err != nil {
+		return nil, toStatus(err)
+	}
+	out.Rows = toRows(listsfmt.Notate(rows, n))
+	return out, nil
+}
+
+func (s *Server) ListLists(ctx context.Context, req *pb.ListListsRequest) (*pb.ListListsResponse, error) {
+	ml, ok := s.Lists.(ldom.MetaLister)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "lists source cannot list metas")
+	}
+	ctx, gen, err := s.pin(ctx, req.GetGeneration())
+	if err != nil {
+		return nil, err
+	}
+	metas, err := ml.ListMetas(ctx)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	out := &pb.ListListsResponse{Generation: gen}
+	for _, m := range metas {
+		if metaMatch(m, req.GetTarget(), req.GetSource(), req.GetKind()) {
+			out.Lists = append(out.Lists, toMeta(m))
+		}
+	}
+	return out, nil
+}
+
+func metaMatch(m ldom.Meta, target, source, kind string) bool {
+	eq := func(want, got string) bool { return want == "" || strings.EqualFold(strings.TrimSpace(want), got) }
+	return eq(target, m.TargetSlug) && eq(source, m.SourceSlug) && eq(kind, m.Kind)
+}
@@ -0,0 +1,139 @@
+// Package grpcctrl — gRPC API (api/tickersvc/v1) поверх тех же репозиториев и use case-ов, что и httpctrl.
+package grpcctrl
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/berezovskyivalerii/tickersvc/api/tickersvc/v1"
+	ad "github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+type AssetViewer interface {
+	View(ctx context.Context, base string) (ad.View, error)
+}
+
+// Server — реализация TickerService. Lists — обязателен; остальное nil → Unimplemented.
+type Server struct {
+	pb.UnimplementedTickerServiceServer
+
+	Lists   ldom.QueryRepo      // ListLists — если ещё и ldom.MetaLister
+	Gens    ldom.GenerationRepo // generation в запросах и ответах
+	Assets  AssetViewer
+	Markets dm.QueryRepo
+	Feed    *Feed // WatchLists
+}
+
+func (s *Server) Register(gs *grpc.Server) { pb.RegisterTickerServiceServer(gs, s) }
+
+// pin закрепляет чтение за поколением (0 — текущее) и возвращает его id; без Gens — 0.
+func (s *Server) pin(ctx context.Context, id int64) (context.Context, int64, error) {
+	if s.Gens == nil {
+		return ctx, 0, nil
+	}
+	if id < 0 {
+		return nil, 0, status.Error(codes.InvalidArgument, "generation must be a positive integer")
+	}
+	g, err := s.Gens.Generation(ctx, id)
+	if err != nil {
+		return nil, 0, toStatus(err)
+	}
+	return ldom.WithGeneration(ctx, g.ID), g.ID, nil
+}
+
+// toStatus — доменные ошибки в коды gRPC (как 400/404/500 у HTTP).
+func toStatus(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ldom.ErrNotFound), errors.Is(err, ldom.ErrGenerationNotFound), errors.Is(err, ad.ErrAssetNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ldom.ErrBadCursor), errors.Is(err, dm.ErrBadCursor), errors.Is(err, dm.ErrBadSort):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func (s *Server) GetAsset(ctx context.Context, req *pb.GetAssetRequest) (*pb.Asset, error) {
+	if s.Assets == nil {
+		return nil, status.Error(codes.Unimplemented, "assets are not configured")
+	}
+	v, err := s.Assets.View(ctx, req.GetBase())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toAsset(v), nil
+}
+
+func (s *Server) GetMarkets(ctx context.Context, req *pb.GetMarketsRequest) (*pb.GetMarketsResponse, error) {
+	if s.Markets == nil {
+		return nil, status.Error(codes.Unimplemented, "markets are not configured")
+	}
+	typ := dm.Type(strings.ToLower(strings.TrimSpace(req.GetType())))
+	switch typ {
+	case "", dm.TypeSpot, dm.TypeFutures:
+	default:
+		return nil, status.Error(codes.InvalidArgument, "type must be one of: spot, futures")
+	}
+
+	// один символ биржи — как /api/markets/:exchange/:symbol
+	if sym := strings.TrimSpace(req.GetSymbol()); sym != "" {
+		if strings.TrimSpace(req.GetExchange()) == "" {
+			return nil, status.Error(codes.InvalidArgument, "symbol needs exchange")
+		}
+		ms, err := s.Markets.GetMarkets(ctx, req.GetExchange(), sym)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		out := &pb.GetMarketsResponse{}
+		for _, m := range ms {
+			if typ == "" || m.Type == typ {
+				out.Markets = append(out.Markets, toMarket(m))
+			}
+		}
+		if len(out.Markets) == 0 {
+			return nil, status.Error(codes.NotFound, "market not found")
+		}
+		return out, nil
+	}
+
+	kinds, err := dm.ParseContractKinds(strings.Join(req.GetContracts(), ","))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "contract must be one of: linear_perp, inverse_perp, delivery")
+	}
+	if req.GetLimit() < 0 || req.GetLimit() > 1000 {
+		return nil, status.Error(codes.InvalidArgument, "limit must be 1..1000")
+	}
+	f := dm.Filter{
+		Exchanges: req.GetExchanges(),
+		Bases:     req.GetBases(),
+		Quotes:    req.GetQuotes(),
+		Type:      typ,
+		Contracts: kinds,
+		Active:    req.Active,
+		Sort:      dm.Sort(strings.ToLower(strings.TrimSpace(req.GetSort()))),
+		Desc:      req.GetDesc(),
+		Limit:     int(req.GetLimit()),
+		Cursor:    req.GetCursor(),
+	}
+	page, err := s.Markets.FindMarkets(ctx, f)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	out := &pb.GetMarketsResponse{NextCursor: page.NextCursor, Markets: make([]*pb.Market, 0, len(page.Items))}
+	for _, m := range page.Items {
+		out.Markets = append(out.Markets, toMarket(m))
+	}
+	return out, nil
+}
@@ -0,0 +1,362 @@
+package grpcctrl
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pb "github.com/berezovskyivalerii/tickersvc/api/tickersvc/v1"
+	ad "github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	"github.com/berezovskyivalerii/tickersvc/internal/infra/http/mw/adminauth"
+)
+
+func sp(s string) *string { return &s }
+
+// fakeLists — поколения списков: gen → slug → строки.
+type fakeLists struct {
+	mu    sync.Mutex
+	cur   int64
+	gens  map[int64]map[string][]ldom.Row
+	metas []ldom.Meta
+}
+
+func (f *fakeLists) rows(ctx context.Context) map[string][]ldom.Row {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := f.cur
+	if g, ok := ldom.GenerationFrom(ctx); ok {
+		id = g
+	}
+	return f.gens[id]
+}
+
+func (f *fakeLists) publish(id int64, lists map[string][]ldom.Row) {
+	f.mu.Lock()
+	f.gens[id], f.cur = lists, id
+	f.mu.Unlock()
+}
+
+func (f *fakeLists) Generation(ctx context.Context, id int64) (ldom.Generation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if id == 0 {
+		id = f.cur
+	}
+	if _, ok := f.gens[id]; !ok {
+		return ldom.Generation{}, ldom.ErrGenerationNotFound
+	}
+	return ldom.Generation{ID: id}, nil
+}
+
+func (f *fakeLists) GetTextBySlug(ctx context.Context, slug string) ([]string, error) {
+	return nil, nil
+}
+func (f *fakeLists) GetTextByTarget(ctx context.Context, t string) (map[string][]string, error) {
+	return nil, nil
+}
+func (f *fakeLists) GetAllText(ctx context.Context) (map[string]map[string][]string, error) {
+	return nil, nil
+}
+func (f *fakeLists) GetRowsBySlug(ctx context.Context, slug string) ([]ldom.Row, error) {
+	return f.rows(ctx)[slug], nil
+}
+func (f *fakeLists) GetRowsByTarget(ctx context.Context, t string) (map[string][]ldom.Row, error) {
+	return nil, nil
+}
+func (f *fakeLists) GetMeta(ctx context.Context, slug string) (ldom.Meta, error) {
+	for _, m := range f.metas {
+		if m.Slug == slug {
+			m.Count = len(f.rows(ctx)[slug])
+			return m, nil
+		}
+	}
+	return ldom.Meta{}, ldom.ErrNotFound
+}
+func (f *fakeLists) ListMetas(ctx context.Context) ([]ldom.Meta, error) { return f.metas, nil }
+func (f *fakeLists) FindRowsBySlug(ctx context.Context, slug string, fl ldom.RowsFilter) (ldom.RowsPage, error) {
+	rows := f.rows(ctx)[slug]
+	if fl.Cursor == "bad" {
+		return ldom.RowsPage{}, ldom.ErrBadCursor
+	}
+	p := ldom.RowsPage{Total: len(rows), Rows: rows}
+	if fl.Limit > 0 && fl.Limit < len(rows) {
+		p.Rows, p.NextCursor = rows[:fl.Limit], rows[fl.Limit-1].Spot
+	}
+	return p, nil
+}
+func (f *fakeLists) FindRowsByTarget(ctx context.Context, t string, fl ldom.RowsFilter) (ldom.RowsPage, error) {
+	return ldom.RowsPage{}, nil
+}
+
+type fakeMarkets struct{ items []dm.Market }
+
+func (f fakeMarkets) GetMarkets(ctx context.Context, exchange, symbol string) ([]dm.Market, error) {
+	var out []dm.Market
+	for _, m := range f.items {
+		if m.Exchange == exchange && m.Symbol == symbol {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+func (f fakeMarkets) FindMarkets(ctx context.Context, fl dm.Filter) (dm.Page, error) {
+	if fl.Sort != "" && fl.Sort != dm.SortSymbol {
+		return dm.Page{}, dm.ErrBadSort
+	}
+	var out []dm.Market
+	for _, m := range f.items {
+		if fl.Type == "" || m.Type == fl.Type {
+			out = append(out, m)
+		}
+	}
+	return dm.Page{Items: out}, nil
+}
+
+type fakeViewer struct{}
+
+func (fakeViewer) View(ctx context.Context, base string) (ad.View, error) {
+	if base != "BTC" {
+		return ad.View{}, ad.ErrAssetNotFound
+	}
+	return ad.View{Asset: "BTC", Tickers: []string{"BTC"},
+		Markets: []dm.Market{{Item: dm.Item{Type: dm.TypeSpot, Symbol: "KRW-BTC", Base: "BTC", Quote: "KRW"}, Exchange: "upbit"}},
+		Lists:   []ldom.Membership{{Slug: "okx_to_upbit", Kind: "target", SourceSlug: "okx", TargetSlug: "upbit", Spot: "BTC-USDT"}},
+	}, nil
+}
+
+type env struct {
+	client pb.TickerServiceClient
+	lists  *fakeLists
+	feed   *Feed
+}
+
+func start(t *testing.T) env {
+	t.Helper()
+	lists := &fakeLists{
+		cur: 1,
+		gens: map[int64]map[string][]ldom.Row{1: {
+			"okx_to_upbit": {
+				{Spot: "AAA-USDT", Futures: sp("AAA-USDT-SWAP"), Source: "okx", Base: "AAA", Quote: "USDT"},
+				{Spot: "BBB-USDT", Source: "okx", Base: "BBB", Quote: "USDT"},
+			},
+			"binance_seg1": {{Spot: "CCCUSDT", Source: "binance", Base: "CCC", Quote: "USDT"}},
+		}},
+		metas: []ldom.Meta{
+			{Slug: "binance_seg1", Kind: "segment", SourceSlug: "binance", Segment: "1"},
+			{Slug: "okx_to_upbit", Kind: "target", SourceSlug: "okx", TargetSlug: "upbit"},
+		},
+	}
+	feed := NewFeed()
+	auth := adminauth.New(func() string { return "k1" })
+	gs := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(auth.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(auth.StreamInterceptor()),
+	)
+	(&Server{
+		Lists: lists, Gens: lists, Assets: fakeViewer{}, Feed: feed,
+		Markets: fakeMarkets{items: []dm.Market{
+			{Item: dm.Item{Type: dm.TypeSpot, Symbol: "BTCUSDT", Base: "BTC", Quote: "USDT", Active: true}, Exchange: "binance"},
+			{Item: dm.Item{Type: dm.TypeFutures, Symbol: "BTCUSDT", Base: "BTC", Quote: "USDT", Active: true,
+				Specs: dm.Specs{TickSize: "0.1"}}, Exchange: "binance"},
+		}},
+	}).Register(gs)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return env{client: pb.NewTickerServiceClient(conn), lists: lists, feed: feed}
+}
+
+func authed() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer k1")
+}
+
+func code(err error) codes.Code { return status.Code(err) }
+
+func TestAuth(t *testing.T) {
+	e := start(t)
+	_, err := e.client.GetList(context.Background(), &pb.GetListRequest{Slug: "okx_to_upbit"})
+	if code(err) != codes.PermissionDenied {
+		t.Fatalf("no key: %v", err)
+	}
+	bad := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "nope")
+	if _, err := e.client.GetList(bad, &pb.GetListRequest{Slug: "okx_to_upbit"}); code(err) != codes.PermissionDenied {
+		t.Fatalf("bad key: %v", err)
+	}
+	ok := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "k1")
+	if _, err := e.client.GetList(ok, &pb.GetListRequest{Slug: "okx_to_upbit"}); err != nil {
+		t.Fatal(err)
+	}
+	stream, err := e.client.WatchLists(context.Background(), &pb.WatchListsRequest{})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if code(err) != codes.PermissionDenied {
+		t.Fatalf("stream without key: %v", err)
+	}
+}
+
+func TestGetList(t *testing.T) {
+	e := start(t)
+	resp, err := e.client.GetList(authed(), &pb.GetListRequest{Slug: "okx_to_upbit"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Generation != 1 || resp.Meta.GetTarget() != "upbit" || resp.Meta.GetCount() != 2 || len(resp.Rows) != 2 {
+		t.Fatalf("resp = %v", resp)
+	}
+	if r := resp.Rows[0]; r.Spot != "AAA-USDT" || r.Futures != "AAA-USDT-SWAP" || r.Base != "AAA" {
+		t.Fatalf("row = %v", r)
+	}
+	if resp.Rows[1].Futures != "" {
+		t.Fatalf("no futures must be empty, got %q", resp.Rows[1].Futures)
+	}
+
+	// нотация и страница
+	resp, err = e.client.GetList(authed(), &pb.GetListRequest{Slug: "okx_to_upbit", Notation: "ccxt", Filter: &pb.RowsFilter{Limit: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Total != 2 || resp.NextCursor == "" || len(resp.Rows) != 1 || resp.Rows[0].Spot != "AAA/USDT" {
+		t.Fatalf("page = %v", resp)
+	}
+
+	for name, tc := range map[string]struct {
+		req  *pb.GetListRequest
+		want codes.Code
+	}{
+		"unknown slug":   {&pb.GetListRequest{Slug: "nope"}, codes.NotFound},
+		"missing slug":   {&pb.GetListRequest{}, codes.InvalidArgument},
+		"old generation": {&pb.GetListRequest{Slug: "okx_to_upbit", Generation: 7}, codes.NotFound},
+		"bad notation":   {&pb.GetListRequest{Slug: "okx_to_upbit", Notation: "x"}, codes.InvalidArgument},
+		"bad sort":       {&pb.GetListRequest{Slug: "okx_to_upbit", Filter: &pb.RowsFilter{Sort: "x"}}, codes.InvalidArgument},
+		"bad cursor":     {&pb.GetListRequest{Slug: "okx_to_upbit", Filter: &pb.RowsFilter{Cursor: "bad"}}, codes.InvalidArgument},
+	} {
+		if _, err := e.client.GetList(authed(), tc.req); code(err) != tc.want {
+			t.Errorf("%s: %v, want %v", name, err, tc.want)
+		}
+	}
+}
+
+func TestListLists(t *testing.T) {
+	e := start(t)
+	resp, err := e.client.ListLists(authed(), &pb.ListListsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Lists) != 2 || resp.Generation != 1 {
+		t.Fatalf("all = %v", resp)
+	}
+	resp, err = e.client.ListLists(authed(), &pb.ListListsRequest{Target: "UPBIT"})
+	if err != nil || len(resp.Lists) != 1 || resp.Lists[0].Slug != "okx_to_upbit" {
+		t.Fatalf("by target = %v %v", resp, err)
+	}
+}
+
+func TestGetAssetAndMarkets(t *testing.T) {
+	e := start(t)
+	a, err := e.client.GetAsset(authed(), &pb.GetAssetRequest{Base: "BTC"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Asset != "BTC" || len(a.Markets) != 1 || a.Markets[0].Exchange != "upbit" || a.Lists[0].Futures != "" {
+		t.Fatalf("asset = %v", a)
+	}
+	if _, err := e.client.GetAsset(authed(), &pb.GetAssetRequest{Base: "NOPE"}); code(err) != codes.NotFound {
+		t.Fatalf("unknown asset: %v", err)
+	}
+
+	m, err := e.client.GetMarkets(authed(), &pb.GetMarketsRequest{Exchange: "binance", Symbol: "BTCUSDT", Type: "futures"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Markets) != 1 || m.Markets[0].Type != "futures" || m.Markets[0].Specs.TickSize != "0.1" || m.Markets[0].Contract != "linear_perp" {
+		t.Fatalf("detail = %v", m)
+	}
+	if _, err := e.client.GetMarkets(authed(), &pb.GetMarketsRequest{Exchange: "okx", Symbol: "BTCUSDT"}); code(err) != codes.NotFound {
+		t.Fatalf("unknown market: %v", err)
+	}
+	m, err = e.client.GetMarkets(authed(), &pb.GetMarketsRequest{Type: "spot"})
+	if err != nil || len(m.Markets) != 1 {
+		t.Fatalf("find = %v %v", m, err)
+	}
+	if _, err := e.client.GetMarkets(authed(), &pb.GetMarketsRequest{Sort: "volume"}); code(err) != codes.InvalidArgument {
+		t.Fatalf("bad sort: %v", err)
+	}
+}
+
+func TestWatchLists(t *testing.T) {
+	e := start(t)
+	ctx, cancel := context.WithTimeout(authed(), 5*time.Second)
+	defer cancel()
+	stream, err := e.client.WatchLists(ctx, &pb.WatchListsRequest{Target: "upbit", Initial: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	snap, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !snap.Snapshot || snap.Slug != "okx_to_upbit" || snap.Generation != 1 || len(snap.Rows) != 2 {
+		t.Fatalf("snapshot = %v", snap)
+	}
+
+	// новое поколение: BBB ушёл, у AAA сменился фьючерс, пришёл DDD; сегмент binance не наблюдается
+	e.lists.publish(2, map[string][]ldom.Row{
+		"okx_to_upbit": {
+			{Spot: "AAA-USDT", Futures: sp("AAA-USDT-250926"), Source: "okx"},
+			{Spot: "DDD-USDT", Source: "okx"},
+		},
+		"binance_seg1": nil,
+	})
+	e.feed.Publish(ldom.Generation{ID: 2})
+
+	ch, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Snapshot || ch.Generation != 2 || ch.Slug != "okx_to_upbit" ||
+		len(ch.Added) != 1 || ch.Added[0].Spot != "DDD-USDT" ||
+		len(ch.Removed) != 1 || ch.Removed[0].Spot != "BBB-USDT" ||
+		len(ch.Changed) != 1 || ch.Changed[0].Futures != "AAA-USDT-250926" {
+		t.Fatalf("change = %v", ch)
+	}
+
+	// повтор того же поколения ничего не шлёт; отмена закрывает поток
+	e.feed.Publish(ldom.Generation{ID: 2})
+	cancel()
+	if _, err := stream.Recv(); code(err) != codes.Canceled {
+		t.Fatalf("after cancel: %v", err)
+	}
+}
+
+func TestWatchLists_UnknownSlug(t *testing.T) {
+	e := start(t)
+	stream, err := e.client.WatchLists(authed(), &pb.WatchListsRequest{Slugs: []string{"nope"}})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if code(err) != codes.NotFound {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package grpcctrl
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/berezovskyivalerii/tickersvc/api/tickersvc/v1"
+	listsfmt "github.com/berezovskyivalerii/tickersvc/internal/adapter/presenter/lists"
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	"github.com/berezovskyivalerii/tickersvc/internal/pkg/symbols"
+)
+
+// Feed — рассылка опубликованных поколений подписчикам WatchLists; Publish вешается на ListsRepo.OnPublish.
+// Медленный подписчик получает только последнее поколение: дифф всё равно считается от того, что он видел.
+type Feed struct {
+	mu   sync.Mutex
+	subs map[chan ldom.Generation]struct{}
+}
+
+func NewFeed() *Feed { return &Feed{subs: map[chan ldom.Generation]struct{}{}} }
+
+func (f *Feed) Publish(g ldom.Generation) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for ch := range f.subs {
+		select {
+		case <-ch: // вытесняем непрочитанное
+		default:
+		}
+		ch <- g
+	}
+}
+
+// Subscribe — канал поколений и отписка.
+func (f *Feed) Subscribe() (<-chan ldom.Generation, func()) {
+	ch := make(chan ldom.Generation, 1)
+	f.mu.Lock()
+	f.subs[ch] = struct{}{}
+	f.mu.Unlock()
+	return ch, func() {
+		f.mu.Lock()
+		delete(f.subs, ch)
+		f.mu.Unlock()
+	}
+}
+
+// WatchLists: подписка до чтения снимка — поколение, опубликованное между ними, не теряется.
+// Списки цели (target) и «все списки» перечитываются на каждом поколении: новый список придёт целиком в added.
+func (s *Server) WatchLists(req *pb.WatchListsRequest, stream pb.TickerService_WatchListsServer) error {
+	if s.Feed == nil {
+		return status.Error(codes.Unimplemented, "list feed is not configured")
+	}
+	n, err := notation(req.GetNotation())
+	if err != nil {
+		return err
+	}
+	ctx := stream.Context()
+	gens, stop := s.Feed.Subscribe()
+	defer stop()
+
+	gctx, gen, err := s.pin(ctx, 0)
+	if err != nil {
+		return err
+	}
+	seen, err := s.watchRows(gctx, req)
+	if err != nil {
+		return err
+	}
+	if req.GetInitial() {
+		for _, slug := range sortedKeys(seen) {
+			msg := &pb.ListChange{Generation: gen, Slug: slug, Snapshot: true, Rows: toRows(listsfmt.Notate(seen[slug], n))}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case g := <-gens:
+			if s.Gens != nil && g.ID <= gen {
+				continue
+			}
+			cur, err := s.watchRows(ldom.WithGeneration(ctx, g.ID), req)
+			if err != nil {
+				return err
+			}
+			for _, slug := range sortedKeys(union(seen, cur)) {
+				msg := diffRows(seen[slug], cur[slug], n)
+				if msg == nil {
+					continue
+				}
+				msg.Generation, msg.Slug = g.ID, slug
+				if err := stream.Send(msg); err != nil {
+					return err
+				}
+			}
+			seen, gen = cur, g.ID
+		}
+	}
+}
+
+// watchRows — строки наблюдаемых списков в поколении из ctx.
+func (s *Server) watchRows(ctx context.Context, req *pb.WatchListsRequest) (map[string][]ldom.Row, error) {
+	slugs := req.GetSlugs()
+	if len(slugs) == 0 {
+		ml, ok := s.Lists.(ldom.MetaLister)
+		if !ok {
+			return nil, status.Error(codes.Unimplemented, "lists source cannot list metas; pass slugs")
+		}
+		metas, err := ml.ListMetas(ctx)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		for _, m := range metas {
+			if metaMatch(m, req.GetTarget(), "", "") {
+				slugs = append(slugs, m.Slug)
+			}
+		}
+	}
+	out := make(map[string][]ldom.Row, len(slugs))
+	for _, slug := range slugs {
+		slug = strings.TrimSpace(slug)
+		if len(req.GetSlugs()) > 0 {
+			// явно названный список должен существовать
+			if _, err := s.Lists.GetMeta(ctx, slug); err != nil {
+				return nil, toStatus(err)
+			}
+		}
+		rows, err := s.Lists.GetRowsBySlug(ctx, slug)
+		if err != nil {
+			return nil, toStatus(err)
+		}
+		out[slug] = rows
+	}
+	return out, nil
+}
+
+// diffRows — по spot-символу; nil — без изменений.
+func diffRows(old, cur []ldom.Row, n symbols.Notation) *pb.ListChange {
+	was := make(map[string]ldom.Row, len(old))
+	for _, r := range old {
+		was[r.Spot] = r
+	}
+	var added, changed []ldom.Row
+	now := make(map[string]bool, len(cur))
+	for _, r := range cur {
+		now[r.Spot] = true
+		p, ok := was[r.Spot]
+		switch {
+		case !ok:
+			added = append(added, r)
+		case str(p.Futures) != str(r.Futures):
+			changed = append(changed, r)
+		}
+	}
+	var removed []ldom.Row
+	for _, r := range old {
+		if !now[r.Spot] {
+			removed = append(removed, r)
+		}
+	}
+	if len(added)+len(removed)+len(changed) == 0 {
+		return nil
+	}
+	return &pb.ListChange{
+		Added:   toRows(listsfmt.Notate(added, n)),
+		Removed: toRows(listsfmt.Notate(removed, n)),
+		Changed: toRows(listsfmt.Notate(changed, n)),
+	}
+}
+
+func union(a, b map[string][]ldom.Row) map[string][]ldom.Row {
+	out := make(map[string][]ldom.Row, len(a)+len(b))
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		out[k] = v
+	}
+	return out
+}
+
+func sortedKeys(m map[string][]ldom.Row) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
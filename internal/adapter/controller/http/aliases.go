@@ -0,0 +1,92 @@
+package httpctrl
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+)
+
+type aliasDTO struct {
+	Exchange  string    `json:"exchange,omitempty"` // "" — для всех бирж
+	Ticker    string    `json:"ticker"`
+	Asset     string    `json:"asset"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+type AliasesController struct {
+	Repo assets.AliasRepo
+}
+
+func NewAliasesController(repo assets.AliasRepo) *AliasesController {
+	return &AliasesController{Repo: repo}
+}
+
+// Register вешает ручки на админ-группу (/admin/aliases).
+func (ctl *AliasesController) Register(g *gin.RouterGroup) {
+	g.GET("/aliases", ctl.list)
+	g.PUT("/aliases", ctl.upsert)
+	g.DELETE("/aliases/:ticker", ctl.remove) // ?exchange=binance; без exchange — глобальный алиас
+}
+
+func (ctl *AliasesController) list(c *gin.Context) {
+	al, err := ctl.Repo.ListAliases(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	out := make([]aliasDTO, 0, len(al))
+	for _, a := range al {
+		out = append(out, aliasDTO{Exchange: a.Exchange, Ticker: a.Ticker, Asset: a.Asset, UpdatedAt: a.UpdatedAt})
+	}
+	c.JSON(http.StatusOK, gin.H{"aliases": out})
+}
+
+func (ctl *AliasesController) upsert(c *gin.Context) {
+	var in aliasDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	in.Ticker = strings.ToUpper(strings.TrimSpace(in.Ticker))
+	in.Asset = strings.ToUpper(strings.TrimSpace(in.Asset))
+	if in.Ticker == "" || in.Asset == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ticker and asset are required"})
+		return
+	}
+	if in.Ticker == in.Asset {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ticker and asset must differ"})
+		return
+	}
+	a, err := ctl.Repo.UpsertAlias(c, in.Exchange, in.Ticker, in.Asset)
+	if errors.Is(err, assets.ErrUnknownExchange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, aliasDTO{Exchange: a.Exchange, Ticker: a.Ticker, Asset: a.Asset, UpdatedAt: a.UpdatedAt})
+}
+
+func (ctl *AliasesController) remove(c *gin.Context) {
+	ok, err := ctl.Repo.DeleteAlias(c, c.Query("exchange"), c.Param("ticker"))
+	if errors.Is(err, assets.ErrUnknownExchange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "alias not found"})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,104 @@
+package httpctrl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	ad "github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+type AssetViewer interface {
+	View(ctx context.Context, base string) (ad.View, error)
+}
+
+type AssetsController struct {
+	UC AssetViewer
+}
+
+func NewAssetsController(uc AssetViewer) *AssetsController {
+	return &AssetsController{UC: uc}
+}
+
+func (ctl *AssetsController) Register(r *gin.Engine) {
+	r.GET("/api/assets/:base", ctl.get)
+}
+
+type assetExchangeDTO struct {
+	Exchange string      `json:"exchange"`
+	Spot     []marketDTO `json:"spot"`
+	Futures  []marketDTO `json:"futures"`
+}
+
+type assetListDTO struct {
+	Slug    string    `json:"slug"`
+	Kind    string    `json:"kind"` // target|segment
+	Source  string    `json:"source"`
+	Target  string    `json:"target,omitempty"`
+	Segment string    `json:"segment,omitempty"`
+	Spot    string    `json:"spot"`
+	Futures string    `json:"futures"` // "none", как в текстовых списках
+	Since   time.Time `json:"since"`
+}
+
+type assetDTO struct {
+	Asset     string             `json:"asset"`
+	Tickers   []string           `json:"tickers"`
+	Exchanges []assetExchangeDTO `json:"exchanges"`
+	Lists     []assetListDTO     `json:"lists"`
+}
+
+func (ctl *AssetsController) get(c *gin.Context) {
+	v, err := ctl.UC.View(c, c.Param("base"))
+	if errors.Is(err, ad.ErrAssetNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "asset not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, toAssetDTO(v))
+}
+
+// toAssetDTO группирует рынки по биржам в порядке первого появления.
+func toAssetDTO(v ad.View) assetDTO {
+	out := assetDTO{
+		Asset:     v.Asset,
+		Tickers:   v.Tickers,
+		Exchanges: []assetExchangeDTO{},
+		Lists:     make([]assetListDTO, 0, len(v.Lists)),
+	}
+	idx := map[string]int{}
+	for _, m := range v.Markets {
+		i, ok := idx[m.Exchange]
+		if !ok {
+			i = len(out.Exchanges)
+			idx[m.Exchange] = i
+			out.Exchanges = append(out.Exchanges, assetExchangeDTO{
+				Exchange: m.Exchange, Spot: []marketDTO{}, Futures: []marketDTO{},
+			})
+		}
+		ex := &out.Exchanges[i]
+		if m.Type == dm.TypeFutures {
+			ex.Futures = append(ex.Futures, toMarketDTO(m))
+		} else {
+			ex.Spot = append(ex.Spot, toMarketDTO(m))
+		}
+	}
+	for _, l := range v.Lists {
+		fut := "none"
+		if l.Futures != nil {
+			fut = *l.Futures
+		}
+		out.Lists = append(out.Lists, assetListDTO{
+			Slug: l.Slug, Kind: l.Kind, Source: l.SourceSlug, Target: l.TargetSlug, Segment: l.Segment,
+			Spot: l.Spot, Futures: fut, Since: l.Since,
+		})
+	}
+	return out
+}
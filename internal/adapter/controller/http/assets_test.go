@@ -0,0 +1,76 @@
+package httpctrl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	ad "github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+type fakeAssetViewer map[string]ad.View
+
+func (f fakeAssetViewer) View(ctx context.Context, base string) (ad.View, error) {
+	v, ok := f[base]
+	if !ok {
+		return ad.View{}, ad.ErrAssetNotFound
+	}
+	return v, nil
+}
+
+func TestAssets_Get(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	listed := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	delisted := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	fut := "PEPEUSDT"
+	r := gin.New()
+	NewAssetsController(fakeAssetViewer{"PEPE": {
+		Asset: "PEPE", Tickers: []string{"PEPE"},
+		Markets: []dm.Market{
+			{Exchange: "binance", ListedAt: listed, Item: dm.Item{Type: dm.TypeSpot, Symbol: "PEPEUSDT", Base: "PEPE", Quote: "USDT", Active: true}},
+			{Exchange: "okx", ListedAt: listed, DelistedAt: &delisted, Item: dm.Item{Type: dm.TypeSpot, Symbol: "PEPE-USDC", Base: "PEPE", Quote: "USDC"}},
+			{Exchange: "binance", ListedAt: listed, Item: dm.Item{Type: dm.TypeFutures, Symbol: "1000PEPEUSDT", Base: "1000PEPE", Quote: "USDT", Multiplier: 1000, Active: true}},
+		},
+		Lists: []ldom.Membership{
+			{Slug: "binance_upbit", Kind: "target", SourceSlug: "binance", TargetSlug: "upbit", Spot: "PEPEUSDT", Futures: &fut, Since: listed},
+			{Slug: "binance_seg1", Kind: "segment", SourceSlug: "binance", Segment: "seg1", Spot: "PEPEUSDT", Since: listed},
+		},
+	}}).Register(r)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/assets/PEPE", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("code=%d body=%s", w.Code, w.Body.String())
+	}
+	var got assetDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Exchanges) != 2 || got.Exchanges[0].Exchange != "binance" || got.Exchanges[1].Exchange != "okx" {
+		t.Fatalf("exchanges: %+v", got.Exchanges)
+	}
+	bn := got.Exchanges[0]
+	if len(bn.Spot) != 1 || len(bn.Futures) != 1 || bn.Futures[0].Multiplier != 1000 {
+		t.Fatalf("binance: %+v", bn)
+	}
+	if ok := got.Exchanges[1]; len(ok.Futures) != 0 || ok.Spot[0].DelistedAt == nil {
+		t.Fatalf("okx: %+v", ok)
+	}
+	if len(got.Lists) != 2 || got.Lists[0].Futures != "PEPEUSDT" || got.Lists[1].Futures != "none" ||
+		got.Lists[1].Segment != "seg1" || !got.Lists[0].Since.Equal(listed) {
+		t.Fatalf("lists: %+v", got.Lists)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/assets/NOPE", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("unknown asset: code=%d", w.Code)
+	}
+}
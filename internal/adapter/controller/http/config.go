@@ -0,0 +1,46 @@
+package httpctrl
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+)
+
+type ConfigSource interface {
+	Get() config.Config
+	Path() string
+	LoadedAt() time.Time
+}
+
+type ConfigController struct {
+	Src ConfigSource
+}
+
+func NewConfigController(src ConfigSource) *ConfigController {
+	return &ConfigController{Src: src}
+}
+
+// Register вешает ручку на админ-группу (/admin/config).
+func (ctl *ConfigController) Register(g *gin.RouterGroup) {
+	g.GET("/config", ctl.get)
+}
+
+type configDTO struct {
+	File       string        `json:"file,omitempty"` // "" — только env
+	LoadedAt   time.Time     `json:"loaded_at"`
+	Reloadable []string      `json:"reloadable"`
+	Config     config.Config `json:"config"`
+}
+
+// get — действующая конфигурация (после env и последнего SIGHUP), секреты скрыты.
+func (ctl *ConfigController) get(c *gin.Context) {
+	c.JSON(http.StatusOK, configDTO{
+		File:       ctl.Src.Path(),
+		LoadedAt:   ctl.Src.LoadedAt(),
+		Reloadable: config.ReloadableKeys,
+		Config:     ctl.Src.Get().Redacted(),
+	})
+}
@@ -0,0 +1,49 @@
+package httpctrl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+)
+
+func TestConfig_GetRedactsSecrets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cfg := config.Defaults()
+	cfg.DB.DSN = "postgres://app:s3cret@db:5432/tickers?sslmode=disable"
+	cfg.Admin.APIKey = "topsecret"
+	r := gin.New()
+	NewConfigController(config.NewStore("/etc/tickersvc.yaml", cfg)).Register(r.Group("/admin"))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/config", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("code=%d body=%s", w.Code, w.Body.String())
+	}
+	body := w.Body.String()
+	if strings.Contains(body, "s3cret") || strings.Contains(body, "topsecret") {
+		t.Fatalf("secret leaked: %s", body)
+	}
+	var got struct {
+		File   string `json:"file"`
+		Config struct {
+			DB         struct{ DSN string }      `json:"db"`
+			AutoUpdate struct{ Interval string } `json:"auto_update"`
+		} `json:"config"`
+		Reloadable []string `json:"reloadable"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.File != "/etc/tickersvc.yaml" || got.Config.AutoUpdate.Interval != "10m0s" || len(got.Reloadable) == 0 {
+		t.Fatalf("got %+v", got)
+	}
+	if got.Config.DB.DSN != "postgres://app:xxxxx@db:5432/tickers?sslmode=disable" {
+		t.Fatalf("dsn: %s", got.Config.DB.DSN)
+	}
+}
@@ -0,0 +1,113 @@
+package httpctrl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+type ListExplainer interface {
+	Explain(ctx context.Context, slug, base string) (listsuc.Explanation, error)
+}
+
+type ExplainController struct {
+	UC ListExplainer
+}
+
+func NewExplainController(uc ListExplainer) *ExplainController {
+	return &ExplainController{UC: uc}
+}
+
+func (ctl *ExplainController) Register(r *gin.Engine) {
+	r.GET("/api/lists/:slug/explain/:base", ctl.get)
+}
+
+type explainMarketDTO struct {
+	Symbol     string `json:"symbol"`
+	Type       string `json:"type"`
+	Quote      string `json:"quote"`
+	Kind       string `json:"kind,omitempty"`
+	Multiplier int64  `json:"multiplier,omitempty"`
+	Chosen     bool   `json:"chosen"`
+	Note       string `json:"note,omitempty"`
+}
+
+type explainQuoteDTO struct {
+	Exchange string `json:"exchange"`
+	Quote    string `json:"quote"`
+	Symbol   string `json:"symbol"`
+	Counted  bool   `json:"counted"`
+}
+
+type explainPickDTO struct {
+	Symbol string `json:"symbol"` // "none" — не выбран
+	Reason string `json:"reason"`
+}
+
+type explainDTO struct {
+	Slug          string             `json:"slug"`
+	Kind          string             `json:"kind"`
+	Base          string             `json:"base"`
+	Source        string             `json:"source"`
+	Target        string             `json:"target,omitempty"`
+	Mode          string             `json:"mode,omitempty"`
+	Expr          string             `json:"expr,omitempty"`
+	Included      bool               `json:"included"`
+	Rule          string             `json:"rule"`
+	Stored        bool               `json:"stored"`
+	Disabled      bool               `json:"disabled,omitempty"` // биржа списка неактивна, список не пересобирается
+	Spot          explainPickDTO     `json:"spot"`
+	Futures       explainPickDTO     `json:"futures"`
+	SourceMarkets []explainMarketDTO `json:"source_markets"`
+	TargetQuotes  []explainQuoteDTO  `json:"target_quotes"`
+	TargetFutures *bool              `json:"target_futures,omitempty"`
+	Flags         map[string]bool    `json:"flags,omitempty"`
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+func (ctl *ExplainController) get(c *gin.Context) {
+	ex, err := ctl.UC.Explain(c, c.Param("slug"), c.Param("base"))
+	switch {
+	case errors.Is(err, ldom.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	out := explainDTO{
+		Slug: ex.Slug, Kind: ex.Kind, Base: ex.Base, Source: ex.Source,
+		Target: ex.Target, Mode: ex.Mode, Expr: ex.Expr,
+		Included: ex.Included, Rule: ex.Rule, Stored: ex.Stored, Disabled: ex.Disabled,
+		Spot:          explainPickDTO{Symbol: orNone(ex.Spot), Reason: ex.SpotReason},
+		Futures:       explainPickDTO{Symbol: orNone(ex.Futures), Reason: ex.FuturesReason},
+		SourceMarkets: make([]explainMarketDTO, 0, len(ex.SourceMarkets)),
+		TargetQuotes:  make([]explainQuoteDTO, 0, len(ex.TargetQuotes)),
+		Flags:         ex.Flags,
+	}
+	if ex.Kind == "target" {
+		out.TargetFutures = &ex.TargetHasFut
+	}
+	for _, m := range ex.SourceMarkets {
+		out.SourceMarkets = append(out.SourceMarkets, explainMarketDTO{
+			Symbol: m.Symbol, Type: string(m.Type), Quote: m.Quote, Kind: string(m.Kind),
+			Multiplier: m.Multiplier, Chosen: m.Chosen, Note: m.Note,
+		})
+	}
+	for _, q := range ex.TargetQuotes {
+		out.TargetQuotes = append(out.TargetQuotes, explainQuoteDTO(q))
+	}
+	c.JSON(http.StatusOK, out)
+}
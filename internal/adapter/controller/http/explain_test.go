@@ -0,0 +1,60 @@
+package httpctrl
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+type fakeExplainer struct{ slug, base string }
+
+func (f *fakeExplainer) Explain(ctx context.Context, slug, base string) (listsuc.Explanation, error) {
+	f.slug, f.base = slug, base
+	if slug != "binance_to_upbit" {
+		return listsuc.Explanation{}, fmt.Errorf("%w: %s", ldom.ErrNotFound, slug)
+	}
+	return listsuc.Explanation{
+		Slug: slug, Kind: "target", Base: "PEPE", Source: "binance", Target: "upbit", Mode: "upbit",
+		Spot: "PEPEUSDT", SpotReason: "quote USDT",
+		SourceMarkets: []listsuc.ExplainMarket{{Symbol: "PEPEUSDT", Type: dm.TypeSpot, Quote: "USDT", Chosen: true}},
+		TargetQuotes:  []listsuc.ExplainQuote{{Exchange: "upbit", Quote: "USDT", Symbol: "USDT-PEPE"}},
+		Included:      true, Rule: "included: ...",
+	}, nil
+}
+
+func TestExplain_Get(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := &fakeExplainer{}
+	r := gin.New()
+	NewExplainController(f).Register(r)
+	NewPublicListsController(nil).Register(r) // маршруты не конфликтуют с /api/lists/:slug
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/lists/binance_to_upbit/explain/pepe", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("code=%d body=%s", w.Code, w.Body.String())
+	}
+	var got explainDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if f.base != "pepe" || !got.Included || got.Futures.Symbol != "none" || got.Spot.Symbol != "PEPEUSDT" ||
+		got.TargetFutures == nil || len(got.TargetQuotes) != 1 || got.TargetQuotes[0].Counted {
+		t.Fatalf("got %+v", got)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/lists/nope/explain/PEPE", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("unknown slug: %d", w.Code)
+	}
+}
@@ -0,0 +1,74 @@
+package httpctrl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+)
+
+// genQuery — списки по поколениям; читает поколение, закреплённое контроллером в ctx.
+type genQuery struct {
+	fakeQuery
+	byGen map[int64]map[string][]ldom.Row
+}
+
+func (q *genQuery) GetRowsBySlug(ctx context.Context, slug string) ([]ldom.Row, error) {
+	id, _ := ldom.GenerationFrom(ctx)
+	return q.byGen[id][slug], nil
+}
+
+func (q *genQuery) Generation(ctx context.Context, id int64) (ldom.Generation, error) {
+	if id == 0 {
+		id = 2 // текущее
+	}
+	if _, ok := q.byGen[id]; !ok {
+		return ldom.Generation{}, ldom.ErrGenerationNotFound
+	}
+	return ldom.Generation{ID: id}, nil
+}
+
+func TestPublicLists_Generation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	q := &genQuery{byGen: map[int64]map[string][]ldom.Row{
+		1: {"okx_to_upbit": {{Spot: "OLD-USDT"}}},
+		2: {"okx_to_upbit": {{Spot: "NEW-USDT"}}},
+	}}
+	r := gin.New()
+	ctl := NewPublicListsController(q)
+	ctl.Gens = q
+	ctl.Register(r)
+
+	get := func(url string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		return w
+	}
+	spot := func(w *httptest.ResponseRecorder) string {
+		var resp itemsResp
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.Items) != 1 {
+			t.Fatalf("body %s: %v", w.Body.String(), err)
+		}
+		return resp.Items[0].SpotSymbol
+	}
+
+	w := get("/api/lists/okx_to_upbit")
+	if w.Code != http.StatusOK || w.Header().Get("X-Generation") != "2" || spot(w) != "NEW-USDT" {
+		t.Fatalf("current: %d %q %s", w.Code, w.Header().Get("X-Generation"), w.Body.String())
+	}
+	w = get("/api/lists/okx_to_upbit?generation=1")
+	if w.Code != http.StatusOK || w.Header().Get("X-Generation") != "1" || spot(w) != "OLD-USDT" {
+		t.Fatalf("pinned: %d %q %s", w.Code, w.Header().Get("X-Generation"), w.Body.String())
+	}
+	if w := get("/api/lists/okx_to_upbit?generation=7"); w.Code != http.StatusNotFound {
+		t.Fatalf("pruned generation: %d", w.Code)
+	}
+	if w := get("/api/lists/okx_to_upbit?generation=abc"); w.Code != http.StatusBadRequest {
+		t.Fatalf("bad generation: %d", w.Code)
+	}
+}
@@ -0,0 +1,219 @@
+package httpctrl
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// Десятичные параметры отдаём строками ("0.1"), как сами биржи: float64 их искажает.
+type specsDTO struct {
+	ContractSize dm.Decimal `json:"contract_size,omitempty"`
+	TickSize     dm.Decimal `json:"tick_size,omitempty"`
+	LotSize      dm.Decimal `json:"lot_size,omitempty"`
+	MinQty       dm.Decimal `json:"min_qty,omitempty"`
+	MinNotional  dm.Decimal `json:"min_notional,omitempty"`
+	MaxLeverage  dm.Decimal `json:"max_leverage,omitempty"`
+}
+
+type marketDTO struct {
+	Exchange   string     `json:"exchange"`
+	Type       string     `json:"type"`
+	Symbol     string     `json:"symbol"`
+	Base       string     `json:"base"`
+	Quote      string     `json:"quote"`
+	Multiplier int64      `json:"multiplier"`
+	Contract   string     `json:"contract,omitempty"` // linear_perp | inverse_perp | delivery
+	Settle     string     `json:"settle,omitempty"`
+	Expiry     *time.Time `json:"expiry,omitempty"`
+	Active     bool       `json:"active"`
+	ListedAt   time.Time  `json:"listed_at"`
+	DelistedAt *time.Time `json:"delisted_at,omitempty"`
+	Specs      specsDTO   `json:"specs"`
+}
+
+func toMarketDTO(m dm.Market) marketDTO {
+	return marketDTO{
+		Exchange:   m.Exchange,
+		Type:       string(m.Type),
+		Symbol:     m.Symbol,
+		Base:       m.Base,
+		Quote:      m.Quote,
+		Multiplier: m.Mult(),
+		Contract:   string(m.Kind()),
+		Settle:     m.Settle,
+		Expiry:     m.Expiry,
+		Active:     m.Active,
+		ListedAt:   m.ListedAt,
+		DelistedAt: m.DelistedAt,
+		Specs:      specsDTO(m.Specs),
+	}
+}
+
+type MarketsController struct {
+	Q dm.QueryRepo
+}
+
+func NewMarketsController(q dm.QueryRepo) *MarketsController {
+	return &MarketsController{Q: q}
+}
+
+func (ctl *MarketsController) Register(r *gin.Engine) {
+	api := r.Group("/api")
+	api.GET("/markets", ctl.list)                     // фильтры + курсор, см. parseMarketsFilter
+	api.GET("/markets/:exchange/:symbol", ctl.detail) // ?type=spot|futures
+}
+
+type marketsPage struct {
+	Items      []marketDTO `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+func (ctl *MarketsController) list(c *gin.Context) {
+	f, err := parseMarketsFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	page, err := ctl.Q.FindMarkets(c, f)
+	if errors.Is(err, dm.ErrBadSort) || errors.Is(err, dm.ErrBadCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	out := marketsPage{Items: make([]marketDTO, 0, len(page.Items)), NextCursor: page.NextCursor}
+	for _, m := range page.Items {
+		out.Items = append(out.Items, toMarketDTO(m))
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// parseMarketsFilter:
+//
+//	?exchange=bithumb,upbit&base=BTC&quote=KRW,USDT — CSV или повтор параметра
+//	?type=spot|futures&contract=inverse_perp,delivery&active=true|false
+//	?listed_from=&listed_to=&delisted_from=&delisted_to= — RFC3339 или YYYY-MM-DD, интервал [from, to)
+//	?sort=symbol|base|listed_at|delisted_at|id (минус — по убыванию)&limit=1..1000&cursor=
+func parseMarketsFilter(c *gin.Context) (dm.Filter, error) {
+	f := dm.Filter{
+		Exchanges: csvQuery(c, "exchange"),
+		Bases:     csvQuery(c, "base"),
+		Quotes:    csvQuery(c, "quote"),
+		Cursor:    strings.TrimSpace(c.Query("cursor")),
+	}
+
+	switch t := dm.Type(strings.ToLower(strings.TrimSpace(c.Query("type")))); t {
+	case "", dm.TypeSpot, dm.TypeFutures:
+		f.Type = t
+	default:
+		return f, errors.New("type must be one of: spot, futures")
+	}
+
+	kinds, err := dm.ParseContractKinds(strings.Join(csvQuery(c, "contract"), ","))
+	if err != nil {
+		return f, errors.New("contract must be one of: linear_perp, inverse_perp, delivery")
+	}
+	f.Contracts = kinds
+
+	if v := strings.TrimSpace(c.Query("active")); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return f, errors.New("active must be true or false")
+		}
+		f.Active = &b
+	}
+
+	for key, dst := range map[string]**time.Time{
+		"listed_from":   &f.ListedFrom,
+		"listed_to":     &f.ListedTo,
+		"delisted_from": &f.DelistedFrom,
+		"delisted_to":   &f.DelistedTo,
+	} {
+		v := strings.TrimSpace(c.Query(key))
+		if v == "" {
+			continue
+		}
+		t, err := parseTimeParam(v)
+		if err != nil {
+			return f, fmt.Errorf("%s must be RFC3339 or YYYY-MM-DD", key)
+		}
+		*dst = &t
+	}
+
+	if s := strings.TrimSpace(c.Query("sort")); s != "" {
+		f.Desc = strings.HasPrefix(s, "-")
+		f.Sort = dm.Sort(strings.TrimPrefix(s, "-"))
+		switch f.Sort {
+		case dm.SortSymbol, dm.SortBase, dm.SortListedAt, dm.SortDelistedAt, dm.SortID:
+		default:
+			return f, errors.New("sort must be one of: symbol, base, listed_at, delisted_at, id")
+		}
+	}
+
+	if v := strings.TrimSpace(c.Query("limit")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > 1000 {
+			return f, errors.New("limit must be 1..1000")
+		}
+		f.Limit = n
+	}
+	return f, nil
+}
+
+// csvQuery: ?k=a,b&k=c → [a b c]
+func csvQuery(c *gin.Context, key string) []string {
+	var out []string
+	for _, v := range c.QueryArray(key) {
+		for _, p := range strings.Split(v, ",") {
+			if p = strings.TrimSpace(p); p != "" {
+				out = append(out, p)
+			}
+		}
+	}
+	return out
+}
+
+func parseTimeParam(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", v)
+}
+
+func (ctl *MarketsController) detail(c *gin.Context) {
+	typ := strings.ToLower(strings.TrimSpace(c.Query("type")))
+	switch dm.Type(typ) {
+	case "", dm.TypeSpot, dm.TypeFutures:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "type must be one of: spot, futures"})
+		return
+	}
+
+	ms, err := ctl.Q.GetMarkets(c, c.Param("exchange"), c.Param("symbol"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	items := make([]marketDTO, 0, len(ms))
+	for _, m := range ms {
+		if typ != "" && string(m.Type) != typ {
+			continue
+		}
+		items = append(items, toMarketDTO(m))
+	}
+	if len(items) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "market not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
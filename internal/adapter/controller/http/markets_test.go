@@ -0,0 +1,173 @@
+package httpctrl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+type fakeMarkets struct {
+	ms   []dm.Market
+	last dm.Filter // что пришло в FindMarkets
+}
+
+func (f *fakeMarkets) FindMarkets(ctx context.Context, flt dm.Filter) (dm.Page, error) {
+	f.last = flt
+	if flt.Cursor == "garbage" {
+		return dm.Page{}, dm.ErrBadCursor
+	}
+	var page dm.Page
+	for _, m := range f.ms {
+		if len(flt.Exchanges) > 0 && m.Exchange != flt.Exchanges[0] {
+			continue
+		}
+		page.Items = append(page.Items, m)
+	}
+	if flt.Limit > 0 && len(page.Items) > flt.Limit {
+		page.Items, page.NextCursor = page.Items[:flt.Limit], "next"
+	}
+	return page, nil
+}
+
+func (f *fakeMarkets) GetMarkets(ctx context.Context, exchange, symbol string) ([]dm.Market, error) {
+	var out []dm.Market
+	for _, m := range f.ms {
+		if m.Exchange == exchange && m.Symbol == strings.ToUpper(symbol) {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func newMarketsRouter() *gin.Engine {
+	r, _ := newMarketsRouterWith()
+	return r
+}
+
+func newMarketsRouterWith() (*gin.Engine, *fakeMarkets) {
+	gin.SetMode(gin.TestMode)
+	listed := time.Date(2025, 8, 1, 0, 0, 0, 0, time.UTC)
+	q := &fakeMarkets{ms: []dm.Market{
+		{Exchange: "okx", ListedAt: listed, Item: dm.Item{
+			Type: dm.TypeFutures, Symbol: "PEPE-USDT-SWAP", Base: "PEPE", Quote: "USDT", Active: true,
+			Specs: dm.Specs{ContractSize: "10000000", TickSize: "0.0000000001", LotSize: "0.1", MinQty: "0.1", MaxLeverage: "50"},
+		}},
+		{Exchange: "binance", ListedAt: listed, Item: dm.Item{
+			Type: dm.TypeSpot, Symbol: "BTCUSDT", Base: "BTC", Quote: "USDT", Active: true,
+			Specs: dm.Specs{TickSize: "0.01", LotSize: "0.00001", MinQty: "0.00001", MinNotional: "5"},
+		}},
+		{Exchange: "binance", ListedAt: listed, Item: dm.Item{
+			Type: dm.TypeFutures, Symbol: "BTCUSDT", Base: "BTC", Quote: "USDT", Active: true,
+			Specs: dm.Specs{TickSize: "0.1", LotSize: "0.001", MinQty: "0.001", MinNotional: "100"},
+		}},
+	}}
+	r := gin.New()
+	NewMarketsController(q).Register(r)
+	return r, q
+}
+
+func TestMarkets_Detail(t *testing.T) {
+	r := newMarketsRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/markets/okx/pepe-usdt-swap", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+	}
+	// десятичные значения приходят строками без потери точности
+	for _, want := range []string{`"contract_size":"10000000"`, `"tick_size":"0.0000000001"`, `"max_leverage":"50"`, `"multiplier":1`} {
+		if !strings.Contains(w.Body.String(), want) {
+			t.Fatalf("missing %s in %s", want, w.Body.String())
+		}
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/markets/binance/BTCUSDT?type=futures", nil)
+	r.ServeHTTP(w, req)
+	var resp struct {
+		Items []marketDTO `json:"items"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Items) != 1 || resp.Items[0].Type != "futures" || resp.Items[0].Specs.MinNotional != "100" ||
+		resp.Items[0].Contract != "linear_perp" {
+		t.Fatalf("bad futures detail: %+v", resp.Items)
+	}
+}
+
+func TestMarkets_Detail_Errors(t *testing.T) {
+	r := newMarketsRouter()
+
+	cases := map[string]int{
+		"/api/markets/binance/NOPEUSDT":             http.StatusNotFound,
+		"/api/markets/okx/PEPE-USDT-SWAP?type=spot": http.StatusNotFound,
+		"/api/markets/binance/BTCUSDT?type=option":  http.StatusBadRequest,
+	}
+	for path, want := range cases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		r.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Fatalf("%s: want %d, got %d", path, want, w.Code)
+		}
+	}
+}
+
+func TestMarkets_List_Filters(t *testing.T) {
+	r, q := newMarketsRouterWith()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet,
+		"/api/markets?exchange=binance&base=btc,eth&quote=USDT&quote=KRW&type=futures&contract=delivery"+
+			"&active=false&listed_from=2025-01-01&delisted_to=2025-08-17T00:00:00Z&sort=-listed_at&limit=1", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status=%d body=%s", w.Code, w.Body.String())
+	}
+	f := q.last
+	if len(f.Bases) != 2 || len(f.Quotes) != 2 || f.Type != dm.TypeFutures || len(f.Contracts) != 1 ||
+		f.Active == nil || *f.Active || f.ListedFrom == nil || f.DelistedTo == nil || f.ListedTo != nil ||
+		f.Sort != dm.SortListedAt || !f.Desc || f.Limit != 1 {
+		t.Fatalf("bad filter: %+v", f)
+	}
+
+	var resp marketsPage
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Items) != 1 || resp.NextCursor != "next" {
+		t.Fatalf("bad page: %+v", resp)
+	}
+}
+
+func TestMarkets_List_BadParams(t *testing.T) {
+	r := newMarketsRouter()
+
+	for _, qs := range []string{
+		"type=option",
+		"contract=option",
+		"active=maybe",
+		"listed_from=yesterday",
+		"sort=volume",
+		"limit=0",
+		"limit=5000",
+		"cursor=garbage",
+	} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/markets?"+qs, nil)
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: want 400, got %d", qs, w.Code)
+		}
+	}
+}
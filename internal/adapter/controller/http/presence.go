@@ -0,0 +1,129 @@
+package httpctrl
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+type PresenceBuilder interface {
+	PresenceMatrix(ctx context.Context, exchanges []string) (listsuc.PresenceMatrix, error)
+}
+
+type PresenceController struct {
+	UC PresenceBuilder
+}
+
+func NewPresenceController(uc PresenceBuilder) *PresenceController {
+	return &PresenceController{UC: uc}
+}
+
+func (ctl *PresenceController) Register(r *gin.Engine) {
+	r.GET("/api/presence", ctl.get) // ?exchange=binance,upbit&format=json|csv
+}
+
+type presenceCellDTO struct {
+	Spot     []string `json:"spot"`
+	Quotes   []string `json:"quotes"`
+	Futures  string   `json:"futures,omitempty"`
+	Contract string   `json:"contract,omitempty"`
+	InSet    bool     `json:"in_set"`
+}
+
+type presenceRowDTO struct {
+	Base  string                     `json:"base"`
+	Cells map[string]presenceCellDTO `json:"cells"`
+}
+
+type presenceDTO struct {
+	Exchanges []string         `json:"exchanges"`
+	Items     []presenceRowDTO `json:"items"`
+}
+
+func (ctl *PresenceController) get(c *gin.Context) {
+	asCSV := false
+	switch f := strings.ToLower(strings.TrimSpace(c.Query("format"))); f {
+	case "json":
+	case "csv":
+		asCSV = true
+	case "":
+		asCSV = strings.Contains(c.GetHeader("Accept"), "text/csv")
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be one of: json, csv"})
+		return
+	}
+
+	m, err := ctl.UC.PresenceMatrix(c, csvQuery(c, "exchange"))
+	if errors.Is(err, listsuc.ErrUnknownExchange) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if asCSV {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Status(http.StatusOK)
+		if err := writePresenceCSV(csv.NewWriter(c.Writer), m); err != nil {
+			_ = c.Error(err)
+		}
+		return
+	}
+
+	out := presenceDTO{Exchanges: m.Exchanges, Items: make([]presenceRowDTO, 0, len(m.Rows))}
+	for _, r := range m.Rows {
+		row := presenceRowDTO{Base: r.Base, Cells: make(map[string]presenceCellDTO, len(r.Cells))}
+		for ex, cell := range r.Cells {
+			row.Cells[ex] = presenceCellDTO{
+				Spot:     nonNil(cell.Spot),
+				Quotes:   nonNil(cell.Quotes),
+				Futures:  cell.Futures,
+				Contract: string(cell.Kind),
+				InSet:    cell.InSet,
+			}
+		}
+		out.Items = append(out.Items, row)
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+// writePresenceCSV — широкая таблица: base, затем по 4 колонки на биржу
+// (<ex>_spot, <ex>_quotes, <ex>_futures, <ex>_in_set); несколько значений в ячейке — через ";".
+func writePresenceCSV(w *csv.Writer, m listsuc.PresenceMatrix) error {
+	head := []string{"base"}
+	for _, ex := range m.Exchanges {
+		head = append(head, ex+"_spot", ex+"_quotes", ex+"_futures", ex+"_in_set")
+	}
+	if err := w.Write(head); err != nil {
+		return err
+	}
+	for _, r := range m.Rows {
+		rec := []string{r.Base}
+		for _, ex := range m.Exchanges {
+			cell := r.Cells[ex]
+			rec = append(rec, strings.Join(cell.Spot, ";"), strings.Join(cell.Quotes, ";"),
+				cell.Futures, strconv.FormatBool(cell.InSet))
+		}
+		if err := w.Write(rec); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func nonNil(ss []string) []string {
+	if ss == nil {
+		return []string{}
+	}
+	return ss
+}
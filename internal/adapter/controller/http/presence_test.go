@@ -0,0 +1,85 @@
+package httpctrl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+type fakePresence struct{ got []string }
+
+func (f *fakePresence) PresenceMatrix(ctx context.Context, exchanges []string) (listsuc.PresenceMatrix, error) {
+	f.got = exchanges
+	if _, err := listsuc.PresenceColumns(exchanges); err != nil {
+		return listsuc.PresenceMatrix{}, err
+	}
+	return listsuc.PresenceMatrix{
+		Exchanges: []string{"binance", "upbit"},
+		Rows: []listsuc.PresenceRow{{Base: "PEPE", Cells: map[string]listsuc.PresenceCell{
+			"binance": {Spot: []string{"PEPEFDUSD", "PEPEUSDT"}, Quotes: []string{"FDUSD", "USDT"},
+				Futures: "1000PEPEUSDT", Kind: dm.ContractLinearPerp, InSet: true},
+			"upbit": {Spot: []string{"KRW-PEPE"}, Quotes: []string{"KRW"}, InSet: true},
+		}}, {Base: "ARB", Cells: map[string]listsuc.PresenceCell{
+			"binance": {Spot: []string{"ARBBTC"}, Quotes: []string{"BTC"}},
+		}}},
+	}, nil
+}
+
+func TestPresence_Get(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &fakePresence{}
+	r := gin.New()
+	NewPresenceController(uc).Register(r)
+
+	get := func(url, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, url, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/api/presence?exchange=binance&exchange=upbit", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("json: code=%d body=%s", w.Code, w.Body.String())
+	}
+	if strings.Join(uc.got, ",") != "binance,upbit" {
+		t.Fatalf("exchanges passed: %v", uc.got)
+	}
+	var got presenceDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if c := got.Items[0].Cells["binance"]; c.Futures != "1000PEPEUSDT" || c.Contract != "linear_perp" || !c.InSet {
+		t.Fatalf("cell: %+v", c)
+	}
+
+	wantCSV := "base,binance_spot,binance_quotes,binance_futures,binance_in_set,upbit_spot,upbit_quotes,upbit_futures,upbit_in_set\n" +
+		"PEPE,PEPEFDUSD;PEPEUSDT,FDUSD;USDT,1000PEPEUSDT,true,KRW-PEPE,KRW,,true\n" +
+		"ARB,ARBBTC,BTC,,false,,,,false\n"
+	for _, w := range []*httptest.ResponseRecorder{get("/api/presence?format=csv", ""), get("/api/presence", "text/csv")} {
+		if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv") {
+			t.Fatalf("csv: code=%d ct=%s", w.Code, w.Header().Get("Content-Type"))
+		}
+		if w.Body.String() != wantCSV {
+			t.Fatalf("csv body:\n%s\nwant:\n%s", w.Body.String(), wantCSV)
+		}
+	}
+
+	if w := get("/api/presence?format=xml", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("bad format: code=%d", w.Code)
+	}
+	if w := get("/api/presence?exchange=kraken", ""); w.Code != http.StatusBadRequest {
+		t.Fatalf("unknown exchange: code=%d", w.Code)
+	}
+}
@@ -0,0 +1,144 @@
+package httpctrl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	"github.com/berezovskyivalerii/tickersvc/internal/pkg/setexpr"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+type ListPreviewer interface {
+	Preview(ctx context.Context, spec listsuc.PreviewSpec) (listsuc.Preview, error)
+}
+
+type PreviewController struct {
+	UC ListPreviewer
+}
+
+func NewPreviewController(uc ListPreviewer) *PreviewController {
+	return &PreviewController{UC: uc}
+}
+
+func (ctl *PreviewController) Register(r *gin.Engine) {
+	r.POST("/api/preview", ctl.post)
+}
+
+type previewQuotesReq struct {
+	SourceSpotQuote     string   `json:"source_spot_quote"`
+	TargetAllowedQuotes []string `json:"target_allowed_quotes"`
+}
+
+type previewReq struct {
+	Source       string            `json:"source" binding:"required"`
+	Target       string            `json:"target"`
+	Mode         string            `json:"mode"`
+	Segment      string            `json:"segment"` // выражение: "S & U & !(C | H)"
+	FuturesKinds []string          `json:"futures_kinds"`
+	Quotes       *previewQuotesReq `json:"quotes"`
+	Slug         string            `json:"slug"`
+}
+
+type previewRowDTO struct {
+	Spot    string `json:"spot"`
+	Futures string `json:"futures"`
+}
+
+type previewChangeDTO struct {
+	Spot string `json:"spot"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type previewDiffDTO struct {
+	Added   []previewRowDTO    `json:"added"`
+	Removed []previewRowDTO    `json:"removed"`
+	Changed []previewChangeDTO `json:"changed"`
+}
+
+type previewResp struct {
+	Count int             `json:"count"`
+	Items []previewRowDTO `json:"items"`
+	Diff  *previewDiffDTO `json:"diff,omitempty"`
+}
+
+func (ctl *PreviewController) post(c *gin.Context) {
+	var req previewReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	kinds, err := dm.ParseContractKinds(strings.Join(req.FuturesKinds, ","))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "futures_kinds must be one of: linear_perp, inverse_perp, delivery"})
+		return
+	}
+	spec := listsuc.PreviewSpec{
+		Source:       strings.ToLower(strings.TrimSpace(req.Source)),
+		Target:       strings.ToLower(strings.TrimSpace(req.Target)),
+		Mode:         strings.ToLower(strings.TrimSpace(req.Mode)),
+		Segment:      strings.TrimSpace(req.Segment),
+		FuturesKinds: kinds,
+		Slug:         strings.TrimSpace(req.Slug),
+	}
+	if q := req.Quotes; q != nil {
+		qc := config.LoadQuotes() // незаданные поля — из env
+		if v := strings.TrimSpace(q.SourceSpotQuote); v != "" {
+			qc.SourceSpotQuote = strings.ToUpper(v)
+		}
+		if q.TargetAllowedQuotes != nil {
+			qc.TargetAllowedQuotes = map[string]struct{}{}
+			for _, v := range q.TargetAllowedQuotes {
+				qc.TargetAllowedQuotes[strings.ToUpper(strings.TrimSpace(v))] = struct{}{}
+			}
+		}
+		spec.Quotes = &qc
+	}
+
+	p, err := ctl.UC.Preview(c, spec)
+	var se *setexpr.SyntaxError
+	switch {
+	case errors.As(err, &se):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "segment: " + se.Error(), "pos": se.Pos})
+		return
+	case errors.Is(err, ldom.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, listsuc.ErrBadPreview), errors.Is(err, listsuc.ErrUnknownExchange),
+		errors.Is(err, listsuc.ErrUnknownSource), errors.Is(err, listsuc.ErrUnknownMode),
+		errors.Is(err, setexpr.ErrUnknownSet):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	out := previewResp{Count: len(p.Rows), Items: toPreviewRows(p.Rows)}
+	if d := p.Diff; d != nil {
+		out.Diff = &previewDiffDTO{
+			Added:   toPreviewRows(d.Added),
+			Removed: toPreviewRows(d.Removed),
+			Changed: make([]previewChangeDTO, 0, len(d.Changed)),
+		}
+		for _, ch := range d.Changed {
+			out.Diff.Changed = append(out.Diff.Changed, previewChangeDTO(ch))
+		}
+	}
+	c.JSON(http.StatusOK, out)
+}
+
+func toPreviewRows(rows []listsuc.Row) []previewRowDTO {
+	out := make([]previewRowDTO, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, previewRowDTO(r))
+	}
+	return out
+}
@@ -0,0 +1,96 @@
+package httpctrl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+type fakePreviewer struct{ got listsuc.PreviewSpec }
+
+func (f *fakePreviewer) Preview(ctx context.Context, spec listsuc.PreviewSpec) (listsuc.Preview, error) {
+	f.got = spec
+	if spec.Segment != "" {
+		if _, err := listsuc.ValidateSegmentExpr(spec.Segment); err != nil {
+			return listsuc.Preview{}, err
+		}
+	}
+	if (spec.Target == "") == (spec.Segment == "") {
+		return listsuc.Preview{}, listsuc.ErrBadPreview
+	}
+	if spec.Slug == "nope" {
+		return listsuc.Preview{}, ldom.ErrNotFound
+	}
+	p := listsuc.Preview{Rows: []listsuc.Row{{Spot: "PEPEUSDT", Futures: "none"}}}
+	if spec.Slug != "" {
+		p.Diff = &listsuc.PreviewDiff{
+			Added:   []listsuc.Row{{Spot: "PEPEUSDT", Futures: "none"}},
+			Changed: []listsuc.RowChange{{Spot: "ARBUSDT", From: "ARBUSDT", To: "none"}},
+		}
+	}
+	return p, nil
+}
+
+func TestPreview_Post(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	uc := &fakePreviewer{}
+	r := gin.New()
+	NewPreviewController(uc).Register(r)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/api/preview", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := post(`{"source":"Binance","segment":"S & U","futures_kinds":["delivery"],
+		"quotes":{"source_spot_quote":"usdc","target_allowed_quotes":["krw"]},"slug":"binance_seg4"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("code=%d body=%s", w.Code, w.Body.String())
+	}
+	g := uc.got
+	if g.Source != "binance" || g.Segment != "S & U" || len(g.FuturesKinds) != 1 || g.FuturesKinds[0] != dm.ContractDelivery {
+		t.Fatalf("spec: %+v", g)
+	}
+	if _, ok := g.Quotes.TargetAllowedQuotes["KRW"]; g.Quotes == nil || g.Quotes.SourceSpotQuote != "USDC" || !ok || len(g.Quotes.TargetAllowedQuotes) != 1 {
+		t.Fatalf("quotes: %+v", g.Quotes)
+	}
+	var got previewResp
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Count != 1 || got.Diff == nil || len(got.Diff.Removed) != 0 || got.Diff.Changed[0].To != "none" {
+		t.Fatalf("resp: %s", w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"removed":[]`) {
+		t.Fatalf("empty diff parts must be [] not null: %s", w.Body.String())
+	}
+
+	for _, body := range []string{
+		`{"target":"upbit"}`, // нет source
+		`{"source":"binance","target":"upbit","segment":"S"}`,
+		`{"source":"binance","segment":"S &"}`,
+		`{"source":"binance","target":"upbit","futures_kinds":["swap"]}`,
+		`not json`,
+	} {
+		if w := post(body); w.Code != http.StatusBadRequest {
+			t.Fatalf("%s: code=%d body=%s", body, w.Code, w.Body.String())
+		}
+	}
+
+	// неизвестный slug — 404, а не diff «всё добавлено»
+	if w := post(`{"source":"binance","target":"upbit","slug":"nope"}`); w.Code != http.StatusNotFound {
+		t.Fatalf("unknown slug: code=%d body=%s", w.Code, w.Body.String())
+	}
+}
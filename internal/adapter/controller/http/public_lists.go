@@ -1,26 +1,37 @@
 package httpctrl
 
 import (
+	"context"
+	"errors"
+	"math"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	listsfmt "github.com/berezovskyivalerii/tickersvc/internal/adapter/presenter/lists"
 	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	"github.com/berezovskyivalerii/tickersvc/internal/pkg/symbols"
 )
 
 type itemDTO struct {
-	SpotSymbol   string `json:"SpotSymbol"`
-	FutureSymbol string `json:"FutureSymbol"`
+	SpotSymbol   string   `json:"SpotSymbol"`
+	FutureSymbol string   `json:"FutureSymbol"`
+	VolumeUSD    *float64 `json:"VolumeUSD,omitempty"` // 24h-оборот спота; нет — биржа не отдаёт тикеры
 }
 
 type itemsResp struct {
-	Items []itemDTO `json:"items"`
+	Items      []itemDTO `json:"items"`
+	Total      *int      `json:"total,omitempty"`       // только с фильтрами/страницей
+	NextCursor string    `json:"next_cursor,omitempty"` // пусто — страница последняя
 }
 
 type PublicListsController struct {
 	Q ldom.QueryRepo
+	// Gens — опционально: X-Generation и ?generation= (согласованное чтение нескольких списков)
+	Gens ldom.GenerationRepo
 }
 
 func NewPublicListsController(q ldom.QueryRepo) *PublicListsController {
@@ -29,26 +40,203 @@ func NewPublicListsController(q ldom.QueryRepo) *PublicListsController {
 
 func (ctl *PublicListsController) Register(r *gin.Engine) {
 	api := r.Group("/api")
-	api.GET("/lists/:slug", ctl.bySlug)                   // JSON или text (as_text=1)
-	api.GET("/lists", ctl.byTarget)                       // уже умел text
+	api.GET("/lists/:slug", ctl.bySlug)                   // JSON, text, csv, tsv, ndjson, json-meta
+	api.GET("/lists", ctl.byTarget)                       // то же, сгруппировано по источникам
 	api.GET("/segments/:source/:seg", ctl.segmentForward) // без редиректа
 }
 
+// pinGeneration закрепляет чтение за поколением (?generation= или текущее) и отдаёт его в X-Generation.
+// Без Gens — контекст запроса как есть.
+func (ctl *PublicListsController) pinGeneration(c *gin.Context) (context.Context, bool) {
+	ctx := c.Request.Context()
+	if ctl.Gens == nil {
+		return ctx, true
+	}
+	var id int64
+	if v := strings.TrimSpace(c.Query("generation")); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "generation must be a positive integer"})
+			return nil, false
+		}
+		id = n
+	}
+	g, err := ctl.Gens.Generation(ctx, id)
+	if errors.Is(err, ldom.ErrGenerationNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	c.Header("X-Generation", strconv.FormatInt(g.ID, 10))
+	return ldom.WithGeneration(ctx, g.ID), true
+}
+
 func wantText(ctx *gin.Context) bool {
 	v := strings.ToLower(strings.TrimSpace(ctx.Query("as_text")))
 	return v == "1" || v == "true" || v == "yes"
 }
 
+// negotiate: ?format= → as_text=1 → Accept. На неизвестный ?format= отвечает 400.
+func negotiate(c *gin.Context) (listsfmt.Format, bool) {
+	f, err := listsfmt.Negotiate(c.Query("format"), wantText(c), c.GetHeader("Accept"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be one of: json, json-meta, text, csv, tsv, ndjson"})
+		return "", false
+	}
+	return f, true
+}
+
+// ?notation=raw|tradingview|ccxt; на неизвестное значение отвечает 400.
+func notation(c *gin.Context) (symbols.Notation, bool) {
+	n, err := symbols.ParseNotation(c.Query("notation"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "notation must be one of: raw, tradingview, ccxt"})
+		return "", false
+	}
+	return n, true
+}
+
+// rowsFilter: ?q=, ?has_futures=, ?quote=USDT,USDC, ?min_volume_usd=, ?sort=spot|volume, ?limit=, ?cursor=.
+// active == false — ни одного
+// параметра нет, отвечаем как раньше (весь список без total).
+func rowsFilter(c *gin.Context) (f ldom.RowsFilter, active, ok bool) {
+	f.Q = strings.TrimSpace(c.Query("q"))
+	f.Cursor = strings.TrimSpace(c.Query("cursor"))
+	if v := strings.TrimSpace(c.Query("has_futures")); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "has_futures must be true or false"})
+			return f, false, false
+		}
+		f.HasFutures = &b
+	}
+	for _, q := range strings.Split(c.Query("quote"), ",") {
+		if q = strings.TrimSpace(q); q != "" {
+			f.Quotes = append(f.Quotes, strings.ToUpper(q))
+		}
+	}
+	if v := strings.TrimSpace(c.Query("min_volume_usd")); v != "" {
+		x, err := strconv.ParseFloat(v, 64)
+		if err != nil || x < 0 || math.IsInf(x, 0) || math.IsNaN(x) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "min_volume_usd must be a non-negative number"})
+			return f, false, false
+		}
+		f.MinVolume = x
+	}
+	switch v := strings.ToLower(strings.TrimSpace(c.Query("sort"))); v {
+	case "":
+	case ldom.SortSpot, ldom.SortVolume:
+		f.Sort = v
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort must be one of: spot, volume"})
+		return f, false, false
+	}
+	if v := strings.TrimSpace(c.Query("limit")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return f, false, false
+		}
+		f.Limit = n
+	}
+	active = f.Q != "" || f.HasFutures != nil || len(f.Quotes) > 0 || f.MinVolume > 0 || f.Sort != "" ||
+		f.Limit > 0 || f.Cursor != ""
+	return f, active, true
+}
+
+// withVolume: колонка volume_usd в csv/tsv — только когда о ней спросили (иначе прежний формат).
+func withVolume(f ldom.RowsFilter) bool {
+	return f.Sort == ldom.SortVolume || f.MinVolume > 0
+}
+
+// findError: испорченный курсор — 400, остальное — 500.
+func findError(c *gin.Context, err error) {
+	if errors.Is(err, ldom.ErrBadCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cursor"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// pageHeaders — total и курсор дублируются в заголовках для text/csv/tsv/ndjson.
+func pageHeaders(c *gin.Context, p ldom.RowsPage) {
+	c.Header("X-Total-Count", strconv.Itoa(p.Total))
+	if p.NextCursor != "" {
+		c.Header("X-Next-Cursor", p.NextCursor)
+	}
+}
+
+func render(c *gin.Context, f listsfmt.Format, doc listsfmt.Doc) {
+	c.Header("Content-Type", listsfmt.ContentType(f))
+	c.Status(http.StatusOK)
+	if err := listsfmt.Render(c.Writer, f, doc); err != nil {
+		_ = c.Error(err)
+	}
+}
+
 func (ctl *PublicListsController) bySlug(c *gin.Context) {
+	f, ok := negotiate(c)
+	if !ok {
+		return
+	}
+	n, ok := notation(c)
+	if !ok {
+		return
+	}
+	filter, paged, ok := rowsFilter(c)
+	if !ok {
+		return
+	}
 	slug := c.Param("slug")
-	rows, err := ctl.Q.GetRowsBySlug(c, slug)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	ctx, ok := ctl.pinGeneration(c)
+	if !ok {
 		return
 	}
 
-	if wantText(c) {
-		writeTextRows(c, rows)
+	// неизвестный slug — 404 только в json-meta: json и text исторически отдают пустой список
+	var meta listsfmt.Meta
+	if f == listsfmt.FormatJSONMeta {
+		m, err := ctl.Q.GetMeta(ctx, slug)
+		if errors.Is(err, ldom.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		meta = listsfmt.FromMeta(m)
+	}
+
+	var rows []ldom.Row
+	var page ldom.RowsPage
+	if paged {
+		p, err := ctl.Q.FindRowsBySlug(ctx, slug, filter)
+		if err != nil {
+			findError(c, err)
+			return
+		}
+		page, rows = p, p.Rows
+		pageHeaders(c, page)
+	} else {
+		var err error
+		if rows, err = ctl.Q.GetRowsBySlug(ctx, slug); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	rows = listsfmt.Notate(rows, n)
+
+	if f != listsfmt.FormatJSON {
+		render(c, f, listsfmt.Doc{
+			Meta:       meta,
+			Records:    listsfmt.FromRows("", rows),
+			Ordered:    filter.Sort == ldom.SortVolume,
+			WithVolume: withVolume(filter),
+		})
 		return
 	}
 
@@ -58,9 +246,13 @@ func (ctl *PublicListsController) bySlug(c *gin.Context) {
 		if r.Futures != nil && *r.Futures != "" {
 			fs = *r.Futures
 		}
-		items = append(items, itemDTO{SpotSymbol: r.Spot, FutureSymbol: fs})
+		items = append(items, itemDTO{SpotSymbol: r.Spot, FutureSymbol: fs, VolumeUSD: r.VolumeUSD})
 	}
-	c.JSON(http.StatusOK, itemsResp{Items: items})
+	resp := itemsResp{Items: items}
+	if paged {
+		resp.Total, resp.NextCursor = &page.Total, page.NextCursor
+	}
+	c.JSON(http.StatusOK, resp)
 }
 
 func (ctl *PublicListsController) byTarget(ctx *gin.Context) {
@@ -69,77 +261,133 @@ func (ctl *PublicListsController) byTarget(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "missing target"})
 		return
 	}
-	data, err := ctl.Q.GetTextByTarget(ctx, target) // map[source][]lines
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	f, ok := negotiate(ctx)
+	if !ok {
+		return
+	}
+	n, ok := notation(ctx)
+	if !ok {
+		return
+	}
+
+	filter, paged, ok := rowsFilter(ctx)
+	if !ok {
+		return
+	}
+
+	gctx, ok := ctl.pinGeneration(ctx)
+	if !ok {
 		return
 	}
-	if wantText(ctx) {
-		keys := make([]string, 0, len(data))
-		for k := range data {
-			keys = append(keys, k)
+
+	var data map[string][]ldom.Row // source → строки
+	var page ldom.RowsPage
+	if paged {
+		p, err := ctl.Q.FindRowsByTarget(gctx, target, filter)
+		if err != nil {
+			findError(ctx, err)
+			return
 		}
-		sort.Strings(keys)
-		ctx.Header("Content-Type", "text/plain; charset=utf-8")
-		first := true
-		for _, k := range keys {
-			for _, s := range data[k] {
-				if !first {
-					_, _ = ctx.Writer.WriteString("\n")
-				} else {
-					first = false
-				}
-				_, _ = ctx.Writer.WriteString(s)
+		page, data = p, map[string][]ldom.Row{}
+		page.Rows = listsfmt.Notate(p.Rows, n)
+		for _, r := range page.Rows {
+			data[r.Source] = append(data[r.Source], r)
+		}
+		pageHeaders(ctx, page)
+	} else {
+		all, err := ctl.Q.GetRowsByTarget(gctx, target)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		// карта из кэша общая для всех читателей — пишем в свою
+		data = make(map[string][]ldom.Row, len(all))
+		for src, rows := range all {
+			data[src] = listsfmt.Notate(rows, n)
+		}
+	}
+
+	if f == listsfmt.FormatJSON {
+		// исторический формат: map[source][]"SPOT, FUTURES"
+		lines := make(map[string][]string, len(data))
+		for src, rows := range data {
+			for _, r := range listsfmt.FromRows(src, rows) {
+				lines[src] = append(lines[src], r.Spot+", "+r.Futures)
 			}
 		}
-		if !first {
-			_, _ = ctx.Writer.WriteString("\n")
+		resp := gin.H{"target": target, "sources": lines}
+		if paged {
+			resp["total"] = page.Total
+			if page.NextCursor != "" {
+				resp["next_cursor"] = page.NextCursor
+			}
 		}
+		ctx.JSON(http.StatusOK, resp)
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"target": target, "sources": data})
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	doc := listsfmt.Doc{
+		Meta:       listsfmt.Meta{Target: target, Sources: keys},
+		WithSource: true,
+		WithVolume: withVolume(filter),
+	}
+	if filter.Sort == ldom.SortVolume {
+		// общий порядок по обороту, источники вперемешку
+		for _, r := range page.Rows {
+			doc.Records = append(doc.Records, listsfmt.FromRows(r.Source, []ldom.Row{r})...)
+		}
+	} else {
+		for _, k := range keys {
+			doc.Records = append(doc.Records, listsfmt.FromRows(k, data[k])...)
+		}
+	}
+	render(ctx, f, doc)
 }
 
-// внутренний форвард без 307
+// внутренний форвард без 307; :seg — номер (4 → seg4) или имя сегмента источника из list_defs
 func (ctl *PublicListsController) segmentForward(c *gin.Context) {
 	source := strings.ToLower(strings.TrimSpace(c.Param("source")))
-	seg := strings.TrimSpace(c.Param("seg"))
+	seg := strings.ToLower(strings.TrimSpace(c.Param("seg")))
+	if _, err := strconv.Atoi(seg); err == nil {
+		seg = "seg" + seg
+	}
 
-	switch source {
-	case "binance", "bybit", "okx":
-	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": "source must be one of: binance, bybit, okx"})
+	slug, err := ctl.segmentSlug(c, source, seg)
+	if errors.Is(err, ldom.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "segment not found: " + source + "/" + seg})
 		return
 	}
-	switch seg {
-	case "0", "1", "2", "3", "4":
-	default:
-		c.JSON(http.StatusBadRequest, gin.H{"error": "seg must be 0, 1, 2, 3, or 4"})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// подложим slug и переиспользуем bySlug (сохранит ?as_text=1)
-	slug := source + "_seg" + seg
+	// подложим slug и переиспользуем bySlug (сохранит ?as_text=1, ?format= и фильтры)
 	c.Params = append(c.Params, gin.Param{Key: "slug", Value: slug})
 	ctl.bySlug(c)
 }
 
-func writeTextRows(c *gin.Context, rows []ldom.Row) {
-	lines := make([]string, 0, len(rows))
-	for _, r := range rows {
-		fs := "none"
-		if r.Futures != nil && *r.Futures != "" {
-			fs = *r.Futures
-		}
-		lines = append(lines, r.Spot+", "+fs)
+// segmentSlug — slug сегмента по источнику и имени: у своих сегментов slug произвольный.
+// Без MetaLister — по соглашению встроенных ({source}_seg{n}).
+func (ctl *PublicListsController) segmentSlug(ctx context.Context, source, seg string) (string, error) {
+	ml, ok := ctl.Q.(ldom.MetaLister)
+	if !ok {
+		return source + "_" + seg, nil
+	}
+	metas, err := ml.ListMetas(ctx)
+	if err != nil {
+		return "", err
 	}
-	sort.Strings(lines)
-	c.Header("Content-Type", "text/plain; charset=utf-8")
-	for i, line := range lines {
-		if i > 0 {
-			_, _ = c.Writer.WriteString("\n")
+	for _, m := range metas {
+		if m.Kind == "segment" && m.SourceSlug == source && m.Segment == seg {
+			return m.Slug, nil
 		}
-		_, _ = c.Writer.WriteString(line)
 	}
-	_, _ = c.Writer.WriteString("\n")
+	return "", ldom.ErrNotFound
 }
@@ -0,0 +1,70 @@
+package httpctrl
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/cache"
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+)
+
+// cachedQuery — fakeQuery с указателем поколения, чтобы завернуть его в cache.ListsQuery.
+type cachedQuery struct{ *fakeQuery }
+
+func (cachedQuery) Generation(ctx context.Context, id int64) (ldom.Generation, error) {
+	if id == 0 {
+		id = 1
+	}
+	return ldom.Generation{ID: id}, nil
+}
+
+// Кэш отдаёт всем читателям одну и ту же карту target → строки: контроллер не должен её менять
+// (go test -race; раньше — concurrent map writes и закэшированные строки в чужой нотации).
+func TestPublicLists_TargetThroughCache_Concurrent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	q := &fakeQuery{target: map[string]map[string][]ldom.Row{
+		"upbit": {
+			"okx": {{Spot: "AAA-USDT", Futures: strPtr("AAA-USDT-SWAP"), Source: "okx",
+				Base: "AAA", Quote: "USDT", FuturesBase: "AAA", FuturesQuote: "USDT", FuturesSettle: "USDT"}},
+			"binance": {{Spot: "CCCUSDT", Source: "binance", Base: "CCC", Quote: "USDT"}},
+		},
+	}}
+	r := gin.New()
+	NewPublicListsController(cache.NewListsQuery(cachedQuery{q}, cache.Options{})).Register(r)
+
+	get := func(path string) string {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: status=%d body=%s", path, w.Code, w.Body.String())
+		}
+		return w.Body.String()
+	}
+	const raw = "source,spot,futures\nbinance,CCCUSDT,none\nokx,AAA-USDT,AAA-USDT-SWAP\n"
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%2 == 0 {
+				get("/api/lists?target=upbit&format=csv&notation=ccxt")
+				return
+			}
+			if got := get("/api/lists?target=upbit&format=csv"); got != raw {
+				t.Errorf("raw read got %q", got)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if got := get("/api/lists?target=upbit&format=csv"); got != raw {
+		t.Fatalf("cached rows rewritten: %q", got)
+	}
+}
@@ -0,0 +1,157 @@
+package httpctrl
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+)
+
+func TestPublicLists_FilterAndPage(t *testing.T) {
+	r := newPublicRouter()
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	items := func(w *httptest.ResponseRecorder) itemsResp {
+		t.Helper()
+		if w.Code != http.StatusOK {
+			t.Fatalf("status %d: %s", w.Code, w.Body.String())
+		}
+		var resp itemsResp
+		if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+			t.Fatal(err)
+		}
+		return resp
+	}
+
+	// поиск по сегменту: префикс базы без учёта регистра
+	w := get("/api/segments/binance/1?q=pe")
+	resp := items(w)
+	if len(resp.Items) != 1 || resp.Items[0].SpotSymbol != "PEPEUSDT" || resp.Total == nil || *resp.Total != 1 {
+		t.Fatalf("q=pe: %s", w.Body.String())
+	}
+	if got := w.Header().Get("X-Total-Count"); got != "1" {
+		t.Fatalf("X-Total-Count = %q", got)
+	}
+
+	// страницы по одному: курсор ведёт до конца, total не меняется
+	var spots []string
+	path := "/api/lists/okx_to_upbit?limit=1"
+	for i := 0; path != ""; i++ {
+		if i > 3 {
+			t.Fatal("cursor does not terminate")
+		}
+		resp := items(get(path))
+		if resp.Total == nil || *resp.Total != 2 || len(resp.Items) != 1 {
+			t.Fatalf("page %d: %+v", i, resp)
+		}
+		spots = append(spots, resp.Items[0].SpotSymbol)
+		path = ""
+		if resp.NextCursor != "" {
+			path = "/api/lists/okx_to_upbit?limit=1&cursor=" + url.QueryEscape(resp.NextCursor)
+		}
+	}
+	if len(spots) != 2 || spots[0] != "AAA-USDT" || spots[1] != "BBB-USDT" {
+		t.Fatalf("pages = %v", spots)
+	}
+
+	// по цели: has_futures=false оставляет строки без фьючерсов у всех источников
+	w = get("/api/lists?target=upbit&has_futures=false")
+	var byTarget struct {
+		Sources map[string][]string `json:"sources"`
+		Total   int                 `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &byTarget); err != nil || w.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", w.Code, w.Body.String())
+	}
+	if byTarget.Total != 2 || len(byTarget.Sources["okx"]) != 1 || len(byTarget.Sources["binance"]) != 1 {
+		t.Fatalf("has_futures=false: %s", w.Body.String())
+	}
+
+	// без параметров — прежний ответ, без total
+	if resp := items(get("/api/lists/okx_to_upbit")); resp.Total != nil || len(resp.Items) != 2 {
+		t.Fatalf("plain: %+v", resp)
+	}
+}
+
+func TestPublicLists_FilterBadParams(t *testing.T) {
+	r := newPublicRouter()
+	for _, path := range []string{
+		"/api/lists/okx_to_upbit?has_futures=maybe",
+		"/api/lists/okx_to_upbit?limit=0",
+		"/api/lists/okx_to_upbit?limit=x",
+		"/api/lists/okx_to_upbit?cursor=bad",
+		"/api/lists?target=upbit&cursor=bad",
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", path, w.Code)
+		}
+	}
+}
+
+func TestPublicLists_SortByVolume(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	vol := func(v float64) *float64 { return &v }
+	q := &fakeQuery{
+		rows: map[string][]ldom.Row{"binance_seg1": {
+			{Spot: "AAAUSDT", Source: "binance", VolumeUSD: vol(5e5)},
+			{Spot: "BBBUSDT", Source: "binance"},
+			{Spot: "CCCUSDT", Source: "binance", VolumeUSD: vol(9e7)},
+		}},
+		target: map[string]map[string][]ldom.Row{"upbit": {
+			"binance": {{Spot: "AAAUSDT", VolumeUSD: vol(5e5)}},
+			"okx":     {{Spot: "DDD-USDT", VolumeUSD: vol(2e6)}},
+		}},
+	}
+	r := gin.New()
+	NewPublicListsController(q).Register(r)
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: status %d: %s", path, w.Code, w.Body.String())
+		}
+		return w
+	}
+
+	var resp itemsResp
+	if err := json.Unmarshal(get("/api/lists/binance_seg1?sort=volume").Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, it := range resp.Items {
+		got = append(got, it.SpotSymbol)
+	}
+	if strings.Join(got, " ") != "CCCUSDT AAAUSDT BBBUSDT" || resp.Items[0].VolumeUSD == nil || *resp.Items[0].VolumeUSD != 9e7 {
+		t.Fatalf("sort=volume: %+v", resp)
+	}
+
+	// text сохраняет порядок по обороту, min_volume_usd отсекает строки без оборота
+	if body := get("/api/lists/binance_seg1?sort=volume&min_volume_usd=1000&format=text").Body.String(); body != "CCCUSDT, none\nAAAUSDT, none\n" {
+		t.Fatalf("text: %q", body)
+	}
+
+	// по цели — общий порядок, колонка volume_usd в csv
+	want := "source,spot,futures,volume_usd\nokx,DDD-USDT,none,2000000.00\nbinance,AAAUSDT,none,500000.00\n"
+	if body := get("/api/lists?target=upbit&sort=volume&format=csv").Body.String(); body != want {
+		t.Fatalf("csv:\n%s", body)
+	}
+
+	for _, path := range []string{"/api/lists/binance_seg1?sort=price", "/api/lists/binance_seg1?min_volume_usd=-1"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", path, w.Code)
+		}
+	}
+}
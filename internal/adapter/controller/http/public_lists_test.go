@@ -0,0 +1,316 @@
+package httpctrl
+
+import (
+	"context"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata/")
+
+type fakeQuery struct {
+	rows   map[string][]ldom.Row            // slug -> rows
+	target map[string]map[string][]ldom.Row // target -> source -> rows
+}
+
+func (q *fakeQuery) GetTextBySlug(ctx context.Context, slug string) ([]string, error) {
+	return nil, nil
+}
+
+func (q *fakeQuery) GetTextByTarget(ctx context.Context, targetSlug string) (map[string][]string, error) {
+	out := map[string][]string{}
+	for src, rows := range q.target[targetSlug] {
+		for _, r := range rows {
+			fs := "none"
+			if r.Futures != nil {
+				fs = *r.Futures
+			}
+			out[src] = append(out[src], r.Spot+", "+fs)
+		}
+	}
+	return out, nil
+}
+
+func (q *fakeQuery) GetAllText(ctx context.Context) (map[string]map[string][]string, error) {
+	return nil, nil
+}
+
+func (q *fakeQuery) GetRowsBySlug(ctx context.Context, slug string) ([]ldom.Row, error) {
+	return q.rows[slug], nil
+}
+
+func (q *fakeQuery) GetRowsByTarget(ctx context.Context, targetSlug string) (map[string][]ldom.Row, error) {
+	return q.target[targetSlug], nil
+}
+
+func (q *fakeQuery) FindRowsBySlug(ctx context.Context, slug string, f ldom.RowsFilter) (ldom.RowsPage, error) {
+	return findRows(q.rows[slug], f)
+}
+
+func (q *fakeQuery) FindRowsByTarget(ctx context.Context, targetSlug string, f ldom.RowsFilter) (ldom.RowsPage, error) {
+	var all []ldom.Row
+	for src, rows := range q.target[targetSlug] {
+		for _, r := range rows {
+			r.Source = src
+			all = append(all, r)
+		}
+	}
+	return findRows(all, f)
+}
+
+// findRows — фильтр как в Postgres; курсор — "source|spot" последней отданной строки.
+func findRows(rows []ldom.Row, f ldom.RowsFilter) (ldom.RowsPage, error) {
+	q := strings.ToUpper(f.Q)
+	var match []ldom.Row
+	for _, r := range rows {
+		switch {
+		case q != "" && !strings.HasPrefix(r.Base, q) && !strings.Contains(strings.ToUpper(r.Spot), q):
+		case f.HasFutures != nil && *f.HasFutures != (r.Futures != nil):
+		case len(f.Quotes) > 0 && !slices.Contains(f.Quotes, r.Quote):
+		case f.MinVolume > 0 && (r.VolumeUSD == nil || *r.VolumeUSD < f.MinVolume):
+		default:
+			match = append(match, r)
+		}
+	}
+	vol := func(r ldom.Row) float64 {
+		if r.VolumeUSD == nil {
+			return -1
+		}
+		return *r.VolumeUSD
+	}
+	sort.Slice(match, func(i, j int) bool {
+		if f.Sort == ldom.SortVolume && vol(match[i]) != vol(match[j]) {
+			return vol(match[i]) > vol(match[j])
+		}
+		if match[i].Source != match[j].Source {
+			return match[i].Source < match[j].Source
+		}
+		return match[i].Spot < match[j].Spot
+	})
+	page := ldom.RowsPage{Total: len(match)}
+	if f.Cursor != "" {
+		src, spot, ok := strings.Cut(f.Cursor, "|")
+		if !ok {
+			return ldom.RowsPage{}, ldom.ErrBadCursor
+		}
+		i := slices.IndexFunc(match, func(r ldom.Row) bool { return r.Source == src && r.Spot == spot })
+		match = match[i+1:] // -1 → с начала
+	}
+	if f.Limit > 0 && len(match) > f.Limit {
+		match = match[:f.Limit]
+		last := match[len(match)-1]
+		page.NextCursor = last.Source + "|" + last.Spot
+	}
+	page.Rows = match
+	return page, nil
+}
+
+func (q *fakeQuery) GetMeta(ctx context.Context, slug string) (ldom.Meta, error) {
+	rows, ok := q.rows[slug]
+	if !ok {
+		return ldom.Meta{}, ldom.ErrNotFound
+	}
+	m := ldom.Meta{
+		Slug:      slug,
+		UpdatedAt: time.Date(2025, 8, 17, 11, 50, 7, 0, time.UTC),
+		Count:     len(rows),
+	}
+	src, rest, _ := strings.Cut(slug, "_")
+	m.SourceSlug = src
+	if tgt, ok := strings.CutPrefix(rest, "to_"); ok {
+		m.Kind, m.TargetSlug = "target", tgt
+	} else {
+		m.Kind, m.Segment = "segment", rest
+	}
+	return m, nil
+}
+
+func (q *fakeQuery) ListMetas(ctx context.Context) ([]ldom.Meta, error) {
+	var out []ldom.Meta
+	for slug := range q.rows {
+		m, _ := q.GetMeta(ctx, slug)
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func newPublicRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	q := &fakeQuery{
+		rows: map[string][]ldom.Row{
+			"okx_to_upbit": {
+				{Spot: "AAA-USDT", Futures: strPtr("AAA-USDT-SWAP"), Source: "okx"},
+				{Spot: "BBB-USDT", Source: "okx"},
+			},
+			"binance_seg1": {
+				{Spot: "PEPEUSDT", Futures: strPtr("1000PEPEUSDT"), Source: "binance",
+					Base: "PEPE", Quote: "USDT", FuturesBase: "1000PEPE", FuturesQuote: "USDT"},
+				{Spot: "ARBUSDT", Source: "binance", Base: "ARB", Quote: "USDT"},
+			},
+			"binance_memes": {{Spot: "DOGEUSDT", Source: "binance", Base: "DOGE", Quote: "USDT"}},
+		},
+		target: map[string]map[string][]ldom.Row{
+			"upbit": {
+				"okx":     {{Spot: "AAA-USDT", Futures: strPtr("AAA-USDT-SWAP"), Source: "okx"}, {Spot: "BBB-USDT", Source: "okx"}},
+				"binance": {{Spot: "CCCUSDT", Source: "binance"}},
+			},
+		},
+	}
+	r := gin.New()
+	NewPublicListsController(q).Register(r)
+	return r
+}
+
+func TestPublicLists_Golden(t *testing.T) {
+	r := newPublicRouter()
+
+	endpoints := map[string]string{
+		"slug":    "/api/lists/okx_to_upbit",
+		"target":  "/api/lists?target=upbit",
+		"segment": "/api/segments/binance/1",
+	}
+	formats := []string{"json", "json-meta", "text", "csv", "tsv", "ndjson"}
+
+	for name, path := range endpoints {
+		for _, f := range formats {
+			sep := "?"
+			if strings.Contains(path, "?") {
+				sep = "&"
+			}
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, path+sep+"format="+f, nil)
+			r.ServeHTTP(w, req)
+			if w.Code != http.StatusOK {
+				t.Fatalf("%s %s: status=%d body=%s", name, f, w.Code, w.Body.String())
+			}
+			checkGolden(t, filepath.Join("testdata", name+"_"+f+".golden"), w.Body.Bytes())
+		}
+	}
+}
+
+func TestPublicLists_AcceptHeader(t *testing.T) {
+	r := newPublicRouter()
+
+	cases := map[string]string{
+		"text/csv":                  "text/csv",
+		"text/tab-separated-values": "text/tab-separated-values",
+		"application/x-ndjson":      "application/x-ndjson",
+		"text/plain":                "text/plain",
+	}
+	for accept, wantCT := range cases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, "/api/segments/binance/1", nil)
+		req.Header.Set("Accept", accept)
+		r.ServeHTTP(w, req)
+		if got := w.Header().Get("Content-Type"); !strings.HasPrefix(got, wantCT) {
+			t.Fatalf("accept %s: content-type=%q", accept, got)
+		}
+	}
+}
+
+func TestPublicLists_BadFormatAndUnknownSlug(t *testing.T) {
+	r := newPublicRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/lists/okx_to_upbit?format=xml", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("format=xml: want 400, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/lists/nope?format=json-meta", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound || w.Body.String() != `{"error":"list not found"}` {
+		t.Fatalf("unknown slug meta: %d %s", w.Code, w.Body.String())
+	}
+
+	// json исторически — 200 с пустым списком
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/api/lists/nope", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Body.String() != `{"items":[]}` {
+		t.Fatalf("unknown slug json: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestPublicLists_Notation(t *testing.T) {
+	r := newPublicRouter()
+
+	cases := map[string]string{
+		"/api/segments/binance/1?format=text&notation=tradingview": "BINANCE:ARBUSDT, none\nBINANCE:PEPEUSDT, BINANCE:1000PEPEUSDT.P\n",
+		"/api/segments/binance/1?format=text&notation=ccxt":        "ARB/USDT, none\nPEPE/USDT, 1000PEPE/USDT:USDT\n",
+		"/api/lists/okx_to_upbit?format=text&notation=tv":          "OKX:AAAUSDT, OKX:AAAUSDT.P\nOKX:BBBUSDT, none\n",
+		"/api/lists?target=upbit&format=csv&notation=ccxt":         "source,spot,futures\nbinance,CCC/USDT,none\nokx,AAA/USDT,AAA/USDT:USDT\nokx,BBB/USDT,none\n",
+	}
+	for path, want := range cases {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		r.ServeHTTP(w, req)
+		if w.Code != http.StatusOK || w.Body.String() != want {
+			t.Fatalf("%s: status=%d\n got=%q\nwant=%q", path, w.Code, w.Body.String(), want)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/lists/okx_to_upbit?notation=bloomberg", nil)
+	r.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("unknown notation: want 400, got %d", w.Code)
+	}
+}
+
+func checkGolden(t *testing.T, path string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden (run with -update): %v", err)
+	}
+	if string(want) != string(got) {
+		t.Fatalf("%s mismatch:\n got=%q\nwant=%q", path, got, want)
+	}
+}
+
+// /api/segments/:source/:seg — номер или имя сегмента из определений, а не фиксированный список
+func TestPublicLists_SegmentForward(t *testing.T) {
+	r := newPublicRouter()
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	cases := map[string]string{
+		"/api/segments/binance/1?format=text":     "ARBUSDT, none\nPEPEUSDT, 1000PEPEUSDT\n",
+		"/api/segments/binance/seg1?format=text":  "ARBUSDT, none\nPEPEUSDT, 1000PEPEUSDT\n",
+		"/api/segments/Binance/memes?format=text": "DOGEUSDT, none\n",
+	}
+	for path, want := range cases {
+		if w := get(path); w.Code != http.StatusOK || w.Body.String() != want {
+			t.Fatalf("%s: %d %q", path, w.Code, w.Body.String())
+		}
+	}
+	for _, path := range []string{"/api/segments/binance/9", "/api/segments/okx/memes", "/api/segments/kraken/1"} {
+		if w := get(path); w.Code != http.StatusNotFound {
+			t.Fatalf("%s: want 404, got %d %s", path, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package httpctrl
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/pkg/setexpr"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+type SetQuerier interface {
+	Query(ctx context.Context, expr, source string) (listsuc.QueryResult, error)
+}
+
+type QueryController struct {
+	UC SetQuerier
+}
+
+func NewQueryController(uc SetQuerier) *QueryController {
+	return &QueryController{UC: uc}
+}
+
+func (ctl *QueryController) Register(r *gin.Engine) {
+	r.GET("/api/query", ctl.get) // ?expr=S & U & !(C | H)&source=binance
+}
+
+type queryItemDTO struct {
+	Base    string `json:"base"`
+	Spot    string `json:"spot,omitempty"`
+	Futures string `json:"futures,omitempty"`
+}
+
+type queryDTO struct {
+	Expr   string         `json:"expr"`
+	Source string         `json:"source,omitempty"`
+	Count  int            `json:"count"`
+	Items  []queryItemDTO `json:"items"`
+}
+
+func (ctl *QueryController) get(c *gin.Context) {
+	expr := strings.TrimSpace(c.Query("expr"))
+	if expr == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expr is required"})
+		return
+	}
+	res, err := ctl.UC.Query(c, expr, strings.ToLower(strings.TrimSpace(c.Query("source"))))
+	var se *setexpr.SyntaxError
+	switch {
+	case errors.As(err, &se):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "expr: " + se.Error(), "pos": se.Pos})
+		return
+	case errors.Is(err, setexpr.ErrUnknownSet), errors.Is(err, listsuc.ErrUnknownSource),
+		errors.Is(err, listsuc.ErrSourceRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	out := queryDTO{Expr: res.Expr, Source: res.Source, Count: len(res.Items), Items: make([]queryItemDTO, 0, len(res.Items))}
+	for _, it := range res.Items {
+		out.Items = append(out.Items, queryItemDTO(it))
+	}
+	c.JSON(http.StatusOK, out)
+}
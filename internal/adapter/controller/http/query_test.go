@@ -0,0 +1,69 @@
+package httpctrl
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+// fakeQuerier проверяет выражение настоящим валидатором и отдаёт фиксированный результат
+type fakeQuerier struct{}
+
+func (fakeQuerier) Query(ctx context.Context, expr, source string) (listsuc.QueryResult, error) {
+	e, err := listsuc.ValidateSegmentExpr(expr)
+	if err != nil {
+		return listsuc.QueryResult{}, err
+	}
+	if source == "" {
+		return listsuc.QueryResult{}, listsuc.ErrSourceRequired
+	}
+	return listsuc.QueryResult{Expr: e.String(), Source: source, Items: []listsuc.QueryItem{
+		{Base: "PEPE", Spot: "PEPEUSDT", Futures: "1000PEPEUSDT"},
+	}}, nil
+}
+
+func TestQuery_Get(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	NewQueryController(fakeQuerier{}).Register(r)
+
+	get := func(expr, source string) *httptest.ResponseRecorder {
+		q := url.Values{"expr": {expr}}
+		if source != "" {
+			q.Set("source", source)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/query?"+q.Encode(), nil))
+		return w
+	}
+
+	w := get("S&U&!(C|H)", "Binance")
+	if w.Code != http.StatusOK {
+		t.Fatalf("code=%d body=%s", w.Code, w.Body.String())
+	}
+	var got queryDTO
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Expr != "S & U & !(C | H)" || got.Source != "binance" || got.Count != 1 || got.Items[0].Futures != "1000PEPEUSDT" {
+		t.Fatalf("got %+v", got)
+	}
+
+	for _, c := range []struct{ expr, source string }{
+		{"", "binance"},
+		{"S & (U", "binance"}, // синтаксис
+		{"S & kraken", "binance"},
+		{"S & U", ""}, // S без источника
+	} {
+		if w := get(c.expr, c.source); w.Code != http.StatusBadRequest {
+			t.Fatalf("%q: code=%d body=%s", c.expr, w.Code, w.Body.String())
+		}
+	}
+}
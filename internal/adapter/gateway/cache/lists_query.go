@@ -0,0 +1,217 @@
+// Package cache — кэширующие декораторы репозиториев.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+)
+
+// ListsSource — то, что кэшируется: чтение списков и указатель текущего поколения.
+type ListsSource interface {
+	ldom.QueryRepo
+	ldom.GenerationRepo
+}
+
+type Options struct {
+	TTL        time.Duration // сколько запись свежая без инвалидации
+	MaxStale   time.Duration // сколько после TTL/инвалидации запись ещё отдаётся, если БД недоступна
+	MaxEntries int           // LRU: сверх лимита вытесняются давно не читанные
+
+	Now func() time.Time // для тестов; nil — time.Now
+}
+
+// Stats — счётчики для /admin/cache.
+type Stats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Stale     uint64 `json:"stale"` // отдано устаревшее из-за ошибки БД
+	Evictions uint64 `json:"evictions"`
+	Entries   int    `json:"entries"`
+	Epoch     uint64 `json:"epoch"` // сколько раз инвалидирован
+}
+
+// ListsQuery — кэш готовых строк списков по slug и target поверх ListsSource.
+// Ключ включает поколение из ctx, Invalidate (публикация нового поколения) делает устаревшим всё.
+// Возвращаемые срезы и map общие для всех читателей — менять их нельзя.
+type ListsQuery struct {
+	next ListsSource
+	opt  Options
+
+	mu    sync.Mutex
+	ll    *list.List // front — последние прочитанные
+	m     map[string]*list.Element
+	epoch uint64
+
+	hits, misses, stale, evictions atomic.Uint64
+}
+
+type entry struct {
+	key   string
+	val   any
+	epoch uint64
+	at    time.Time
+}
+
+func NewListsQuery(next ListsSource, opt Options) *ListsQuery {
+	if opt.TTL <= 0 {
+		opt.TTL = 10 * time.Minute
+	}
+	if opt.MaxEntries <= 0 {
+		opt.MaxEntries = 1000
+	}
+	if opt.Now == nil {
+		opt.Now = time.Now
+	}
+	return &ListsQuery{next: next, opt: opt, ll: list.New(), m: map[string]*list.Element{}}
+}
+
+// Invalidate — вызывается после коммита публикации: следующие чтения пойдут в БД.
+// Записи не удаляются сразу — они нужны, чтобы пережить недоступность БД.
+func (c *ListsQuery) Invalidate() {
+	c.mu.Lock()
+	c.epoch++
+	c.mu.Unlock()
+}
+
+func (c *ListsQuery) Stats() Stats {
+	c.mu.Lock()
+	n, epoch := c.ll.Len(), c.epoch
+	c.mu.Unlock()
+	return Stats{
+		Hits: c.hits.Load(), Misses: c.misses.Load(), Stale: c.stale.Load(), Evictions: c.evictions.Load(),
+		Entries: n, Epoch: epoch,
+	}
+}
+
+func cached[T any](c *ListsQuery, ctx context.Context, key string, load func(context.Context) (T, error)) (T, error) {
+	gen, _ := ldom.GenerationFrom(ctx)
+	key = strconv.FormatInt(gen, 10) + "|" + key
+
+	now := c.opt.Now()
+	c.mu.Lock()
+	var old *entry
+	if el, ok := c.m[key]; ok {
+		c.ll.MoveToFront(el)
+		e := *el.Value.(*entry)
+		old = &e
+	}
+	epoch := c.epoch
+	c.mu.Unlock()
+
+	if old != nil && old.epoch == epoch && now.Sub(old.at) < c.opt.TTL {
+		c.hits.Add(1)
+		return old.val.(T), nil
+	}
+	c.misses.Add(1)
+
+	v, err := load(ctx)
+	if err != nil {
+		// «нет такого списка/поколения» — ответ БД, а не её недоступность
+		notFound := errors.Is(err, ldom.ErrNotFound) || errors.Is(err, ldom.ErrGenerationNotFound)
+		if old != nil && !notFound && ctx.Err() == nil && now.Sub(old.at) < c.opt.TTL+c.opt.MaxStale {
+			c.stale.Add(1)
+			return old.val.(T), nil
+		}
+		return v, err
+	}
+	c.put(key, v, epoch, now)
+	return v, nil
+}
+
+// put сохраняет значение, если за время запроса не было инвалидации (иначе оно может быть старым).
+func (c *ListsQuery) put(key string, v any, epoch uint64, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if epoch != c.epoch {
+		return
+	}
+	if el, ok := c.m[key]; ok {
+		el.Value = &entry{key: key, val: v, epoch: epoch, at: at}
+		c.ll.MoveToFront(el)
+		return
+	}
+	c.m[key] = c.ll.PushFront(&entry{key: key, val: v, epoch: epoch, at: at})
+	for c.ll.Len() > c.opt.MaxEntries {
+		el := c.ll.Back()
+		c.ll.Remove(el)
+		delete(c.m, el.Value.(*entry).key)
+		c.evictions.Add(1)
+	}
+}
+
+func (c *ListsQuery) GetTextBySlug(ctx context.Context, slug string) ([]string, error) {
+	return cached(c, ctx, "text:"+slug, func(ctx context.Context) ([]string, error) { return c.next.GetTextBySlug(ctx, slug) })
+}
+
+func (c *ListsQuery) GetTextByTarget(ctx context.Context, target string) (map[string][]string, error) {
+	return cached(c, ctx, "ttext:"+target, func(ctx context.Context) (map[string][]string, error) {
+		return c.next.GetTextByTarget(ctx, target)
+	})
+}
+
+func (c *ListsQuery) GetAllText(ctx context.Context) (map[string]map[string][]string, error) {
+	return cached(c, ctx, "all", c.next.GetAllText)
+}
+
+func (c *ListsQuery) GetRowsBySlug(ctx context.Context, slug string) ([]ldom.Row, error) {
+	return cached(c, ctx, "rows:"+slug, func(ctx context.Context) ([]ldom.Row, error) { return c.next.GetRowsBySlug(ctx, slug) })
+}
+
+func (c *ListsQuery) GetRowsByTarget(ctx context.Context, target string) (map[string][]ldom.Row, error) {
+	return cached(c, ctx, "trows:"+target, func(ctx context.Context) (map[string][]ldom.Row, error) {
+		return c.next.GetRowsByTarget(ctx, target)
+	})
+}
+
+func (c *ListsQuery) GetMeta(ctx context.Context, slug string) (ldom.Meta, error) {
+	return cached(c, ctx, "meta:"+slug, func(ctx context.Context) (ldom.Meta, error) { return c.next.GetMeta(ctx, slug) })
+}
+
+// ListMetas — если источник умеет (ldom.MetaLister).
+func (c *ListsQuery) ListMetas(ctx context.Context) ([]ldom.Meta, error) {
+	ml, ok := c.next.(ldom.MetaLister)
+	if !ok {
+		return nil, errors.New("lists source cannot list metas")
+	}
+	return cached(c, ctx, "metas", ml.ListMetas)
+}
+
+func (c *ListsQuery) FindRowsBySlug(ctx context.Context, slug string, f ldom.RowsFilter) (ldom.RowsPage, error) {
+	return cached(c, ctx, "find:"+slug+"|"+filterKey(f), func(ctx context.Context) (ldom.RowsPage, error) {
+		return c.next.FindRowsBySlug(ctx, slug, f)
+	})
+}
+
+func (c *ListsQuery) FindRowsByTarget(ctx context.Context, target string, f ldom.RowsFilter) (ldom.RowsPage, error) {
+	return cached(c, ctx, "tfind:"+target+"|"+filterKey(f), func(ctx context.Context) (ldom.RowsPage, error) {
+		return c.next.FindRowsByTarget(ctx, target, f)
+	})
+}
+
+// filterKey — все поля фильтра; разделитель \x00 не встречается в параметрах запроса.
+func filterKey(f ldom.RowsFilter) string {
+	fut := "-"
+	if f.HasFutures != nil {
+		fut = strconv.FormatBool(*f.HasFutures)
+	}
+	return strings.Join([]string{f.Q, fut, strings.Join(f.Quotes, ","),
+		strconv.FormatFloat(f.MinVolume, 'g', -1, 64), f.Sort, strconv.Itoa(f.Limit), f.Cursor}, "\x00")
+}
+
+// Generation: текущее (id == 0) кэшируется и сбрасывается публикацией — так X-Generation
+// и чтения по нему переживают кратковременную недоступность БД.
+func (c *ListsQuery) Generation(ctx context.Context, id int64) (ldom.Generation, error) {
+	return cached(c, ctx, "gen:"+strconv.FormatInt(id, 10), func(ctx context.Context) (ldom.Generation, error) {
+		return c.next.Generation(ctx, id)
+	})
+}
+
+var _ ListsSource = (*ListsQuery)(nil)
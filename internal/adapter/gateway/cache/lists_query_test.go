@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+)
+
+// fakeSource — списки в «БД»; down — БД недоступна.
+type fakeSource struct {
+	ldom.QueryRepo
+	rows  map[string][]ldom.Row
+	gen   int64
+	calls int
+	down  bool
+	// during — вызывается посреди чтения (публикация, пока запрос в полёте)
+	during func()
+}
+
+var errDown = errors.New("connection refused")
+
+func (f *fakeSource) GetRowsBySlug(ctx context.Context, slug string) ([]ldom.Row, error) {
+	f.calls++
+	if f.during != nil {
+		f.during()
+	}
+	if f.down {
+		return nil, errDown
+	}
+	rows, ok := f.rows[slug]
+	if !ok {
+		return nil, ldom.ErrNotFound
+	}
+	return rows, nil
+}
+
+func (f *fakeSource) Generation(ctx context.Context, id int64) (ldom.Generation, error) {
+	f.calls++
+	if f.down {
+		return ldom.Generation{}, errDown
+	}
+	if id == 0 {
+		id = f.gen
+	}
+	return ldom.Generation{ID: id}, nil
+}
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func newTestCache(src *fakeSource, maxEntries int) (*ListsQuery, *clock) {
+	clk := &clock{t: time.Date(2025, 8, 17, 12, 0, 0, 0, time.UTC)}
+	c := NewListsQuery(src, Options{TTL: time.Minute, MaxStale: 5 * time.Minute, MaxEntries: maxEntries, Now: clk.now})
+	return c, clk
+}
+
+// first — spot первой строки или текст ошибки.
+func first(rows []ldom.Row, err error) string {
+	if err != nil {
+		return "error: " + err.Error()
+	}
+	return rows[0].Spot
+}
+
+func TestListsQuery_HitsAndInvalidate(t *testing.T) {
+	src := &fakeSource{rows: map[string][]ldom.Row{"a": {{Spot: "OLD"}}}, gen: 1}
+	c, clk := newTestCache(src, 10)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if got := first(c.GetRowsBySlug(ctx, "a")); got != "OLD" {
+			t.Fatalf("got %s", got)
+		}
+	}
+	if src.calls != 1 {
+		t.Fatalf("db calls %d, want 1", src.calls)
+	}
+
+	// публикация: новое читается сразу, не дожидаясь TTL
+	src.rows["a"] = []ldom.Row{{Spot: "NEW"}}
+	c.Invalidate()
+	if got := first(c.GetRowsBySlug(ctx, "a")); got != "NEW" {
+		t.Fatalf("after invalidate: %s", got)
+	}
+
+	// TTL
+	clk.t = clk.t.Add(2 * time.Minute)
+	_, _ = c.GetRowsBySlug(ctx, "a")
+	if st := c.Stats(); st.Hits != 2 || st.Misses != 3 || st.Epoch != 1 || st.Entries != 1 {
+		t.Fatalf("stats %+v", st)
+	}
+
+	// закреплённое поколение — отдельный ключ
+	_, _ = c.GetRowsBySlug(ldom.WithGeneration(ctx, 7), "a")
+	if st := c.Stats(); st.Entries != 2 {
+		t.Fatalf("stats %+v", st)
+	}
+}
+
+func TestListsQuery_PublishDuringRead(t *testing.T) {
+	src := &fakeSource{rows: map[string][]ldom.Row{"a": {{Spot: "OLD"}}}}
+	c, _ := newTestCache(src, 10)
+	ctx := context.Background()
+
+	// чтение начато до коммита, закончилось после: старое значение не кэшируется
+	src.during = func() { c.Invalidate(); src.during = nil }
+	_, _ = c.GetRowsBySlug(ctx, "a")
+	src.rows["a"] = []ldom.Row{{Spot: "NEW"}}
+	if got := first(c.GetRowsBySlug(ctx, "a")); got != "NEW" {
+		t.Fatalf("stale value cached across publish: %s", got)
+	}
+}
+
+func TestListsQuery_ServesStaleWhenDown(t *testing.T) {
+	src := &fakeSource{rows: map[string][]ldom.Row{"a": {{Spot: "A"}}}, gen: 3}
+	c, clk := newTestCache(src, 10)
+	ctx := context.Background()
+	_, _ = c.GetRowsBySlug(ctx, "a")
+	_, _ = c.Generation(ctx, 0)
+
+	src.down = true
+	c.Invalidate()
+	clk.t = clk.t.Add(2 * time.Minute) // и TTL истёк
+	if got := first(c.GetRowsBySlug(ctx, "a")); got != "A" {
+		t.Fatalf("got %s", got)
+	}
+	if g, err := c.Generation(ctx, 0); err != nil || g.ID != 3 {
+		t.Fatalf("generation %+v, %v", g, err)
+	}
+	if st := c.Stats(); st.Stale != 2 {
+		t.Fatalf("stats %+v", st)
+	}
+
+	// не закэшированное и слишком старое — ошибка БД
+	if _, err := c.GetRowsBySlug(ctx, "b"); !errors.Is(err, errDown) {
+		t.Fatalf("uncached: %v", err)
+	}
+	clk.t = clk.t.Add(10 * time.Minute)
+	if _, err := c.GetRowsBySlug(ctx, "a"); !errors.Is(err, errDown) {
+		t.Fatalf("past max_stale: %v", err)
+	}
+
+	// «списка нет» — ответ БД, устаревшее не подставляется
+	src.down = false
+	delete(src.rows, "a")
+	c.Invalidate()
+	if _, err := c.GetRowsBySlug(ctx, "a"); !errors.Is(err, ldom.ErrNotFound) {
+		t.Fatalf("deleted list: %v", err)
+	}
+}
+
+func TestListsQuery_LRU(t *testing.T) {
+	src := &fakeSource{rows: map[string][]ldom.Row{"a": {{Spot: "A"}}, "b": {{Spot: "B"}}, "c": {{Spot: "C"}}}}
+	c, _ := newTestCache(src, 2)
+	ctx := context.Background()
+	_, _ = c.GetRowsBySlug(ctx, "a")
+	_, _ = c.GetRowsBySlug(ctx, "b")
+	_, _ = c.GetRowsBySlug(ctx, "a") // a свежее b
+	_, _ = c.GetRowsBySlug(ctx, "c") // вытесняет b
+	src.calls = 0
+	_, _ = c.GetRowsBySlug(ctx, "a")
+	_, _ = c.GetRowsBySlug(ctx, "b")
+	if st := c.Stats(); src.calls != 1 || st.Evictions != 2 || st.Entries != 2 {
+		t.Fatalf("calls %d, stats %+v", src.calls, st)
+	}
+}
@@ -2,7 +2,10 @@
 
 	import (
 		"context"
+		"log/slog"
+		"strconv"
 		"strings"
+		"sync"
 
 		"github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/common"
 		dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
@@ -12,7 +15,11 @@
 
 	type Client struct {
 		spot *common.Client
-		fut  *common.Client
+		fut  *common.Client // USD-M
+		coin *common.Client // COIN-M
+
+		mu    sync.Mutex
+		coinm []dm.Item // последний удачный снимок COIN-M: отдаётся, пока dapi недоступен
 	}
 
 	func New() *Client {
@@ -20,20 +27,22 @@
 		return &Client{
 			spot: common.NewWith("https://api.binance.com", opt),
 			fut:  common.NewWith("https://fapi.binance.com", opt), // USD-M
+			coin: common.NewWith("https://dapi.binance.com", opt), // COIN-M
 		}
 	}
 
-	// тесты/DI
+	// тесты/DI; COIN-M ходит на futuresBase (пути разные: /fapi vs /dapi)
 	func NewWithBaseURL(spotBase, futuresBase string) *Client {
 		opt := common.DefaultOptionsFromEnv()
 		return &Client{
 			spot: common.NewWith(spotBase, opt),
 			fut:  common.NewWith(futuresBase, opt),
+			coin: common.NewWith(futuresBase, opt),
 		}
 	}
 
-	func (Client) ExchangeID() int16 { return ExchangeID }
-	func (Client) Name() string      { return "binance" }
+	func (*Client) ExchangeID() int16 { return ExchangeID }
+	func (*Client) Name() string      { return "binance" }
 
 	type exInfo struct {
 		Symbols []struct {
@@ -41,9 +50,41 @@
 			Status string `json:"status"` // TRADING
 			Base   string `json:"baseAsset"`
 			Quote  string `json:"quoteAsset"`
+			Filters []filter `json:"filters"`
 		} `json:"symbols"`
 	}
 
+	// filter — элемент symbols[].filters; поля зависят от filterType.
+	type filter struct {
+		Type        string `json:"filterType"`
+		TickSize    string `json:"tickSize"`    // PRICE_FILTER
+		StepSize    string `json:"stepSize"`    // LOT_SIZE
+		MinQty      string `json:"minQty"`      // LOT_SIZE
+		MinNotional string `json:"minNotional"` // spot: NOTIONAL / MIN_NOTIONAL
+		Notional    string `json:"notional"`    // fapi: MIN_NOTIONAL
+	}
+
+	// specs собирает tick/lot/min notional из фильтров; плечо публично не отдаётся.
+	func specs(fs []filter) dm.Specs {
+		var sp dm.Specs
+		for _, f := range fs {
+			switch f.Type {
+			case "PRICE_FILTER":
+				sp.TickSize = dm.ParseDecimal(f.TickSize)
+			case "LOT_SIZE":
+				sp.LotSize = dm.ParseDecimal(f.StepSize)
+				sp.MinQty = dm.ParseDecimal(f.MinQty)
+			case "NOTIONAL", "MIN_NOTIONAL":
+				v := f.MinNotional
+				if v == "" {
+					v = f.Notional
+				}
+				sp.MinNotional = dm.ParseDecimal(v)
+			}
+		}
+		return sp
+	}
+
 	func (cl *Client) FetchSpot(ctx context.Context) ([]dm.Item, error) {
 		var v exInfo
 		if err := cl.spot.GetJSON(ctx, "/api/v3/exchangeInfo", nil, &v); err != nil {
@@ -65,23 +106,56 @@
 				Base:       base,
 				Quote:      quote,
 				Active:     active,
+				Specs:      specs(s.Filters),
 			})
 		}
 		return out, nil
 	}
 
-	// USD-M futures (perps): https://fapi.binance.com/fapi/v1/exchangeInfo
+	// finfo — exchangeInfo фьючерсов; у USD-M статус в status, у COIN-M — в contractStatus.
+	type finfo struct {
+		Symbols []struct {
+			Symbol         string   `json:"symbol"`
+			Status         string   `json:"status"`         // fapi: TRADING
+			ContractStatus string   `json:"contractStatus"` // dapi: TRADING
+			ContractType   string   `json:"contractType"`   // PERPETUAL, CURRENT_QUARTER, NEXT_QUARTER, ...
+			DeliveryDate   int64    `json:"deliveryDate"`   // мс; у перпов — 2100 год
+			Base           string   `json:"baseAsset"`
+			Quote          string   `json:"quoteAsset"`
+			MarginAsset    string   `json:"marginAsset"`
+			ContractSize   int64    `json:"contractSize"` // dapi: номинал в USD (100 для BTC, 10 для остальных)
+			Filters        []filter `json:"filters"`
+		} `json:"symbols"`
+	}
+
+	// FetchFutures: USD-M (fapi, линейные перпы и квартальные) + COIN-M (dapi, инверсные).
+	// Какие виды контрактов попадут в списки — решают правила списков (list_defs.futures_kinds).
 	func (cl *Client) FetchFutures(ctx context.Context) ([]dm.Item, error) {
-		type finfo struct {
-			Symbols []struct {
-				Symbol string `json:"symbol"`
-				Status string `json:"status"` // TRADING
-				Base   string `json:"baseAsset"`
-				Quote  string `json:"quoteAsset"`
-			} `json:"symbols"`
+		usdm, err := cl.fetchFutures(ctx, cl.fut, "/fapi/v1/exchangeInfo", false)
+		if err != nil {
+			return nil, err
 		}
+		return append(usdm, cl.coinMargined(ctx)...), nil
+	}
+
+	// coinMargined — COIN-M без права уронить снимок: в списки по умолчанию идут только линейные перпы.
+	// При сбое dapi — прошлый удачный снимок (иначе синк архивировал бы инверсные контракты);
+	// после рестарта, пока dapi не ответил, COIN-M в снимке нет.
+	func (cl *Client) coinMargined(ctx context.Context) []dm.Item {
+		items, err := cl.fetchFutures(ctx, cl.coin, "/dapi/v1/exchangeInfo", true)
+		cl.mu.Lock()
+		defer cl.mu.Unlock()
+		if err != nil {
+			slog.Warn("binance: COIN-M fetch failed, using last snapshot", "err", err, "markets", len(cl.coinm))
+			return cl.coinm
+		}
+		cl.coinm = items
+		return items
+	}
+
+	func (cl *Client) fetchFutures(ctx context.Context, c *common.Client, path string, inverse bool) ([]dm.Item, error) {
 		var v finfo
-		if err := cl.fut.GetJSON(ctx, "/fapi/v1/exchangeInfo", nil, &v); err != nil {
+		if err := c.GetJSON(ctx, path, nil, &v); err != nil {
 			return nil, err
 		}
 		out := make([]dm.Item, 0, len(v.Symbols))
@@ -92,15 +166,55 @@
 					base, quote = b, q
 				}
 			}
-			active := strings.EqualFold(s.Status, "TRADING")
-			out = append(out, dm.Item{
+			status := s.Status
+			if status == "" {
+				status = s.ContractStatus
+			}
+			active := strings.EqualFold(status, "TRADING")
+
+			it := dm.Item{
 				ExchangeID: cl.ExchangeID(),
 				Type:       dm.TypeFutures,
 				Symbol:     s.Symbol,
 				Base:       base,
 				Quote:      quote,
 				Active:     active,
-			})
+				Specs:      specs(s.Filters),
+				Settle:     s.MarginAsset,
+			}
+			if s.ContractSize > 0 {
+				it.ContractSize = dm.ParseDecimal(strconv.FormatInt(s.ContractSize, 10))
+			}
+			switch {
+			case strings.Contains(s.ContractType, "PERPETUAL") || s.ContractType == "":
+				it.Contract = dm.ContractLinearPerp
+				if inverse {
+					it.Contract = dm.ContractInversePerp
+				}
+			default:
+				it.Contract = dm.ContractDelivery
+				it.Expiry = dm.ExpiryFromMillis(s.DeliveryDate)
+			}
+			out = append(out, it)
+		}
+		return out, nil
+	}
+
+	type ticker24 struct {
+		Symbol      string `json:"symbol"`
+		LastPrice   string `json:"lastPrice"`
+		QuoteVolume string `json:"quoteVolume"`
+	}
+
+	// FetchSpotTickers: /api/v3/ticker/24hr без symbol — все пары одним запросом (вес 80).
+	func (cl *Client) FetchSpotTickers(ctx context.Context) ([]dm.Ticker, error) {
+		var v []ticker24
+		if err := cl.spot.GetJSON(ctx, "/api/v3/ticker/24hr", nil, &v); err != nil {
+			return nil, err
+		}
+		out := make([]dm.Ticker, 0, len(v))
+		for _, t := range v {
+			out = append(out, dm.Ticker{Symbol: t.Symbol, Last: common.Float(t.LastPrice), QuoteVolume: common.Float(t.QuoteVolume)})
 		}
 		return out, nil
 	}
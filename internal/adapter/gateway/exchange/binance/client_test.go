@@ -0,0 +1,97 @@
+package binance_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	cl "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/binance"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// COIN-M (dapi) не должен ронять фьючерсы: USD-M отдаются всегда, инверсные — из прошлого удачного снимка.
+func TestFetchFutures_CoinMBestEffort(t *testing.T) {
+	t.Setenv("HTTP_RETRIES", "0")
+	var dapiDown atomic.Bool
+	mux := http.NewServeMux()
+	mux.HandleFunc("/fapi/v1/exchangeInfo", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"symbols":[{"symbol":"BTCUSDT","status":"TRADING","baseAsset":"BTC","quoteAsset":"USDT",
+			"contractType":"PERPETUAL","marginAsset":"USDT"}]}`))
+	})
+	mux.HandleFunc("/dapi/v1/exchangeInfo", func(w http.ResponseWriter, r *http.Request) {
+		if dapiDown.Load() {
+			http.Error(w, "maintenance", http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte(`{"symbols":[{"symbol":"BTCUSD_PERP","contractStatus":"TRADING","baseAsset":"BTC","quoteAsset":"USD",
+			"contractType":"PERPETUAL","marginAsset":"BTC","contractSize":100}]}`))
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	cli := cl.NewWithBaseURL(ts.URL, ts.URL)
+	ctx := context.Background()
+
+	kinds := func(items []dm.Item) map[string]dm.ContractKind {
+		out := map[string]dm.ContractKind{}
+		for _, it := range items {
+			out[it.Symbol] = it.Kind()
+		}
+		return out
+	}
+
+	// dapi недоступен с самого старта — только USD-M, без ошибки
+	dapiDown.Store(true)
+	fut, err := cli.FetchFutures(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := kinds(fut); len(got) != 1 || got["BTCUSDT"] != dm.ContractLinearPerp {
+		t.Fatalf("dapi down at start: %v", got)
+	}
+
+	dapiDown.Store(false)
+	fut, err = cli.FetchFutures(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := kinds(fut); len(got) != 2 || got["BTCUSD_PERP"] != dm.ContractInversePerp {
+		t.Fatalf("both up: %v", got)
+	}
+
+	// сбой dapi после удачного снимка — инверсные из прошлого снимка, синк их не архивирует
+	dapiDown.Store(true)
+	fut, err = cli.FetchFutures(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := kinds(fut); len(got) != 2 || got["BTCUSD_PERP"] != dm.ContractInversePerp {
+		t.Fatalf("dapi down later: %v", got)
+	}
+}
+
+func TestFetchSpotTickers(t *testing.T) {
+	t.Setenv("HTTP_RETRIES", "0")
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v3/ticker/24hr" || r.URL.RawQuery != "" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"symbol":"BTCUSDT","lastPrice":"65000.10","quoteVolume":"1234567.8","volume":"19"},
+			{"symbol":"ETHBTC","lastPrice":"0.05","quoteVolume":"12.5"}]`))
+	}))
+	defer ts.Close()
+
+	out, err := cl.NewWithBaseURL(ts.URL, ts.URL).FetchSpotTickers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []dm.Ticker{
+		{Symbol: "BTCUSDT", Last: 65000.10, QuoteVolume: 1234567.8},
+		{Symbol: "ETHBTC", Last: 0.05, QuoteVolume: 12.5},
+	}
+	if len(out) != len(want) || out[0] != want[0] || out[1] != want[1] {
+		t.Fatalf("tickers = %+v", out)
+	}
+}
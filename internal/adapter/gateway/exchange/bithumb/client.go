@@ -3,7 +3,9 @@ package bithumb
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/common"
 	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
@@ -11,13 +13,16 @@ import (
 
 const ExchangeID int16 = 6
 
-type Client struct{ c *common.Client }
+type Client struct {
+	c    *common.Client
+	poll common.Validators // ETag прошлого PollSpot
+}
 
 func New() *Client  { return &Client{c: common.NewWith("https://api.bithumb.com", common.DefaultOptionsFromEnv())} }
 func NewWithBaseURL(base string) *Client { return &Client{c: common.NewWith(base, common.DefaultOptionsFromEnv())} }
 
-func (Client) ExchangeID() int16 { return ExchangeID }
-func (Client) Name() string      { return "bithumb" }
+func (*Client) ExchangeID() int16 { return ExchangeID }
+func (*Client) Name() string      { return "bithumb" }
 
 type allResp struct {
 	Status string                 `json:"status"` // "0000"
@@ -63,6 +68,79 @@ func (cl *Client) FetchSpot(ctx context.Context) ([]dm.Item, error) {
 	return items, nil
 }
 
+type market struct {
+	Market string `json:"market"` // "KRW-BTC" — формат Upbit
+}
+
+// PollSpot — лёгкий /v1/market/all (условным запросом) вместо полных тикеров FetchSpot.
+// Символы приводятся к виду FetchSpot ("BTC-KRW"); берутся только маркеты, которые синкает FetchSpot (KRW, USDT).
+func (cl *Client) PollSpot(ctx context.Context) ([]dm.Item, bool, error) {
+	var v []market
+	err := cl.c.GetJSONIfModified(ctx, "/v1/market/all", nil, &cl.poll, &v)
+	if errors.Is(err, common.ErrNotModified) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	out := make([]dm.Item, 0, len(v))
+	for _, m := range v {
+		quote, base, ok := strings.Cut(m.Market, "-")
+		if !ok || (quote != "KRW" && quote != "USDT") {
+			continue
+		}
+		out = append(out, dm.Item{
+			ExchangeID: cl.ExchangeID(),
+			Type:       dm.TypeSpot,
+			Symbol:     base + "-" + quote,
+			Base:       base,
+			Quote:      quote,
+			Active:     true,
+		})
+	}
+	return out, true, nil
+}
+
 func (cl *Client) FetchFutures(ctx context.Context) ([]dm.Item, error) {
 	return nil, nil
 }
+
+type coinTicker struct {
+	ClosingPrice     string `json:"closing_price"`
+	AccTradeValue24H string `json:"acc_trade_value_24H"` // оборот в quote за 24ч
+}
+
+// FetchSpotTickers — те же /public/ticker/ALL и ALL_USDT, что и FetchSpot, но с ценой и оборотом.
+// Как и FetchSpot, недоступный маркет пропускается; ошибка — только если не ответил ни один.
+func (cl *Client) FetchSpotTickers(ctx context.Context) ([]dm.Ticker, error) {
+	var out []dm.Ticker
+	var lastErr error
+	ok := false
+	for _, m := range []struct{ path, quote string }{{"/public/ticker/ALL", "KRW"}, {"/public/ticker/ALL_USDT", "USDT"}} {
+		var v allResp
+		if err := cl.c.GetJSON(ctx, m.path, nil, &v); err != nil {
+			lastErr = err
+			continue
+		}
+		if v.Status != "0000" {
+			lastErr = fmt.Errorf("bithumb status=%s", v.Status)
+			continue
+		}
+		ok = true
+		for k, raw := range v.Data {
+			var t coinTicker
+			if k == "date" || json.Unmarshal(raw, &t) != nil {
+				continue
+			}
+			out = append(out, dm.Ticker{
+				Symbol:      k + "-" + m.quote,
+				Last:        common.Float(t.ClosingPrice),
+				QuoteVolume: common.Float(t.AccTradeValue24H),
+			})
+		}
+	}
+	if !ok {
+		return nil, lastErr
+	}
+	return out, nil
+}
@@ -42,3 +42,49 @@ func TestFetch_All_KRW_USDT(t *testing.T) {
 		t.Fatalf("missing pairs: %+v", found)
 	}
 }
+
+func TestPollSpot_ConditionalAndSymbols(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"market":"KRW-AAA"},{"market":"BTC-CCC"},{"market":"USDT-BBB"}]`))
+	}))
+	defer ts.Close()
+	cli := newClient(ts)
+
+	items, changed, err := cli.PollSpot(context.Background())
+	if err != nil || !changed {
+		t.Fatalf("changed=%v err=%v", changed, err)
+	}
+	if len(items) != 2 || items[0].Symbol != "AAA-KRW" || items[0].Quote != "KRW" || items[1].Symbol != "BBB-USDT" {
+		t.Fatalf("items = %+v", items)
+	}
+	if items, changed, err = cli.PollSpot(context.Background()); err != nil || changed || items != nil {
+		t.Fatalf("second poll: changed=%v items=%v err=%v", changed, items, err)
+	}
+}
+
+// Тикеры: цена и оборот в котировке; недоступный USDT-маркет не валит KRW.
+func TestFetchSpotTickers(t *testing.T) {
+	t.Setenv("HTTP_RETRIES", "0")
+	mux := http.NewServeMux()
+	mux.HandleFunc("/public/ticker/ALL", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"0000","data":{"AAA":{"closing_price":"1500","acc_trade_value_24H":"3000000000.5"},"date":"123"}}`))
+	})
+	mux.HandleFunc("/public/ticker/ALL_USDT", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusInternalServerError)
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	out, err := newClient(ts).FetchSpotTickers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 1 || out[0].Symbol != "AAA-KRW" || out[0].Last != 1500 || out[0].QuoteVolume != 3000000000.5 {
+		t.Fatalf("tickers = %+v", out)
+	}
+}
@@ -3,7 +3,10 @@ package bybit
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/common"
 	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
@@ -11,7 +14,12 @@ import (
 
 const ExchangeID int16 = 2
 
-type Client struct{ c *common.Client }
+type Client struct {
+	c *common.Client
+
+	mu  sync.Mutex
+	inv []dm.Item // последний удачный снимок inverse: отдаётся, пока категория недоступна
+}
 
 func New() *Client {
 	return &Client{c: common.NewWith("https://api.bybit.com", common.DefaultOptionsFromEnv())}
@@ -20,23 +28,60 @@ func NewWithBaseURL(base string) *Client {
 	return &Client{c: common.NewWith(base, common.DefaultOptionsFromEnv())}
 }
 
-func (Client) ExchangeID() int16 { return ExchangeID }
-func (Client) Name() string      { return "bybit" }
+func (*Client) ExchangeID() int16 { return ExchangeID }
+func (*Client) Name() string      { return "bybit" }
 
 type instResp struct {
 	RetCode int `json:"retCode"`
 	Result  struct {
-		Category string `json:"category"`
-		List     []struct {
-			Symbol       string `json:"symbol"`
-			BaseCoin     string `json:"baseCoin"`
-			QuoteCoin    string `json:"quoteCoin"`
-			Status       string `json:"status"` // Trading
-			ContractSize string `json:"contractSize"`
-		} `json:"list"`
+		Category string       `json:"category"`
+		List     []instrument `json:"list"`
 	} `json:"result"`
 }
 
+type instrument struct {
+	Symbol       string `json:"symbol"`
+	BaseCoin     string `json:"baseCoin"`
+	QuoteCoin    string `json:"quoteCoin"`
+	Status       string `json:"status"` // Trading
+	ContractSize string `json:"contractSize"`
+	ContractType string `json:"contractType"` // LinearPerpetual, LinearFutures, InversePerpetual, InverseFutures
+	SettleCoin   string `json:"settleCoin"`
+	DeliveryTime string `json:"deliveryTime"` // мс; "0" у перпов
+	PriceFilter  struct {
+		TickSize string `json:"tickSize"`
+	} `json:"priceFilter"`
+	LotSizeFilter struct {
+		BasePrecision    string `json:"basePrecision"` // spot: шаг количества
+		QtyStep          string `json:"qtyStep"`       // linear
+		MinOrderQty      string `json:"minOrderQty"`
+		MinOrderAmt      string `json:"minOrderAmt"`      // spot: мин. сумма в quote
+		MinNotionalValue string `json:"minNotionalValue"` // linear
+	} `json:"lotSizeFilter"`
+	LeverageFilter struct {
+		MaxLeverage string `json:"maxLeverage"`
+	} `json:"leverageFilter"`
+}
+
+// specs: у спота и деривативов поля lotSizeFilter называются по-разному.
+func (it instrument) specs() dm.Specs {
+	lot, minN := it.LotSizeFilter.QtyStep, it.LotSizeFilter.MinNotionalValue
+	if lot == "" {
+		lot = it.LotSizeFilter.BasePrecision
+	}
+	if minN == "" {
+		minN = it.LotSizeFilter.MinOrderAmt
+	}
+	return dm.Specs{
+		ContractSize: dm.ParseDecimal(it.ContractSize),
+		TickSize:     dm.ParseDecimal(it.PriceFilter.TickSize),
+		LotSize:      dm.ParseDecimal(lot),
+		MinQty:       dm.ParseDecimal(it.LotSizeFilter.MinOrderQty),
+		MinNotional:  dm.ParseDecimal(minN),
+		MaxLeverage:  dm.ParseDecimal(it.LeverageFilter.MaxLeverage),
+	}
+}
+
 func (cl *Client) FetchSpot(ctx context.Context) ([]dm.Item, error) {
 	var out instResp
 	if err := cl.c.GetJSON(ctx, "/v5/market/instruments-info", map[string]string{"category": "spot"}, &out); err != nil {
@@ -57,44 +102,103 @@ func (cl *Client) FetchSpot(ctx context.Context) ([]dm.Item, error) {
 			Base:       it.BaseCoin,
 			Quote:      it.QuoteCoin,
 			Active:     true,
+			Specs:      it.specs(),
 		})
 	}
 	return items, nil
 }
 
+// FetchFutures: linear (USDT/USDC перпы и срочные) + inverse (coin-margined).
 func (cl *Client) FetchFutures(ctx context.Context) ([]dm.Item, error) {
+	linear, err := cl.fetchFutures(ctx, "linear")
+	if err != nil {
+		return nil, err
+	}
+	return append(linear, cl.inverse(ctx)...), nil
+}
+
+// inverse — без права уронить снимок, как COIN-M у Binance: при сбое — прошлый удачный снимок.
+func (cl *Client) inverse(ctx context.Context) []dm.Item {
+	items, err := cl.fetchFutures(ctx, "inverse")
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	if err != nil {
+		slog.Warn("bybit: inverse fetch failed, using last snapshot", "err", err, "markets", len(cl.inv))
+		return cl.inv
+	}
+	cl.inv = items
+	return items
+}
+
+func (cl *Client) fetchFutures(ctx context.Context, category string) ([]dm.Item, error) {
 	var out instResp
-	if err := cl.c.GetJSON(ctx, "/v5/market/instruments-info", map[string]string{"category": "linear"}, &out); err != nil {
+	if err := cl.c.GetJSON(ctx, "/v5/market/instruments-info", map[string]string{"category": category}, &out); err != nil {
 		return nil, err
 	}
 	if out.RetCode != 0 {
-		return nil, fmt.Errorf("bybit retCode=%d", out.RetCode)
+		return nil, fmt.Errorf("bybit %s retCode=%d", category, out.RetCode)
 	}
-	items := make([]dm.Item, 0, len(out.Result.List))
+	var items []dm.Item
 	for _, it := range out.Result.List {
 		if it.Status != "Trading" {
 			continue
 		}
-		// parse contractSize if present and integral
-		var cs *int64
-		if it.ContractSize != "" {
-			if f, err := strconv.ParseFloat(it.ContractSize, 64); err == nil {
-				if f == float64(int64(f)) {
-					v := int64(f)
-					cs = &v
-				}
-			}
+		item := dm.Item{
+			ExchangeID: cl.ExchangeID(),
+			Type:       dm.TypeFutures,
+			Symbol:     it.Symbol,
+			Base:       it.BaseCoin,
+			Quote:      it.QuoteCoin,
+			Active:     true,
+			Specs:      it.specs(),
+			Contract:   contractKind(category, it.ContractType),
+			Settle:     it.SettleCoin,
+		}
+		if item.Contract == dm.ContractDelivery {
+			ms, _ := strconv.ParseInt(it.DeliveryTime, 10, 64)
+			item.Expiry = dm.ExpiryFromMillis(ms)
 		}
-		items = append(items, dm.Item{
-			ExchangeID:  cl.ExchangeID(),
-			Type:        dm.TypeFutures,
-			Symbol:      it.Symbol,
-			Base:        it.BaseCoin,
-			Quote:       it.QuoteCoin,
-			ContractSize: cs,
-			Active:      true,
-		})
+		items = append(items, item)
 	}
 	return items, nil
 }
 
+// contractKind: LinearPerpetual/InversePerpetual — перпы, LinearFutures/InverseFutures — срочные.
+func contractKind(category, contractType string) dm.ContractKind {
+	switch {
+	case strings.HasSuffix(contractType, "Futures"):
+		return dm.ContractDelivery
+	case category == "inverse":
+		return dm.ContractInversePerp
+	default:
+		return dm.ContractLinearPerp
+	}
+}
+
+type tickersResp struct {
+	RetCode int    `json:"retCode"`
+	RetMsg  string `json:"retMsg"`
+	Result  struct {
+		List []struct {
+			Symbol      string `json:"symbol"`
+			LastPrice   string `json:"lastPrice"`
+			Turnover24h string `json:"turnover24h"` // оборот в quote
+		} `json:"list"`
+	} `json:"result"`
+}
+
+// FetchSpotTickers: /v5/market/tickers?category=spot — все пары одним запросом.
+func (cl *Client) FetchSpotTickers(ctx context.Context) ([]dm.Ticker, error) {
+	var v tickersResp
+	if err := cl.c.GetJSON(ctx, "/v5/market/tickers", map[string]string{"category": "spot"}, &v); err != nil {
+		return nil, err
+	}
+	if v.RetCode != 0 {
+		return nil, fmt.Errorf("bybit tickers retCode=%d: %s", v.RetCode, v.RetMsg)
+	}
+	out := make([]dm.Ticker, 0, len(v.Result.List))
+	for _, t := range v.Result.List {
+		out = append(out, dm.Ticker{Symbol: t.Symbol, Last: common.Float(t.LastPrice), QuoteVolume: common.Float(t.Turnover24h)})
+	}
+	return out, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	cl "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/bybit"
@@ -77,7 +78,85 @@ func TestFetch_Spot_And_Futures(t *testing.T) {
 	if len(fut) != 1 || fut[0].Type != dm.TypeFutures {
 		t.Fatalf("futures parsed wrong: %+v", fut)
 	}
-	if fut[0].ContractSize == nil || *fut[0].ContractSize != 1 {
+	if fut[0].ContractSize != "1" {
 		t.Fatalf("contract size wrong: %+v", fut[0])
 	}
 }
+
+func TestFetchSpotTickers_Bybit(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v5/market/tickers" || r.URL.Query().Get("category") != "spot" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"retCode":0,"result":{"category":"spot","list":[{"symbol":"AAAUSDT","lastPrice":"2","volume24h":"10","turnover24h":"20.5"}]}}`))
+	}))
+	defer ts.Close()
+
+	got, err := newClient(ts).FetchSpotTickers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != (dm.Ticker{Symbol: "AAAUSDT", Last: 2, QuoteVolume: 20.5}) {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+// inverse: перпы и срочные coin-margined; сбой категории не роняет linear — инверсные из прошлого снимка.
+func TestFetchFutures_Inverse(t *testing.T) {
+	t.Setenv("HTTP_RETRIES", "0")
+	var inverseDown atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("category") {
+		case "linear":
+			w.Write([]byte(`{"retCode":0,"result":{"category":"linear","list":[
+				{"symbol":"BTCUSDT","baseCoin":"BTC","quoteCoin":"USDT","status":"Trading","contractType":"LinearPerpetual","settleCoin":"USDT","deliveryTime":"0"}]}}`))
+		case "inverse":
+			if inverseDown.Load() {
+				http.Error(w, "maintenance", http.StatusForbidden)
+				return
+			}
+			w.Write([]byte(`{"retCode":0,"result":{"category":"inverse","list":[
+				{"symbol":"BTCUSD","baseCoin":"BTC","quoteCoin":"USD","status":"Trading","contractType":"InversePerpetual","settleCoin":"BTC","deliveryTime":"0"},
+				{"symbol":"BTCUSDZ25","baseCoin":"BTC","quoteCoin":"USD","status":"Trading","contractType":"InverseFutures","settleCoin":"BTC","deliveryTime":"1766736000000"}]}}`))
+		}
+	}))
+	defer ts.Close()
+	cli := newClient(ts)
+	ctx := context.Background()
+
+	fut, err := cli.FetchFutures(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fut) != 3 {
+		t.Fatalf("want 3 contracts, got %+v", fut)
+	}
+	want := []struct {
+		sym    string
+		kind   dm.ContractKind
+		settle string
+	}{
+		{"BTCUSDT", dm.ContractLinearPerp, "USDT"},
+		{"BTCUSD", dm.ContractInversePerp, "BTC"},
+		{"BTCUSDZ25", dm.ContractDelivery, "BTC"},
+	}
+	for i, w := range want {
+		if fut[i].Symbol != w.sym || fut[i].Contract != w.kind || fut[i].Settle != w.settle {
+			t.Fatalf("%d: %+v", i, fut[i])
+		}
+	}
+	if fut[1].Expiry != nil || fut[2].Expiry == nil || fut[2].Expiry.Format("2006-01-02") != "2025-12-26" {
+		t.Fatalf("bad expiry: %v / %v", fut[1].Expiry, fut[2].Expiry)
+	}
+
+	// inverse недоступен — linear на месте, инверсные из прошлого снимка
+	inverseDown.Store(true)
+	fut, err = cli.FetchFutures(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fut) != 3 || fut[0].Symbol != "BTCUSDT" || fut[1].Symbol != "BTCUSD" {
+		t.Fatalf("inverse down: %+v", fut)
+	}
+}
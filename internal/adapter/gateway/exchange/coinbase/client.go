@@ -23,6 +23,10 @@ type product struct {
 	BaseCurrency  string `json:"base_currency"`
 	QuoteCurrency string `json:"quote_currency"`
 	Status        string `json:"status"` // online
+	QuoteIncrement string `json:"quote_increment"` // шаг цены
+	BaseIncrement  string `json:"base_increment"`  // шаг количества
+	BaseMinSize    string `json:"base_min_size"`
+	MinMarketFunds string `json:"min_market_funds"` // мин. сумма в quote
 }
 
 func (cl *Client) FetchSpot(ctx context.Context) ([]dm.Item, error) {
@@ -42,6 +46,12 @@ func (cl *Client) FetchSpot(ctx context.Context) ([]dm.Item, error) {
 			Base:       p.BaseCurrency,
 			Quote:      p.QuoteCurrency,
 			Active:     true,
+			Specs: dm.Specs{
+				TickSize:    dm.ParseDecimal(p.QuoteIncrement),
+				LotSize:     dm.ParseDecimal(p.BaseIncrement),
+				MinQty:      dm.ParseDecimal(p.BaseMinSize),
+				MinNotional: dm.ParseDecimal(p.MinMarketFunds),
+			},
 		})
 	}
 	return out, nil
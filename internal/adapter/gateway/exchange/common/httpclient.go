@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -63,7 +65,49 @@ func (c *Client) GetJSON(ctx context.Context, path string, q map[string]string,
 	return c.doJSON(req, v)
 }
 
+// ErrNotModified — 304 на условный запрос: данные с прошлого ответа не менялись.
+var ErrNotModified = errors.New("not modified")
+
+// Validators — ETag/Last-Modified последнего ответа для условных запросов; безопасен для горутин.
+type Validators struct {
+	mu           sync.Mutex
+	etag         string
+	lastModified string
+}
+
+// GetJSONIfModified — GET с If-None-Match/If-Modified-Since из val. 304 → ErrNotModified (v не трогается),
+// на 200 валидаторы обновляются. Биржи без ETag/Last-Modified просто всегда отвечают 200.
+func (c *Client) GetJSONIfModified(ctx context.Context, path string, q map[string]string, val *Validators, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url(path, q), nil)
+	if err != nil {
+		return err
+	}
+	val.mu.Lock()
+	if val.etag != "" {
+		req.Header.Set("If-None-Match", val.etag)
+	}
+	if val.lastModified != "" {
+		req.Header.Set("If-Modified-Since", val.lastModified)
+	}
+	val.mu.Unlock()
+
+	h, err := c.do(req, v)
+	if err != nil {
+		return err
+	}
+	val.mu.Lock()
+	val.etag, val.lastModified = h.Get("ETag"), h.Get("Last-Modified")
+	val.mu.Unlock()
+	return nil
+}
+
 func (c *Client) doJSON(req *http.Request, v any) error {
+	_, err := c.do(req, v)
+	return err
+}
+
+// do — запрос с ретраями; на 2xx декодирует тело в v и отдаёт заголовки ответа.
+func (c *Client) do(req *http.Request, v any) (http.Header, error) {
 	req.Header.Set("User-Agent", c.opt.UserAgent)
 	req.Header.Set("Accept", "application/json")
 
@@ -81,27 +125,30 @@ func (c *Client) doJSON(req *http.Request, v any) error {
 				lastErr = err
 				continue
 			}
-			return err
+			return nil, err
 		}
 		body, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
 		cancel()
 
+		if resp.StatusCode == http.StatusNotModified {
+			return resp.Header, ErrNotModified
+		}
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 			ct := resp.Header.Get("Content-Type")
 			if !isJSON(ct) && len(body) > 0 && body[0] != '{' && body[0] != '[' {
 				// защитимся от HTML/текст
-				return fmt.Errorf("unexpected content-type: %s", ct)
+				return nil, fmt.Errorf("unexpected content-type: %s", ct)
 			}
 			if v == nil || len(body) == 0 {
-				return nil
+				return resp.Header, nil
 			}
 			dec := json.NewDecoder(bytes.NewReader(body))
 			dec.UseNumber()
 			if err := dec.Decode(v); err != nil {
-				return fmt.Errorf("json decode: %w", err)
+				return nil, fmt.Errorf("json decode: %w", err)
 			}
-			return nil
+			return resp.Header, nil
 		}
 
 		// retryable?
@@ -116,7 +163,7 @@ func (c *Client) doJSON(req *http.Request, v any) error {
 		if len(preview) > 256 {
 			preview = preview[:256] + "…"
 		}
-		return fmt.Errorf("http %d: %s", resp.StatusCode, preview)
+		return nil, fmt.Errorf("http %d: %s", resp.StatusCode, preview)
 	}
-	return lastErr
+	return nil, lastErr
 }
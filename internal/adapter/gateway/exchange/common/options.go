@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
 )
 
 type Options struct {
@@ -15,7 +17,18 @@ type Options struct {
 	UserAgent  string
 }
 
+// DefaultOptionsFromEnv — из активной конфигурации (exchanges.http), если она загружена, иначе из env.
 func DefaultOptionsFromEnv() Options {
+	if c, ok := config.Active(); ok {
+		h := c.Exchanges.HTTP
+		return Options{
+			Timeout:    h.Timeout.D(),
+			Retries:    h.Retries,
+			BackoffMin: h.BackoffMin.D(),
+			BackoffMax: h.BackoffMax.D(),
+			UserAgent:  h.UserAgent,
+		}
+	}
 	parseDur := func(k string, d time.Duration) time.Duration {
 		if v := strings.TrimSpace(os.Getenv(k)); v != "" {
 			if x, err := time.ParseDuration(v); err == nil {
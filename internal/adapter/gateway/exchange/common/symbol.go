@@ -1,6 +1,9 @@
 package common
 
-import "strings"
+import (
+	"strconv"
+	"strings"
+)
 
 // TrimPerpSuffix возвращает core-символ без окончаний PERP/SWAP и признак фьючерса.
 func TrimPerpSuffix(sym string) (core string, perp bool) {
@@ -41,3 +44,12 @@ var CommonQuoteSet = []string{
 	"USDT", "USDC", "USD", "FDUSD", "BUSD",
 	"BTC", "ETH", "BNB", "EUR", "TRY", "KRW", "BRL", "TUSD",
 }
+
+// Float — числа из тикеров бирж приходят строками; пусто/мусор → 0.
+func Float(s string) float64 {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
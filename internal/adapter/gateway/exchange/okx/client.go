@@ -2,8 +2,10 @@ package okx
 
 import (
 	"context"
+	"log/slog"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/common"
 	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
@@ -11,22 +13,39 @@ import (
 
 const ExchangeID int16 = 3
 
-type Client struct{ c *common.Client }
+type Client struct {
+	c *common.Client
 
-func New() *Client  { return &Client{c: common.NewWith("https://www.okx.com", common.DefaultOptionsFromEnv())} }
-func NewWithBaseURL(base string) *Client { return &Client{c: common.NewWith(base, common.DefaultOptionsFromEnv())} }
+	mu       sync.Mutex
+	delivery []dm.Item // последний удачный снимок FUTURES: отдаётся, пока instType недоступен
+}
+
+func New() *Client {
+	return &Client{c: common.NewWith("https://www.okx.com", common.DefaultOptionsFromEnv())}
+}
+func NewWithBaseURL(base string) *Client {
+	return &Client{c: common.NewWith(base, common.DefaultOptionsFromEnv())}
+}
 
-func (Client) ExchangeID() int16 { return ExchangeID }
-func (Client) Name() string      { return "okx" }
+func (*Client) ExchangeID() int16 { return ExchangeID }
+func (*Client) Name() string      { return "okx" }
 
 type inst struct {
 	Data []struct {
-		InstID   string `json:"instId"`   // AAA-USDT, AAA-USDT-SWAP
-		InstType string `json:"instType"` // SPOT/SWAP
-		BaseCcy  string `json:"baseCcy"`
-		QuoteCcy string `json:"quoteCcy"`
-		CtVal    string `json:"ctVal"` // can be "1", "0.1", ...
-		State    string `json:"state"` // live/suspend
+		InstID    string `json:"instId"`   // AAA-USDT, AAA-USDT-SWAP
+		InstType  string `json:"instType"` // SPOT/SWAP
+		BaseCcy   string `json:"baseCcy"`
+		QuoteCcy  string `json:"quoteCcy"`
+		CtVal     string `json:"ctVal"` // can be "1", "0.1", ...
+		TickSz    string `json:"tickSz"`
+		LotSz     string `json:"lotSz"` // SWAP: в контрактах
+		MinSz     string `json:"minSz"`
+		Lever     string `json:"lever"`     // max leverage; у спота пусто
+		Uly       string `json:"uly"`       // BTC-USDT (деривативы)
+		CtType    string `json:"ctType"`    // linear/inverse
+		SettleCcy string `json:"settleCcy"` // USDT / BTC
+		ExpTime   string `json:"expTime"`   // мс; только FUTURES
+		State     string `json:"state"`     // live/suspend
 	} `json:"data"`
 }
 
@@ -47,37 +66,103 @@ func (cl *Client) FetchSpot(ctx context.Context) ([]dm.Item, error) {
 			Base:       it.BaseCcy,
 			Quote:      it.QuoteCcy,
 			Active:     true,
+			Specs: dm.Specs{
+				TickSize: dm.ParseDecimal(it.TickSz),
+				LotSize:  dm.ParseDecimal(it.LotSz),
+				MinQty:   dm.ParseDecimal(it.MinSz),
+			},
 		})
 	}
 	return out, nil
 }
 
+// FetchFutures: SWAP (линейные и инверсные перпы) + FUTURES (срочные).
 func (cl *Client) FetchFutures(ctx context.Context) ([]dm.Item, error) {
+	swap, err := cl.fetchFutures(ctx, "SWAP")
+	if err != nil {
+		return nil, err
+	}
+	return append(swap, cl.deliveries(ctx)...), nil
+}
+
+// deliveries — срочные без права уронить снимок, как COIN-M у Binance: при сбое — прошлый удачный снимок.
+func (cl *Client) deliveries(ctx context.Context) []dm.Item {
+	items, err := cl.fetchFutures(ctx, "FUTURES")
+	cl.mu.Lock()
+	defer cl.mu.Unlock()
+	if err != nil {
+		slog.Warn("okx: FUTURES fetch failed, using last snapshot", "err", err, "markets", len(cl.delivery))
+		return cl.delivery
+	}
+	cl.delivery = items
+	return items
+}
+
+func (cl *Client) fetchFutures(ctx context.Context, instType string) ([]dm.Item, error) {
 	var v inst
-	if err := cl.c.GetJSON(ctx, "/api/v5/public/instruments", map[string]string{"instType": "SWAP"}, &v); err != nil {
+	if err := cl.c.GetJSON(ctx, "/api/v5/public/instruments", map[string]string{"instType": instType}, &v); err != nil {
 		return nil, err
 	}
-	out := make([]dm.Item, 0, len(v.Data))
+	var out []dm.Item
 	for _, it := range v.Data {
 		if !strings.EqualFold(it.State, "live") {
 			continue
 		}
-		var cs *int64
-		if it.CtVal != "" {
-			if n, err := strconv.ParseFloat(it.CtVal, 64); err == nil && n == float64(int64(n)) {
-				x := int64(n)
-				cs = &x
+		// у деривативов baseCcy/quoteCcy пустые — берём из uly (BTC-USDT)
+		base, quote := it.BaseCcy, it.QuoteCcy
+		if base == "" || quote == "" {
+			if b, q, ok := strings.Cut(it.Uly, "-"); ok {
+				base, quote = b, q
 			}
 		}
-		out = append(out, dm.Item{
-			ExchangeID:  cl.ExchangeID(),
-			Type:        dm.TypeFutures,
-			Symbol:      it.InstID,
-			Base:        it.BaseCcy,
-			Quote:       it.QuoteCcy,
-			ContractSize: cs,
-			Active:      true,
-		})
+		item := dm.Item{
+			ExchangeID: cl.ExchangeID(),
+			Type:       dm.TypeFutures,
+			Symbol:     it.InstID,
+			Base:       base,
+			Quote:      quote,
+			Active:     true,
+			Specs: dm.Specs{
+				ContractSize: dm.ParseDecimal(it.CtVal),
+				TickSize:     dm.ParseDecimal(it.TickSz),
+				LotSize:      dm.ParseDecimal(it.LotSz),
+				MinQty:       dm.ParseDecimal(it.MinSz),
+				MaxLeverage:  dm.ParseDecimal(it.Lever),
+			},
+			Settle: it.SettleCcy,
+		}
+		switch {
+		case instType == "FUTURES":
+			item.Contract = dm.ContractDelivery
+			ms, _ := strconv.ParseInt(it.ExpTime, 10, 64)
+			item.Expiry = dm.ExpiryFromMillis(ms)
+		case strings.EqualFold(it.CtType, "inverse"):
+			item.Contract = dm.ContractInversePerp
+		default:
+			item.Contract = dm.ContractLinearPerp
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+type tickers struct {
+	Data []struct {
+		InstID    string `json:"instId"`
+		Last      string `json:"last"`
+		VolCcy24h string `json:"volCcy24h"` // у спота — оборот в quote
+	} `json:"data"`
+}
+
+// FetchSpotTickers: /api/v5/market/tickers?instType=SPOT — все пары одним запросом.
+func (cl *Client) FetchSpotTickers(ctx context.Context) ([]dm.Ticker, error) {
+	var v tickers
+	if err := cl.c.GetJSON(ctx, "/api/v5/market/tickers", map[string]string{"instType": "SPOT"}, &v); err != nil {
+		return nil, err
+	}
+	out := make([]dm.Ticker, 0, len(v.Data))
+	for _, t := range v.Data {
+		out = append(out, dm.Ticker{Symbol: t.InstID, Last: common.Float(t.Last), QuoteVolume: common.Float(t.VolCcy24h)})
 	}
 	return out, nil
 }
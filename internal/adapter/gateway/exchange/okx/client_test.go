@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 
 	cl "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/okx"
@@ -35,7 +36,11 @@ func TestFetch_OKX_SPOT_SWAP(t *testing.T) {
 				InstID, InstType, BaseCcy, QuoteCcy, CtVal, State string
 			}{
 				{"AAA-USDT-SWAP", "SWAP", "AAA", "USDT", "1", "live"},
+				{"BBB-USDT-SWAP", "SWAP", "BBB", "USDT", "0.1", "live"},
 			}})
+		case "FUTURES":
+			w.Write([]byte(`{"data":[{"instId":"BTC-USD-250926","instType":"FUTURES","uly":"BTC-USD","ctType":"inverse",
+				"settleCcy":"BTC","ctVal":"100","expTime":"1758873600000","state":"live"}]}`))
 		default:
 			w.WriteHeader(400)
 		}
@@ -47,12 +52,118 @@ func TestFetch_OKX_SPOT_SWAP(t *testing.T) {
 	ctx := context.Background()
 
 	spot, _ := cli.FetchSpot(ctx)
-	fut, _ := cli.FetchFutures(ctx)
+	fut, err := cli.FetchFutures(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
 
 	if len(spot) != 1 || spot[0].Type != dm.TypeSpot || spot[0].Base != "AAA" || spot[0].Quote != "USDT" {
 		t.Fatalf("bad spot: %+v", spot)
 	}
-	if len(fut) != 1 || fut[0].Type != dm.TypeFutures || fut[0].Symbol != "AAA-USDT-SWAP" {
+	if len(fut) != 3 || fut[0].Type != dm.TypeFutures || fut[0].Symbol != "AAA-USDT-SWAP" {
 		t.Fatalf("bad fut: %+v", fut)
 	}
+	// дробный ctVal больше не теряется
+	if fut[0].ContractSize != "1" || fut[1].ContractSize != "0.1" {
+		t.Fatalf("bad ctVal: %q %q", fut[0].ContractSize, fut[1].ContractSize)
+	}
+}
+
+func TestFetch_OKX_ContractKinds(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v5/public/instruments", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("instType") {
+		case "SWAP":
+			w.Write([]byte(`{"data":[
+				{"instId":"BTC-USDT-SWAP","uly":"BTC-USDT","ctType":"linear","settleCcy":"USDT","ctVal":"0.01","state":"live"},
+				{"instId":"BTC-USD-SWAP","uly":"BTC-USD","ctType":"inverse","settleCcy":"BTC","ctVal":"100","state":"live"}]}`))
+		case "FUTURES":
+			w.Write([]byte(`{"data":[
+				{"instId":"BTC-USD-250926","uly":"BTC-USD","ctType":"inverse","settleCcy":"BTC","ctVal":"100","expTime":"1758873600000","state":"live"}]}`))
+		}
+	})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	fut, err := newClient(ts).FetchFutures(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fut) != 3 {
+		t.Fatalf("want 3 contracts, got %+v", fut)
+	}
+	want := []struct {
+		kind   dm.ContractKind
+		settle string
+	}{
+		{dm.ContractLinearPerp, "USDT"},
+		{dm.ContractInversePerp, "BTC"},
+		{dm.ContractDelivery, "BTC"},
+	}
+	for i, w := range want {
+		if fut[i].Contract != w.kind || fut[i].Settle != w.settle || fut[i].Base != "BTC" {
+			t.Fatalf("%s: %+v", fut[i].Symbol, fut[i])
+		}
+	}
+	if fut[0].Expiry != nil || fut[2].Expiry == nil || fut[2].Expiry.Format("2006-01-02") != "2025-09-26" {
+		t.Fatalf("bad expiry: %v / %v", fut[0].Expiry, fut[2].Expiry)
+	}
+}
+
+func TestFetchSpotTickers_OKX(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v5/market/tickers" || r.URL.Query().Get("instType") != "SPOT" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"code":"0","data":[{"instId":"AAA-USDT","last":"2.5","vol24h":"100","volCcy24h":"250"}]}`))
+	}))
+	defer ts.Close()
+
+	got, err := newClient(ts).FetchSpotTickers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != (dm.Ticker{Symbol: "AAA-USDT", Last: 2.5, QuoteVolume: 250}) {
+		t.Fatalf("got %+v", got)
+	}
+}
+
+// FUTURES недоступен — SWAP отдаются без ошибки, срочные из прошлого удачного снимка.
+func TestFetchFutures_DeliveryBestEffort(t *testing.T) {
+	t.Setenv("HTTP_RETRIES", "0")
+	var futuresDown atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("instType") {
+		case "SWAP":
+			w.Write([]byte(`{"data":[{"instId":"BTC-USDT-SWAP","uly":"BTC-USDT","ctType":"linear","state":"live"}]}`))
+		case "FUTURES":
+			if futuresDown.Load() {
+				http.Error(w, "maintenance", http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"data":[{"instId":"BTC-USD-250926","uly":"BTC-USD","ctType":"inverse","expTime":"1758873600000","state":"live"}]}`))
+		}
+	}))
+	defer ts.Close()
+	cli := newClient(ts)
+	ctx := context.Background()
+
+	// с самого старта — только перпы
+	futuresDown.Store(true)
+	fut, err := cli.FetchFutures(ctx)
+	if err != nil || len(fut) != 1 || fut[0].Symbol != "BTC-USDT-SWAP" {
+		t.Fatalf("down at start: %+v %v", fut, err)
+	}
+
+	futuresDown.Store(false)
+	if fut, err = cli.FetchFutures(ctx); err != nil || len(fut) != 2 {
+		t.Fatalf("both up: %+v %v", fut, err)
+	}
+
+	futuresDown.Store(true)
+	fut, err = cli.FetchFutures(ctx)
+	if err != nil || len(fut) != 2 || fut[1].Contract != dm.ContractDelivery {
+		t.Fatalf("down later: %+v %v", fut, err)
+	}
 }
@@ -2,6 +2,7 @@ package upbit
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/common"
@@ -10,13 +11,16 @@ import (
 
 const ExchangeID int16 = 5
 
-type Client struct{ c *common.Client }
+type Client struct {
+	c    *common.Client
+	poll common.Validators // ETag прошлого PollSpot
+}
 
 func New() *Client  { return &Client{c: common.NewWith("https://api.upbit.com", common.DefaultOptionsFromEnv())} }
 func NewWithBaseURL(base string) *Client { return &Client{c: common.NewWith(base, common.DefaultOptionsFromEnv())} }
 
-func (Client) ExchangeID() int16 { return ExchangeID }
-func (Client) Name() string      { return "upbit" }
+func (*Client) ExchangeID() int16 { return ExchangeID }
+func (*Client) Name() string      { return "upbit" }
 
 type mkt struct {
 	Market string `json:"market"` // e.g. "KRW-AAA", "BTC-AAA", "USDT-AAA"
@@ -28,6 +32,23 @@ func (cl *Client) FetchSpot(ctx context.Context) ([]dm.Item, error) {
 	if err := cl.c.GetJSON(ctx, "/v1/market/all", map[string]string{"isDetails": "false"}, &v); err != nil {
 		return nil, err
 	}
+	return cl.items(v), nil
+}
+
+// PollSpot — тот же /v1/market/all, но условным запросом: пока список не менялся, Upbit отвечает 304.
+func (cl *Client) PollSpot(ctx context.Context) ([]dm.Item, bool, error) {
+	var v []mkt
+	err := cl.c.GetJSONIfModified(ctx, "/v1/market/all", map[string]string{"isDetails": "false"}, &cl.poll, &v)
+	if errors.Is(err, common.ErrNotModified) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return cl.items(v), true, nil
+}
+
+func (cl *Client) items(v []mkt) []dm.Item {
 	out := make([]dm.Item, 0, len(v))
 	for _, m := range v {
 		parts := strings.SplitN(m.Market, "-", 2)
@@ -45,9 +66,41 @@ func (cl *Client) FetchSpot(ctx context.Context) ([]dm.Item, error) {
 			Active:     true,
 		})
 	}
-	return out, nil
+	return out
 }
 
 func (cl *Client) FetchFutures(ctx context.Context) ([]dm.Item, error) {
 	return nil, nil
 }
+
+type ticker struct {
+	Market           string  `json:"market"`
+	TradePrice       float64 `json:"trade_price"`
+	AccTradePrice24h float64 `json:"acc_trade_price_24h"` // оборот в quote за 24ч
+}
+
+// tickersBatch — сколько рынков в одном ?markets= (URL не должен разрастаться).
+const tickersBatch = 100
+
+// FetchSpotTickers: /v1/ticker принимает только явный список рынков — берём его из /v1/market/all.
+func (cl *Client) FetchSpotTickers(ctx context.Context) ([]dm.Ticker, error) {
+	var all []mkt
+	if err := cl.c.GetJSON(ctx, "/v1/market/all", map[string]string{"isDetails": "false"}, &all); err != nil {
+		return nil, err
+	}
+	out := make([]dm.Ticker, 0, len(all))
+	for i := 0; i < len(all); i += tickersBatch {
+		names := make([]string, 0, tickersBatch)
+		for _, m := range all[i:min(i+tickersBatch, len(all))] {
+			names = append(names, m.Market)
+		}
+		var v []ticker
+		if err := cl.c.GetJSON(ctx, "/v1/ticker", map[string]string{"markets": strings.Join(names, ",")}, &v); err != nil {
+			return nil, err
+		}
+		for _, t := range v {
+			out = append(out, dm.Ticker{Symbol: t.Market, Last: t.TradePrice, QuoteVolume: t.AccTradePrice24h})
+		}
+	}
+	return out, nil
+}
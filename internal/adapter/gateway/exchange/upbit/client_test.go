@@ -2,8 +2,10 @@ package upbit
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/common"
@@ -27,3 +29,60 @@ func TestFetchSpot_OK(t *testing.T) {
 		t.Fatalf("bad parse: %+v", items[1])
 	}
 }
+
+func TestFetchSpotTickers_Batches(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/market/all":
+			var ms []string
+			for i := 0; i < tickersBatch+1; i++ {
+				ms = append(ms, fmt.Sprintf(`{"market":"KRW-T%d"}`, i))
+			}
+			w.Write([]byte("[" + strings.Join(ms, ",") + "]"))
+		case "/v1/ticker":
+			calls++
+			var ts []string
+			for _, m := range strings.Split(r.URL.Query().Get("markets"), ",") {
+				ts = append(ts, fmt.Sprintf(`{"market":%q,"trade_price":10,"acc_trade_price_24h":1000.5}`, m))
+			}
+			w.Write([]byte("[" + strings.Join(ts, ",") + "]"))
+		}
+	}))
+	defer ts.Close()
+
+	cl := &Client{c: common.New(ts.URL)}
+	got, err := cl.FetchSpotTickers(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 || len(got) != tickersBatch+1 {
+		t.Fatalf("calls=%d tickers=%d", calls, len(got))
+	}
+	if got[0].Symbol != "KRW-T0" || got[0].Last != 10 || got[0].QuoteVolume != 1000.5 {
+		t.Fatalf("bad parse: %+v", got[0])
+	}
+}
+
+func TestPollSpot_NotModified(t *testing.T) {
+	var conditional int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `W/"abc"` {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `W/"abc"`)
+		w.Write([]byte(`[{"market":"KRW-BTC"},{"market":"KRW-NEW"}]`))
+	}))
+	defer ts.Close()
+
+	cl := &Client{c: common.New(ts.URL)}
+	items, changed, err := cl.PollSpot(context.Background())
+	if err != nil || !changed || len(items) != 2 || items[1].Base != "NEW" {
+		t.Fatalf("first: items=%+v changed=%v err=%v", items, changed, err)
+	}
+	if _, changed, err := cl.PollSpot(context.Background()); err != nil || changed || conditional != 1 {
+		t.Fatalf("second: changed=%v conditional=%d err=%v", changed, conditional, err)
+	}
+}
@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSlack_Send(t *testing.T) {
+	var got map[string]string
+	fail := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if fail {
+			http.Error(w, "invalid_token", http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("%s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	s := &Slack{WebhookURL: srv.URL}
+	if err := s.Send(context.Background(), "UPBIT listing KRW-XYZ", "UPBIT listed KRW-XYZ"); err != nil {
+		t.Fatal(err)
+	}
+	if got["text"] != "*UPBIT listing KRW-XYZ*\nUPBIT listed KRW-XYZ" {
+		t.Fatalf("text = %q", got["text"])
+	}
+
+	fail = true
+	if err := s.Send(context.Background(), "", "x"); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestTelegram_Send(t *testing.T) {
+	var path string
+	var body map[string]any
+	ok := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"ok":false,"description":"Bad Request: chat not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":{}}`))
+	}))
+	defer srv.Close()
+
+	tg := &Telegram{Token: "123:secret", ChatID: "-10042", BaseURL: srv.URL}
+	if err := tg.Send(context.Background(), "", "UPBIT listed KRW-XYZ"); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/bot123:secret/sendMessage" || body["chat_id"] != "-10042" || body["text"] != "UPBIT listed KRW-XYZ" {
+		t.Fatalf("path=%s body=%v", path, body)
+	}
+
+	ok = false
+	err := tg.Send(context.Background(), "", "x")
+	if err == nil || !strings.Contains(err.Error(), "chat not found") || strings.Contains(err.Error(), "secret") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+// smtpStandIn — минимальный SMTP-сервер: принимает одно письмо и отдаёт его в канал.
+func smtpStandIn(t *testing.T) (addr string, mail <-chan string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	out := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		say := func(s string) { _, _ = conn.Write([]byte(s + "\r\n")) }
+		say("220 stand-in ESMTP")
+		var env, data strings.Builder
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				say("250 stand-in")
+			case strings.HasPrefix(cmd, "MAIL FROM"), strings.HasPrefix(cmd, "RCPT TO"):
+				env.WriteString(strings.TrimSpace(line) + "\n")
+				say("250 ok")
+			case cmd == "DATA":
+				say("354 go ahead")
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				say("250 queued")
+			case cmd == "QUIT":
+				say("221 bye")
+				out <- env.String() + "\n" + data.String()
+				return
+			default:
+				say("502 not implemented")
+			}
+		}
+	}()
+	return ln.Addr().String(), out
+}
+
+func TestSMTP_Send(t *testing.T) {
+	addr, mail := smtpStandIn(t)
+	s := &SMTP{Addr: addr, From: "tickersvc@example.com", To: []string{"ops@example.com", "dev@example.com"}}
+	if err := s.Send(context.Background(), "UPBIT listing KRW-XYZ", "UPBIT listed KRW-XYZ\nBITHUMB listed XYZ-KRW"); err != nil {
+		t.Fatal(err)
+	}
+	got := <-mail
+	for _, want := range []string{
+		"MAIL FROM:<tickersvc@example.com>",
+		"RCPT TO:<ops@example.com>",
+		"RCPT TO:<dev@example.com>",
+		"Subject: UPBIT listing KRW-XYZ\r\n",
+		"To: ops@example.com, dev@example.com\r\n",
+		"\r\n\r\nUPBIT listed KRW-XYZ\r\nBITHUMB listed XYZ-KRW\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("no %q in:\n%s", want, got)
+		}
+	}
+
+	if err := (&SMTP{Addr: addr, From: "a@b"}).Send(context.Background(), "", "x"); err == nil {
+		t.Fatal("want error without recipients")
+	}
+}
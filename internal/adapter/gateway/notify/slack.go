@@ -0,0 +1,65 @@
+// Package notify — каналы доставки уведомлений: Slack (incoming webhook), Telegram Bot API, SMTP.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var defaultHTTP = &http.Client{Timeout: 15 * time.Second}
+
+// Slack — incoming webhook; тема идёт жирной первой строкой.
+type Slack struct {
+	Label      string // имя канала в логах; "" — slack
+	WebhookURL string
+	HTTP       *http.Client
+}
+
+func (s *Slack) Name() string { return label(s.Label, "slack") }
+
+func (s *Slack) Send(ctx context.Context, subject, text string) error {
+	if subject != "" {
+		text = "*" + subject + "*\n" + text
+	}
+	_, err := postJSON(ctx, s.HTTP, s.WebhookURL, map[string]string{"text": text})
+	return err
+}
+
+// postJSON — POST с JSON-телом; не-2xx — ошибка с началом ответа.
+func postJSON(ctx context.Context, hc *http.Client, url string, body any) ([]byte, error) {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if hc == nil {
+		hc = defaultHTTP
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode/100 != 2 {
+		return raw, fmt.Errorf("http %d: %s", resp.StatusCode, strings.TrimSpace(string(raw[:min(len(raw), 256)])))
+	}
+	return raw, nil
+}
+
+func label(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// SMTP — письмо в text/plain; STARTTLS — если сервер предлагает (net/smtp.SendMail).
+// Username пустой — без AUTH (PLAIN разрешён только поверх TLS или на localhost).
+type SMTP struct {
+	Label    string // "" — smtp
+	Addr     string // host:port
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+func (s *SMTP) Name() string { return label(s.Label, "smtp") }
+
+func (s *SMTP) Send(ctx context.Context, subject, text string) error {
+	if len(s.To) == 0 {
+		return errors.New("smtp: no recipients")
+	}
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, err := net.SplitHostPort(s.Addr)
+		if err != nil {
+			return fmt.Errorf("smtp addr: %w", err)
+		}
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+	msg := s.message(subject, text, time.Now())
+
+	// net/smtp без контекста: ждём в горутине, по отмене соединение дорабатывает само
+	done := make(chan error, 1)
+	go func() { done <- smtp.SendMail(s.Addr, auth, s.From, s.To, msg) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *SMTP) message(subject, text string, now time.Time) []byte {
+	var b strings.Builder
+	hdr := func(k, v string) { b.WriteString(k + ": " + v + "\r\n") }
+	hdr("From", s.From)
+	hdr("To", strings.Join(s.To, ", "))
+	hdr("Subject", mime.QEncoding.Encode("utf-8", subject))
+	hdr("Date", now.Format(time.RFC1123Z))
+	hdr("MIME-Version", "1.0")
+	hdr("Content-Type", "text/plain; charset=utf-8")
+	hdr("Content-Transfer-Encoding", "8bit")
+	b.WriteString("\r\n")
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		b.WriteString(line + "\r\n")
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Telegram — Bot API sendMessage в один чат (канал, группа, личка).
+type Telegram struct {
+	Label   string // "" — telegram
+	Token   string
+	ChatID  string
+	BaseURL string // "" — https://api.telegram.org
+	HTTP    *http.Client
+}
+
+func (t *Telegram) Name() string { return label(t.Label, "telegram") }
+
+func (t *Telegram) Send(ctx context.Context, subject, text string) error {
+	if subject != "" {
+		text = subject + "\n\n" + text
+	}
+	base := strings.TrimRight(label(t.BaseURL, "https://api.telegram.org"), "/")
+	raw, err := postJSON(ctx, t.HTTP, base+"/bot"+t.Token+"/sendMessage", map[string]any{
+		"chat_id":                  t.ChatID,
+		"text":                     text,
+		"disable_web_page_preview": true,
+	})
+	if err != nil {
+		// токен в URL — не выносим его в логи
+		return errors.New(strings.ReplaceAll(err.Error(), t.Token, "xxxxx"))
+	}
+	var r struct {
+		OK          bool   `json:"ok"`
+		Description string `json:"description"`
+	}
+	if json.Unmarshal(raw, &r) == nil && !r.OK {
+		return errors.New("telegram: " + r.Description)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+)
+
+type AliasesRepo struct{ db *sql.DB }
+
+func NewAliasesRepo(db *sql.DB) *AliasesRepo { return &AliasesRepo{db: db} }
+
+func (r *AliasesRepo) ListAliases(ctx context.Context) ([]assets.Alias, error) {
+	const q = `
+		SELECT COALESCE(a.exchange_id, 0), COALESCE(e.slug, ''), a.ticker, a.asset, a.updated_at
+		FROM asset_aliases a
+		LEFT JOIN exchanges e ON e.id = a.exchange_id
+		ORDER BY a.asset, a.ticker, COALESCE(a.exchange_id, 0)`
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("asset_aliases list: %w", err)
+	}
+	defer rows.Close()
+
+	var out []assets.Alias
+	for rows.Next() {
+		var a assets.Alias
+		if err := rows.Scan(&a.ExchangeID, &a.Exchange, &a.Ticker, &a.Asset, &a.UpdatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+func (r *AliasesRepo) UpsertAlias(ctx context.Context, exchange, ticker, asset string) (assets.Alias, error) {
+	exID, err := r.exchangeID(ctx, exchange)
+	if err != nil {
+		return assets.Alias{}, err
+	}
+	const q = `
+		INSERT INTO asset_aliases (exchange_id, ticker, asset, updated_at)
+		VALUES ($1, $2, $3, now())
+		ON CONFLICT ((COALESCE(exchange_id, 0)), ticker) DO UPDATE SET
+			asset      = EXCLUDED.asset,
+			updated_at = EXCLUDED.updated_at
+		RETURNING COALESCE(exchange_id, 0), ticker, asset, updated_at`
+	a := assets.Alias{Exchange: strings.ToLower(strings.TrimSpace(exchange))}
+	err = r.db.QueryRowContext(ctx, q, exID, strings.ToUpper(ticker), strings.ToUpper(asset)).
+		Scan(&a.ExchangeID, &a.Ticker, &a.Asset, &a.UpdatedAt)
+	if err != nil {
+		return assets.Alias{}, fmt.Errorf("asset_aliases upsert: %w", err)
+	}
+	return a, nil
+}
+
+func (r *AliasesRepo) DeleteAlias(ctx context.Context, exchange, ticker string) (bool, error) {
+	exID, err := r.exchangeID(ctx, exchange)
+	if err != nil {
+		return false, err
+	}
+	res, err := r.db.ExecContext(ctx, `
+		DELETE FROM asset_aliases
+		WHERE COALESCE(exchange_id, 0) = COALESCE($1::smallint, 0) AND ticker = $2`,
+		exID, strings.ToUpper(ticker))
+	if err != nil {
+		return false, fmt.Errorf("asset_aliases delete: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// exchangeID: "" → NULL (глобальный алиас), иначе id по slug.
+func (r *AliasesRepo) exchangeID(ctx context.Context, slug string) (any, error) {
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if slug == "" {
+		return nil, nil
+	}
+	var id int16
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM exchanges WHERE slug = $1`, slug).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", assets.ErrUnknownExchange, slug)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+var _ assets.AliasRepo = (*AliasesRepo)(nil)
@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 )
 
@@ -24,9 +25,36 @@ func (r *ExchangesRepo) ActiveMap(ctx context.Context) (map[int16]bool, error) {
 	return m, rows.Err()
 }
 
+// ErrUnknownExchange — биржи с таким slug нет в exchanges.
+var ErrUnknownExchange = errors.New("unknown exchange")
+
 func (r *ExchangesRepo) SetActiveBySlug(ctx context.Context, slug string, on bool) error {
 	const q = `UPDATE exchanges SET is_active=$1 WHERE slug=$2`
-	_, err := r.db.ExecContext(ctx, q, on, slug)
+	res, err := r.db.ExecContext(ctx, q, on, slug)
 	if err != nil { return fmt.Errorf("exchanges set active: %w", err) }
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownExchange, slug)
+	}
 	return nil
 }
+
+type Exchange struct {
+	ID     int16
+	Slug   string
+	Name   string
+	Active bool
+}
+
+func (r *ExchangesRepo) List(ctx context.Context) ([]Exchange, error) {
+	const q = `SELECT id, slug, name, is_active FROM exchanges ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil { return nil, fmt.Errorf("exchanges list: %w", err) }
+	defer rows.Close()
+	var out []Exchange
+	for rows.Next() {
+		var e Exchange
+		if err := rows.Scan(&e.ID, &e.Slug, &e.Name, &e.Active); err != nil { return nil, err }
+		out = append(out, e)
+	}
+	return out, rows.Err()
+}
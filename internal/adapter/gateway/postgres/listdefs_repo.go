@@ -3,11 +3,13 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"github.com/lib/pq"
 
 	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
 )
 
 type ListDef struct {
@@ -25,7 +27,8 @@ func (r *ListDefsRepo) Find(ctx context.Context, sourceSlug, targetSlug *string)
 	q := `
 		SELECT ld.id, ld.slug,
 			ld.source_exchange, s.slug,
-			ld.target_exchange, t.slug
+			ld.target_exchange, t.slug,
+			ld.futures_kinds
 		FROM list_defs ld
 		JOIN exchanges s ON s.id = ld.source_exchange AND s.is_active = true
 		JOIN exchanges t ON t.id = ld.target_exchange AND t.is_active = true
@@ -54,9 +57,11 @@ func (r *ListDefsRepo) Find(ctx context.Context, sourceSlug, targetSlug *string)
 	var out []listsdom.Def
 	for rows.Next() {
 		var d listsdom.Def
-		if err := rows.Scan(&d.ID, &d.Slug, &d.SourceID, &d.SourceSlug, &d.TargetID, &d.TargetSlug); err != nil {
+		var kinds []string
+		if err := rows.Scan(&d.ID, &d.Slug, &d.SourceID, &d.SourceSlug, &d.TargetID, &d.TargetSlug, pq.Array(&kinds)); err != nil {
 			return nil, err
 		}
+		d.FuturesKinds = toKinds(kinds)
 		out = append(out, d)
 	}
 	return out, rows.Err()
@@ -64,16 +69,18 @@ func (r *ListDefsRepo) Find(ctx context.Context, sourceSlug, targetSlug *string)
 
 func (r *ListDefsRepo) GetByID(ctx context.Context, id int16) (listsdom.Def, error) {
 	const q = `
-		SELECT ld.id, ld.slug, ld.source_exchange, s.slug, ld.target_exchange, t.slug
+		SELECT ld.id, ld.slug, ld.source_exchange, s.slug, ld.target_exchange, t.slug, ld.futures_kinds
 		FROM list_defs ld
 		JOIN exchanges s ON s.id = ld.source_exchange
 		JOIN exchanges t ON t.id = ld.target_exchange
 		WHERE ld.id = $1`
 	var d listsdom.Def
+	var kinds []string
 	if err := r.db.QueryRowContext(ctx, q, id).
-		Scan(&d.ID, &d.Slug, &d.SourceID, &d.SourceSlug, &d.TargetID, &d.TargetSlug); err != nil {
+		Scan(&d.ID, &d.Slug, &d.SourceID, &d.SourceSlug, &d.TargetID, &d.TargetSlug, pq.Array(&kinds)); err != nil {
 		return listsdom.Def{}, fmt.Errorf("list_defs get by id: %w", err)
 	}
+	d.FuturesKinds = toKinds(kinds)
 	return d, nil
 }
 
@@ -129,5 +136,103 @@ func (r *ListDefsRepo) IDsBySlugs(ctx context.Context, slugs []string) (map[stri
     return out, rows.Err()
 }
 
+func (r *ListDefsRepo) SegmentDefs(ctx context.Context) ([]listsdom.SegmentDef, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT ld.id, ld.slug, s.slug, ld.segment, ld.segment_expr, ld.futures_kinds
+		FROM list_defs ld
+		JOIN exchanges s ON s.id = ld.source_exchange
+		WHERE ld.list_kind = 'segment'
+		ORDER BY ld.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list_defs segments: %w", err)
+	}
+	defer rows.Close()
+
+	var out []listsdom.SegmentDef
+	for rows.Next() {
+		var d listsdom.SegmentDef
+		var kinds []string
+		if err := rows.Scan(&d.ID, &d.Slug, &d.SourceSlug, &d.Segment, &d.Expr, pq.Array(&kinds)); err != nil {
+			return nil, err
+		}
+		d.FuturesKinds = toKinds(kinds)
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+// Create заводит target-список source → target (ignore_btc_only — как у сидов: для upbit/bithumb).
+// ErrExists — slug занят.
+func (r *ListDefsRepo) Create(ctx context.Context, d listsdom.Def) (int16, error) {
+	var id int16
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO list_defs (slug, source_exchange, target_exchange, ignore_btc_only, futures_kinds)
+		SELECT $1, s.id, t.id, t.slug IN ('upbit', 'bithumb'), $4
+		FROM exchanges s, exchanges t
+		WHERE s.slug = $2 AND t.slug = $3
+		ON CONFLICT (slug) DO NOTHING
+		RETURNING id
+	`, d.Slug, d.SourceSlug, d.TargetSlug, pq.Array(fromKinds(d.FuturesKinds))).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, r.whyNotCreated(ctx, d.Slug, d.SourceSlug, d.TargetSlug)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("list_defs create: %w", err)
+	}
+	return id, nil
+}
+
+// CreateSegment заводит сегмент источника; выражение проверяет вызывающий (listsuc.ValidateSegmentExpr).
+func (r *ListDefsRepo) CreateSegment(ctx context.Context, d listsdom.SegmentDef) (int16, error) {
+	var id int16
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO list_defs (slug, source_exchange, list_kind, segment, segment_expr, futures_kinds)
+		SELECT $1, s.id, 'segment', $3, $4, $5
+		FROM exchanges s
+		WHERE s.slug = $2
+		ON CONFLICT (slug) DO NOTHING
+		RETURNING id
+	`, d.Slug, d.SourceSlug, d.Segment, d.Expr, pq.Array(fromKinds(d.FuturesKinds))).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, r.whyNotCreated(ctx, d.Slug, d.SourceSlug)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("list_defs create segment: %w", err)
+	}
+	return id, nil
+}
+
+// whyNotCreated: INSERT ... SELECT не вставил строку — либо slug занят, либо нет такой биржи.
+func (r *ListDefsRepo) whyNotCreated(ctx context.Context, slug string, exchanges ...string) error {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM list_defs WHERE slug = $1)`, slug).Scan(&exists); err != nil {
+		return fmt.Errorf("list_defs create: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", listsdom.ErrExists, slug)
+	}
+	return fmt.Errorf("list_defs create: unknown exchange in %v", exchanges)
+}
+
+func fromKinds(ks []dm.ContractKind) []string {
+	if len(ks) == 0 {
+		return []string{string(dm.ContractLinearPerp)}
+	}
+	out := make([]string, 0, len(ks))
+	for _, k := range ks {
+		out = append(out, string(k))
+	}
+	return out
+}
+
+func toKinds(ss []string) []dm.ContractKind {
+	out := make([]dm.ContractKind, 0, len(ss))
+	for _, s := range ss {
+		out = append(out, dm.ContractKind(s))
+	}
+	return out
+}
+
 // small helper to pass string slice as Postgres array
 func pqArray[T any](v []T) interface{} { return pq.Array(v) }
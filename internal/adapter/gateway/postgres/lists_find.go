@@ -0,0 +1,167 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+)
+
+const maxListRowsLimit = 1000
+
+// listsCursor — последняя отданная строка; Volume — только при сортировке по обороту (-1 — оборот неизвестен).
+type listsCursor struct {
+	Volume *float64 `json:"v,omitempty"`
+	Source string   `json:"src,omitempty"`
+	Spot   string   `json:"s"`
+}
+
+func (c listsCursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeListsCursor(s string) (listsCursor, error) {
+	var c listsCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, listsdom.ErrBadCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil || c.Spot == "" {
+		return c, listsdom.ErrBadCursor
+	}
+	return c, nil
+}
+
+// likeEscape экранирует спецсимволы LIKE (escape по умолчанию — обратный слэш).
+var likeEscape = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *ListsQueryRepo) FindRowsBySlug(ctx context.Context, slug string, f listsdom.RowsFilter) (listsdom.RowsPage, error) {
+	return r.findRows(ctx, "ld.slug", slug, f)
+}
+
+func (r *ListsQueryRepo) FindRowsByTarget(ctx context.Context, targetSlug string, f listsdom.RowsFilter) (listsdom.RowsPage, error) {
+	return r.findRows(ctx, "t.slug", targetSlug, f)
+}
+
+// findRows — фильтр, общее число и страница одним запросом (один снимок поколения).
+// Если страница пуста, LEFT JOIN всё равно даёт строку с total и NULL вместо полей.
+func (r *ListsQueryRepo) findRows(ctx context.Context, scopeCol, scope string, f listsdom.RowsFilter) (listsdom.RowsPage, error) {
+	if f.Limit > maxListRowsLimit {
+		f.Limit = maxListRowsLimit
+	}
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	where := []string{scopeCol + " = " + arg(scope)}
+	args = append(args, genArg(ctx))
+	where = append(where, genCond(len(args)))
+	if q := strings.ToUpper(strings.TrimSpace(f.Q)); q != "" {
+		q = likeEscape.Replace(q)
+		where = append(where, fmt.Sprintf("(ms.base_asset LIKE %s OR upper(li.spot_symbol) LIKE %s)", arg(q+"%"), arg("%"+q+"%")))
+	}
+	if f.HasFutures != nil {
+		if *f.HasFutures {
+			where = append(where, "li.futures_symbol IS NOT NULL")
+		} else {
+			where = append(where, "li.futures_symbol IS NULL")
+		}
+	}
+	if len(f.Quotes) > 0 {
+		where = append(where, "ms.quote_asset = ANY("+arg(pq.Array(upperAll(f.Quotes)))+")")
+	}
+	if f.MinVolume > 0 {
+		where = append(where, "ms.volume_usd_24h >= "+arg(f.MinVolume))
+	}
+
+	byVolume := f.Sort == listsdom.SortVolume
+	order, outer := "source, spot", "page.source, page.spot"
+	if byVolume {
+		order, outer = "vol DESC, source, spot", "page.vol DESC, page.source, page.spot"
+	}
+	var after, limit string
+	if f.Cursor != "" {
+		c, err := decodeListsCursor(f.Cursor)
+		if err != nil {
+			return listsdom.RowsPage{}, err
+		}
+		if byVolume != (c.Volume != nil) {
+			return listsdom.RowsPage{}, listsdom.ErrBadCursor // курсор от другой сортировки
+		}
+		after = fmt.Sprintf("WHERE (source, spot) > (%s, %s)", arg(c.Source), arg(c.Spot))
+		if byVolume {
+			v := arg(*c.Volume)
+			after = fmt.Sprintf("WHERE (vol < %s OR vol = %s AND (source, spot) > (%s, %s))", v, v, arg(c.Source), arg(c.Spot))
+		}
+	}
+	if f.Limit > 0 {
+		limit = fmt.Sprintf("LIMIT %d", f.Limit+1) // +1 — есть ли следующая страница
+	}
+
+	q := `
+		WITH f AS (
+			SELECT s.slug AS source, li.spot_symbol AS spot, li.futures_symbol AS fut,
+			       COALESCE(ms.base_asset, '') AS base, COALESCE(ms.quote_asset, '') AS quote,
+			       COALESCE(mf.base_asset, '') AS fbase, COALESCE(mf.quote_asset, '') AS fquote,
+			       COALESCE(mf.contract_kind, '') AS fkind, COALESCE(mf.settle_asset, '') AS fsettle, mf.expiry_at AS fexp,
+			       ms.volume_usd_24h::float8 AS volume, COALESCE(ms.volume_usd_24h::float8, -1) AS vol
+			FROM list_items li
+			JOIN list_defs ld     ON ld.id = li.list_id
+			JOIN exchanges s      ON s.id = ld.source_exchange
+			LEFT JOIN exchanges t ON t.id = ld.target_exchange` + rowsMarketsJoin + `
+			WHERE ` + strings.Join(where, "\n\t\t\t  AND ") + `
+		), page AS (
+			SELECT * FROM f ` + after + `
+			ORDER BY ` + order + ` ` + limit + `
+		)
+		SELECT (SELECT COUNT(*) FROM f), page.source, page.spot, page.fut, page.base, page.quote,
+		       page.fbase, page.fquote, page.fkind, page.fsettle, page.fexp, page.volume, page.vol
+		FROM (SELECT 1) AS one
+		LEFT JOIN page ON TRUE
+		ORDER BY ` + outer
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return listsdom.RowsPage{}, fmt.Errorf("lists.findRows: %w", err)
+	}
+	defer rows.Close()
+
+	var page listsdom.RowsPage
+	var lastVol *float64 // vol последней отданной строки — для курсора
+	for rows.Next() {
+		var src, spot, base, quote, fbase, fquote, fkind, fsettle *string
+		var row listsdom.Row
+		var exp *time.Time
+		var vol *float64
+		if err := rows.Scan(&page.Total, &src, &spot, &row.Futures, &base, &quote,
+			&fbase, &fquote, &fkind, &fsettle, &exp, &row.VolumeUSD, &vol); err != nil {
+			return listsdom.RowsPage{}, fmt.Errorf("lists.findRows.scan: %w", err)
+		}
+		if spot == nil {
+			continue // пустая страница
+		}
+		if f.Limit > 0 && len(page.Rows) == f.Limit {
+			last := page.Rows[len(page.Rows)-1]
+			c := listsCursor{Source: last.Source, Spot: last.Spot}
+			if byVolume {
+				c.Volume = lastVol
+			}
+			page.NextCursor = c.encode()
+			break
+		}
+		row.Source, row.Spot, row.Base, row.Quote = *src, *spot, *base, *quote
+		row.FuturesBase, row.FuturesQuote, row.FuturesKind, row.FuturesSettle = *fbase, *fquote, *fkind, *fsettle
+		row.FuturesExpiry = exp
+		page.Rows = append(page.Rows, row)
+		lastVol = vol
+	}
+	return page, rows.Err()
+}
@@ -3,9 +3,12 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"sort"
 
+	"github.com/lib/pq"
+
 	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
 )
 
@@ -13,13 +16,45 @@ type ListsQueryRepo struct{ db *sql.DB }
 
 func NewListsQueryRepo(db *sql.DB) *ListsQueryRepo { return &ListsQueryRepo{db: db} }
 
+// genCond — строки list_items (li) одного поколения: закреплённого в ctx (?generation=) или текущего.
+// Поколение хранит не строки, а версии списков (list_generation_lists), см. ListsRepo.Publish.
+// Один SQL-оператор видит один снимок, поэтому указатель list_state читается согласованно с данными.
+func genCond(n int) string {
+	return fmt.Sprintf(`(li.list_id, li.generation) IN (
+			SELECT gl.list_id, gl.items_gen FROM list_generation_lists gl
+			WHERE gl.generation = COALESCE($%d::bigint, (SELECT generation FROM list_state)))`, n)
+}
+
+func genArg(ctx context.Context) any {
+	if id, ok := listsdom.GenerationFrom(ctx); ok {
+		return id
+	}
+	return nil
+}
+
+// Generation: id == 0 — текущее; ErrGenerationNotFound, если такого поколения (уже) нет.
+func (r *ListsQueryRepo) Generation(ctx context.Context, id int64) (listsdom.Generation, error) {
+	var g listsdom.Generation
+	err := r.db.QueryRowContext(ctx, `
+		SELECT g.id, g.created_at FROM list_generations g
+		WHERE g.id = CASE WHEN $1::bigint = 0 THEN (SELECT generation FROM list_state) ELSE $1::bigint END`, id).
+		Scan(&g.ID, &g.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return listsdom.Generation{}, listsdom.ErrGenerationNotFound
+	}
+	if err != nil {
+		return listsdom.Generation{}, fmt.Errorf("lists.Generation: %w", err)
+	}
+	return g, nil
+}
+
 func (r *ListsQueryRepo) GetTextBySlug(ctx context.Context, slug string) ([]string, error) {
 	rows, err := r.db.QueryContext(ctx, `
 SELECT li.spot_symbol, COALESCE(li.futures_symbol, 'none')
 FROM list_items li
 JOIN list_defs ld ON ld.id = li.list_id
-WHERE ld.slug = $1
-ORDER BY li.spot_symbol`, slug)
+WHERE ld.slug = $1 AND `+genCond(2)+`
+ORDER BY li.spot_symbol`, slug, genArg(ctx))
 	if err != nil { return nil, err }
 	defer rows.Close()
 
@@ -33,16 +68,16 @@ ORDER BY li.spot_symbol`, slug)
 }
 
 func (r *ListsQueryRepo) GetTextByTarget(ctx context.Context, targetSlug string) (map[string][]string, error) {
-    const q = `
+    q := `
         SELECT s.slug as source_slug, li.spot_symbol,
                COALESCE(li.futures_symbol,'none') AS fut
         FROM list_items li
         JOIN list_defs  ld ON ld.id = li.list_id
         JOIN exchanges  s  ON s.id = ld.source_exchange
         JOIN exchanges  t  ON t.id = ld.target_exchange
-        WHERE t.slug = $1
+        WHERE t.slug = $1 AND ` + genCond(2) + `
         ORDER BY s.slug, li.spot_symbol`
-    rows, err := r.db.QueryContext(ctx, q, targetSlug)
+    rows, err := r.db.QueryContext(ctx, q, targetSlug, genArg(ctx))
     if err != nil {
         return nil, fmt.Errorf("lists.GetTextByTarget: %w", err)
     }
@@ -66,7 +101,8 @@ FROM list_items li
 JOIN list_defs ld ON ld.id = li.list_id
 JOIN exchanges s ON s.id = ld.source_exchange
 JOIN exchanges t ON t.id = ld.target_exchange
-ORDER BY t.slug, s.slug, li.spot_symbol`)
+WHERE `+genCond(1)+`
+ORDER BY t.slug, s.slug, li.spot_symbol`, genArg(ctx))
 	if err != nil { return nil, err }
 	defer rows.Close()
 
@@ -89,13 +125,17 @@ ORDER BY t.slug, s.slug, li.spot_symbol`)
 }
 
 func (r *ListsQueryRepo) GetRowsBySlug(ctx context.Context, slug string) ([]listsdom.Row, error) {
-	const q = `
-		SELECT li.spot_symbol, li.futures_symbol
+	q := `
+		SELECT li.spot_symbol, li.futures_symbol, s.slug,
+		       COALESCE(ms.base_asset, ''), COALESCE(ms.quote_asset, ''),
+		       COALESCE(mf.base_asset, ''), COALESCE(mf.quote_asset, ''),
+		       COALESCE(mf.contract_kind, ''), COALESCE(mf.settle_asset, ''), mf.expiry_at, ms.volume_usd_24h
 		FROM list_items li
 		JOIN list_defs ld ON ld.id = li.list_id
-		WHERE ld.slug = $1
+		JOIN exchanges s  ON s.id = ld.source_exchange` + rowsMarketsJoin + `
+		WHERE ld.slug = $1 AND ` + genCond(2) + `
 		ORDER BY li.spot_symbol`
-	rows, err := r.db.QueryContext(ctx, q, slug)
+	rows, err := r.db.QueryContext(ctx, q, slug, genArg(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -103,16 +143,128 @@ func (r *ListsQueryRepo) GetRowsBySlug(ctx context.Context, slug string) ([]list
 
 	var out []listsdom.Row
 	for rows.Next() {
-		var spot string
-		var fut *string
-		if err := rows.Scan(&spot, &fut); err != nil {
+		var row listsdom.Row
+		if err := rows.Scan(&row.Spot, &row.Futures, &row.Source,
+			&row.Base, &row.Quote, &row.FuturesBase, &row.FuturesQuote,
+			&row.FuturesKind, &row.FuturesSettle, &row.FuturesExpiry, &row.VolumeUSD); err != nil {
 			return nil, err
 		}
-		out = append(out, listsdom.Row{Spot: spot, Futures: fut})
+		out = append(out, row)
 	}
 	return out, rows.Err()
 }
 
+// base/quote (и вид контракта фьючерса) для строки списка — рынки источника
+const rowsMarketsJoin = `
+		LEFT JOIN markets ms ON ms.exchange_id = ld.source_exchange
+		                    AND ms.symbol = li.spot_symbol AND ms.mtype = 'spot'
+		LEFT JOIN markets mf ON mf.exchange_id = ld.source_exchange
+		                    AND mf.symbol = li.futures_symbol AND mf.mtype = 'futures'`
+
+func (r *ListsQueryRepo) GetRowsByTarget(ctx context.Context, targetSlug string) (map[string][]listsdom.Row, error) {
+	q := `
+		SELECT s.slug, li.spot_symbol, li.futures_symbol,
+		       COALESCE(ms.base_asset, ''), COALESCE(ms.quote_asset, ''),
+		       COALESCE(mf.base_asset, ''), COALESCE(mf.quote_asset, ''),
+		       COALESCE(mf.contract_kind, ''), COALESCE(mf.settle_asset, ''), mf.expiry_at, ms.volume_usd_24h
+		FROM list_items li
+		JOIN list_defs ld ON ld.id = li.list_id
+		JOIN exchanges s  ON s.id = ld.source_exchange
+		JOIN exchanges t  ON t.id = ld.target_exchange` + rowsMarketsJoin + `
+		WHERE t.slug = $1 AND ` + genCond(2) + `
+		ORDER BY s.slug, li.spot_symbol`
+	rows, err := r.db.QueryContext(ctx, q, targetSlug, genArg(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("lists.GetRowsByTarget: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[string][]listsdom.Row{}
+	for rows.Next() {
+		var row listsdom.Row
+		if err := rows.Scan(&row.Source, &row.Spot, &row.Futures,
+			&row.Base, &row.Quote, &row.FuturesBase, &row.FuturesQuote,
+			&row.FuturesKind, &row.FuturesSettle, &row.FuturesExpiry, &row.VolumeUSD); err != nil {
+			return nil, fmt.Errorf("lists.GetRowsByTarget.scan: %w", err)
+		}
+		out[row.Source] = append(out[row.Source], row)
+	}
+	return out, rows.Err()
+}
+
+// metaSQL — метаданные списков; count — в поколении $1 (genCond(1)).
+var metaSQL = `
+		SELECT ld.slug, ld.list_kind, s.slug, COALESCE(t.slug, ''), COALESCE(ld.segment, ''),
+		       COALESCE(ld.segment_expr, ''), ld.updated_at,
+		       (SELECT COUNT(*) FROM list_items li WHERE li.list_id = ld.id AND ` + genCond(1) + `)
+		FROM list_defs ld
+		JOIN exchanges s      ON s.id = ld.source_exchange
+		LEFT JOIN exchanges t ON t.id = ld.target_exchange`
+
+func (r *ListsQueryRepo) GetMeta(ctx context.Context, slug string) (listsdom.Meta, error) {
+	q := metaSQL + `
+		WHERE ld.slug = $2`
+	var m listsdom.Meta
+	err := r.db.QueryRowContext(ctx, q, genArg(ctx), slug).
+		Scan(&m.Slug, &m.Kind, &m.SourceSlug, &m.TargetSlug, &m.Segment, &m.Expr, &m.UpdatedAt, &m.Count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return listsdom.Meta{}, listsdom.ErrNotFound
+	}
+	if err != nil {
+		return listsdom.Meta{}, fmt.Errorf("lists.GetMeta: %w", err)
+	}
+	return m, nil
+}
+
+func (r *ListsQueryRepo) ListMetas(ctx context.Context) ([]listsdom.Meta, error) {
+	rows, err := r.db.QueryContext(ctx, metaSQL+`
+		ORDER BY ld.slug`, genArg(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("lists.ListMetas: %w", err)
+	}
+	defer rows.Close()
+	var out []listsdom.Meta
+	for rows.Next() {
+		var m listsdom.Meta
+		if err := rows.Scan(&m.Slug, &m.Kind, &m.SourceSlug, &m.TargetSlug, &m.Segment, &m.Expr, &m.UpdatedAt, &m.Count); err != nil {
+			return nil, fmt.Errorf("lists.ListMetas.scan: %w", err)
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+func (r *ListsQueryRepo) MembershipsByBases(ctx context.Context, bases []string) ([]listsdom.Membership, error) {
+	q := `
+		SELECT ld.slug, ld.list_kind, ld.source_exchange, s.slug, COALESCE(t.slug, ''), COALESCE(ld.segment, ''),
+		       ms.base_asset, li.spot_symbol, li.futures_symbol, li.created_at
+		FROM list_items li
+		JOIN list_defs ld     ON ld.id = li.list_id
+		JOIN exchanges s      ON s.id = ld.source_exchange
+		LEFT JOIN exchanges t ON t.id = ld.target_exchange
+		JOIN markets ms       ON ms.exchange_id = ld.source_exchange
+		                     AND ms.symbol = li.spot_symbol AND ms.mtype = 'spot'
+		WHERE ms.base_asset = ANY($1) AND ` + genCond(2) + `
+		ORDER BY ld.slug`
+	rows, err := r.db.QueryContext(ctx, q, pq.Array(upperAll(bases)), genArg(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("lists.MembershipsByBases: %w", err)
+	}
+	defer rows.Close()
+
+	var out []listsdom.Membership
+	for rows.Next() {
+		var m listsdom.Membership
+		if err := rows.Scan(&m.Slug, &m.Kind, &m.SourceID, &m.SourceSlug, &m.TargetSlug, &m.Segment,
+			&m.Base, &m.Spot, &m.Futures, &m.Since); err != nil {
+			return nil, fmt.Errorf("lists.MembershipsByBases.scan: %w", err)
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
 
 // (Компилятор требует, чтобы ListsQueryRepo реализовывал интерфейс)
 var _ listsdom.QueryRepo = (*ListsQueryRepo)(nil)
+var _ listsdom.MembershipRepo = (*ListsQueryRepo)(nil)
+var _ listsdom.GenerationRepo = (*ListsQueryRepo)(nil)
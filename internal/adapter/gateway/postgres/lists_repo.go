@@ -4,152 +4,301 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/lib/pq"
+
 	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
 )
 
-type ListsRepo struct{ db *sql.DB }
+type ListsRepo struct {
+	db *sql.DB
+
+	mu        sync.Mutex
+	onPublish []func(listsdom.Generation)
+	last      int64 // последнее разосланное поколение
+}
+
+// PublishChannel — канал NOTIFY о новом поколении (payload — id): так публикацию из другого процесса
+// (tickerctl rebuild) видят кэш и WatchLists API, см. Listen.
+const PublishChannel = "list_generations"
 
 func NewListsRepo(db *sql.DB) *ListsRepo { return &ListsRepo{db: db} }
 
 func (r *ListsRepo) ReplaceBySlug(ctx context.Context, slug string, items []listsdom.Item) (int, error) {
-	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
-	if err != nil { return 0, err }
-	rollback := func(e error) (int, error) { _ = tx.Rollback(); return 0, e }
-
 	var listID int16
-	err = tx.QueryRowContext(ctx, `SELECT id FROM list_defs WHERE slug=$1 FOR UPDATE`, slug).Scan(&listID)
-	if err != nil { return rollback(fmt.Errorf("list slug not found: %w", err)) }
-
-	n, err := replaceByIDTx(ctx, tx, listID, items)
-	if err != nil { return rollback(err) }
+	err := r.db.QueryRowContext(ctx, `SELECT id FROM list_defs WHERE slug=$1`, slug).Scan(&listID)
+	if err != nil { return 0, fmt.Errorf("list slug not found: %w", err) }
+	return r.ReplaceByListID(ctx, listID, items)
+}
 
-	// отметим время обновления
-	if _, err := tx.ExecContext(ctx, `UPDATE list_defs SET updated_at = $2 WHERE id = $1`, listID, time.Now().UTC()); err != nil {
-		return rollback(fmt.Errorf("update list_defs.updated_at: %w", err))
+// ReplaceByListID — новое поколение, в котором заменён один список.
+func (r *ListsRepo) ReplaceByListID(ctx context.Context, listID int16, items []listsdom.Item) (int, error) {
+	if _, err := r.Publish(ctx, map[int16][]listsdom.Item{listID: items}); err != nil {
+		return 0, err
 	}
+	return len(items), nil
+}
 
-	if err := tx.Commit(); err != nil { return 0, err }
-	return n, nil
+// OnPublish — fn вызывается после коммита каждого нового поколения (сброс кэшей чтения).
+func (r *ListsRepo) OnPublish(fn func(listsdom.Generation)) {
+	r.mu.Lock()
+	r.onPublish = append(r.onPublish, fn)
+	r.mu.Unlock()
 }
 
-func (r *ListsRepo) ReplaceByListID(ctx context.Context, listID int16, items []listsdom.Item) (int, error) {
-	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
-	if err != nil { return 0, err }
-	rollback := func(e error) (int, error) { _ = tx.Rollback(); return 0, e }
+// genKeep — сколько живёт поколение для ?generation= (старые удаляются при публикации). Считается по
+// возрасту, а не по числу: частые публикации детектора листингов не выбьют только что выданный номер.
+const genKeep = 24 * time.Hour
 
-	// залочим строку определения на время обновления (на случай параллельных апдейтов)
-	var existed int
-	if err := tx.QueryRowContext(ctx, `SELECT 1 FROM list_defs WHERE id=$1 FOR UPDATE`, listID).Scan(&existed); err != nil {
-		return rollback(fmt.Errorf("list id not found: %w", err))
+// Publish пишет новое поколение и переключает на него list_state одной транзакцией.
+// Строки пишутся только для списков из lists — новой версией; остальные списки поколение берёт у текущего
+// ссылкой на их версию (list_generation_lists). created_at остающихся символов сохраняется — это «в списке с».
+func (r *ListsRepo) Publish(ctx context.Context, lists map[int16][]listsdom.Item) (listsdom.Generation, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return listsdom.Generation{}, err
 	}
+	rollback := func(e error) (listsdom.Generation, error) { _ = tx.Rollback(); return listsdom.Generation{}, e }
 
-	n, err := replaceByIDTx(ctx, tx, listID, items)
-	if err != nil { return rollback(err) }
-
-	if _, err := tx.ExecContext(ctx, `UPDATE list_defs SET updated_at = $2 WHERE id = $1`, listID, time.Now().UTC()); err != nil {
-		return rollback(fmt.Errorf("update list_defs.updated_at: %w", err))
+	// лок указателя сериализует публикации: параллельная пересборка не потеряет чужие списки
+	var cur int64
+	if err := tx.QueryRowContext(ctx, `SELECT generation FROM list_state FOR UPDATE`).Scan(&cur); err != nil {
+		return rollback(fmt.Errorf("lock list_state: %w", err))
 	}
 
-	if err := tx.Commit(); err != nil { return 0, err }
-	return n, nil
-}
+	ids := make([]int64, 0, len(lists))
+	for id := range lists {
+		ids = append(ids, int64(id))
+	}
+	var known int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM list_defs WHERE id = ANY($1)`, pq.Array(ids)).Scan(&known); err != nil {
+		return rollback(err)
+	}
+	if known != len(ids) {
+		return rollback(fmt.Errorf("list id not found: %w", sql.ErrNoRows))
+	}
 
-// Внутренний помощник: DELETE + bulk INSERT в рамках уже открытой транзакции.
-func replaceByIDTx(ctx context.Context, tx *sql.Tx, listID int16, items []listsdom.Item) (int, error) {
-	// Удаляем старое содержимое
-	if _, err := tx.ExecContext(ctx, `DELETE FROM list_items WHERE list_id = $1`, listID); err != nil {
-		return 0, fmt.Errorf("delete old list_items: %w", err)
+	var g listsdom.Generation
+	if err := tx.QueryRowContext(ctx, `INSERT INTO list_generations DEFAULT VALUES RETURNING id, created_at`).
+		Scan(&g.ID, &g.CreatedAt); err != nil {
+		return rollback(fmt.Errorf("insert list_generations: %w", err))
 	}
 
-	// Пустой список — это валидно (оставили пустым)
-	if len(items) == 0 {
-		return 0, nil
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO list_generation_lists (generation, list_id, items_gen)
+		SELECT $1::bigint, list_id, items_gen FROM list_generation_lists
+		WHERE generation = $2 AND NOT (list_id = ANY($3::smallint[]))
+		UNION ALL
+		SELECT $1::bigint, id, $1::bigint FROM unnest($3::smallint[]) AS id`, g.ID, cur, pq.Array(ids)); err != nil {
+		return rollback(fmt.Errorf("insert list_generation_lists: %w", err))
 	}
 
-	// Bulk INSERT
-	const cols = 3 // (list_id, spot_symbol, futures_symbol)
-	vals := make([]string, 0, len(items))
-	args := make([]any, 0, len(items)*cols)
+	since, err := itemsSince(ctx, tx, cur, ids)
+	if err != nil {
+		return rollback(err)
+	}
+	for listID, items := range lists {
+		if err := insertItems(ctx, tx, g.ID, listID, items, since[listID]); err != nil {
+			return rollback(err)
+		}
+	}
 
-	for i, it := range items {
-		off := i*cols + 1
-		vals = append(vals, fmt.Sprintf("($%d,$%d,$%d)", off, off+1, off+2))
-		args = append(args, listID, it.Spot, it.Futures) // nil → NULL
+	if _, err := tx.ExecContext(ctx, `UPDATE list_defs SET updated_at = $2 WHERE id = ANY($1)`, pq.Array(ids), time.Now().UTC()); err != nil {
+		return rollback(fmt.Errorf("update list_defs.updated_at: %w", err))
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE list_state SET generation = $1`, g.ID); err != nil {
+		return rollback(fmt.Errorf("update list_state: %w", err))
+	}
+	if err := prune(ctx, tx, g); err != nil {
+		return rollback(err)
+	}
+	// уходит слушателям только при коммите
+	if _, err := tx.ExecContext(ctx, `SELECT pg_notify($1, $2)`, PublishChannel, strconv.FormatInt(g.ID, 10)); err != nil {
+		return rollback(fmt.Errorf("notify %s: %w", PublishChannel, err))
 	}
 
-	q := `INSERT INTO list_items (list_id, spot_symbol, futures_symbol) VALUES ` + strings.Join(vals, ",")
-	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
-		return 0, fmt.Errorf("insert list_items: %w", err)
+	if err := tx.Commit(); err != nil {
+		return listsdom.Generation{}, err
 	}
-	return len(items), nil
+	r.dispatch(g)
+	return g, nil
 }
 
-// ReplaceItems перезаписывает все элементы списка.
-func (r *ListsRepo) ReplaceItems(ctx context.Context, listID int16, rows []listsdom.Row) (int, error) {
-	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{})
-	if err != nil { return 0, err }
-	rollback := func(e error) (int, error) { _ = tx.Rollback(); return 0, e }
-
-	// Лочим строку list_defs, как в ReplaceByListID
-	var existed int
-	if err := tx.QueryRowContext(ctx, `SELECT 1 FROM list_defs WHERE id=$1 FOR UPDATE`, listID).Scan(&existed); err != nil {
-		return rollback(fmt.Errorf("list id not found: %w", err))
+// prune удаляет поколения старше genKeep (кроме нового) и версии списков, на которые больше никто
+// не ссылается. Версия записана своим поколением, поэтому мусор — только среди версий старше самого
+// старого оставшегося поколения.
+func prune(ctx context.Context, tx *sql.Tx, g listsdom.Generation) error {
+	res, err := tx.ExecContext(ctx, `DELETE FROM list_generations WHERE id < $1 AND created_at < $2`,
+		g.ID, g.CreatedAt.Add(-genKeep))
+	if err != nil {
+		return fmt.Errorf("prune list_generations: %w", err)
 	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil
+	}
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM list_items li
+		WHERE li.generation < (SELECT MIN(id) FROM list_generations)
+		  AND NOT EXISTS (SELECT 1 FROM list_generation_lists gl
+		                  WHERE gl.list_id = li.list_id AND gl.items_gen = li.generation)`); err != nil {
+		return fmt.Errorf("prune list_items: %w", err)
+	}
+	return nil
+}
 
-	// Чистим старые элементы
-	if _, err := tx.ExecContext(ctx, `DELETE FROM list_items WHERE list_id = $1`, listID); err != nil {
-		return rollback(fmt.Errorf("delete old list_items: %w", err))
+// dispatch — подписчикам OnPublish, каждое поколение один раз: своя публикация приходит ещё и через NOTIFY.
+func (r *ListsRepo) dispatch(g listsdom.Generation) {
+	r.mu.Lock()
+	if g.ID <= r.last {
+		r.mu.Unlock()
+		return
+	}
+	r.last = g.ID
+	subs := r.onPublish
+	r.mu.Unlock()
+	for _, fn := range subs {
+		fn(g)
 	}
+}
 
-	// Дедуп по spot_symbol (на всякий)
-	type rec struct{ spot string; fut *string }
-	uniq := make(map[string]rec, len(rows))
-	for _, rr := range rows {
-		// если будут дубли — возьмём первый
-		if _, ok := uniq[rr.Spot]; !ok {
-			uniq[rr.Spot] = rec{spot: rr.Spot, fut: rr.Futures}
+// Listen — LISTEN на PublishChannel до отмены ctx: поколения, опубликованные другими процессами,
+// раздаются подписчикам OnPublish. После переподключения уведомления за время обрыва потеряны —
+// поэтому на любое событие сверяемся с list_state, а не верим payload.
+func (r *ListsRepo) Listen(ctx context.Context, dsn string) error {
+	l := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			slog.Warn("lists listener", "event", ev, "err", err)
 		}
+	})
+	if err := l.Listen(PublishChannel); err != nil {
+		_ = l.Close()
+		return fmt.Errorf("listen %s: %w", PublishChannel, err)
 	}
-	if len(uniq) == 0 {
-		// обновим метку времени и выходим
-		if _, err := tx.ExecContext(ctx, `UPDATE list_defs SET updated_at = $2 WHERE id = $1`, listID, time.Now().UTC()); err != nil {
-			return rollback(fmt.Errorf("update list_defs.updated_at: %w", err))
+	go func() {
+		defer l.Close()
+		ping := time.NewTicker(90 * time.Second) // мёртвое соединение иначе не заметить
+		defer ping.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-l.Notify: // nil — переподключились
+				r.catchUp(ctx)
+			case <-ping.C:
+				_ = l.Ping()
+			}
 		}
-		if err := tx.Commit(); err != nil { return 0, err }
-		return 0, nil
-	}
+	}()
+	return nil
+}
 
-	// Готовим bulk insert
-	const cols = 3
-	vals := make([]string, 0, len(uniq))
-	args := make([]any, 0, len(uniq)*cols)
-	i := 0
-	for _, v := range uniq {
-		off := i*cols + 1
-		vals = append(vals, fmt.Sprintf("($%d,$%d,$%d)", off, off+1, off+2))
-		args = append(args, listID, v.spot, v.fut) // nil -> NULL
-		i++
+func (r *ListsRepo) catchUp(ctx context.Context) {
+	var g listsdom.Generation
+	err := r.db.QueryRowContext(ctx, `
+		SELECT g.id, g.created_at FROM list_state s JOIN list_generations g ON g.id = s.generation`).Scan(&g.ID, &g.CreatedAt)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Warn("lists listener: read current generation", "err", err)
+		}
+		return
 	}
-	q := `INSERT INTO list_items (list_id, spot_symbol, futures_symbol) VALUES ` + strings.Join(vals, ",")
+	r.dispatch(g)
+}
 
-	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
-		return rollback(fmt.Errorf("insert list_items: %w", err))
+// insertItems — bulk INSERT элементов одного списка пачками (лимит параметров Postgres — 65535).
+func insertItems(ctx context.Context, tx *sql.Tx, gen int64, listID int16, items []listsdom.Item, since map[string]*time.Time) error {
+	const cols = 5 // (generation, list_id, spot_symbol, futures_symbol, created_at)
+	const batch = 1000
+	for lo := 0; lo < len(items); lo += batch {
+		chunk := items[lo:min(lo+batch, len(items))]
+		vals := make([]string, 0, len(chunk))
+		args := make([]any, 0, len(chunk)*cols)
+		for i, it := range chunk {
+			off := i*cols + 1
+			vals = append(vals, fmt.Sprintf("($%d,$%d,$%d,$%d,COALESCE($%d::timestamptz, now()))", off, off+1, off+2, off+3, off+4))
+			args = append(args, gen, listID, it.Spot, it.Futures, since[it.Spot]) // nil → NULL
+		}
+		q := `INSERT INTO list_items (generation, list_id, spot_symbol, futures_symbol, created_at) VALUES ` + strings.Join(vals, ",")
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return fmt.Errorf("insert list_items: %w", err)
+		}
 	}
+	return nil
+}
 
-	// Обновим updated_at
-	if _, err := tx.ExecContext(ctx, `UPDATE list_defs SET updated_at = $2 WHERE id = $1`, listID, time.Now().UTC()); err != nil {
-		return rollback(fmt.Errorf("update list_defs.updated_at: %w", err))
+// ReplaceItems перезаписывает все элементы списка (дубли по spot_symbol — берётся первый).
+func (r *ListsRepo) ReplaceItems(ctx context.Context, listID int16, rows []listsdom.Row) (int, error) {
+	seen := make(map[string]bool, len(rows))
+	items := make([]listsdom.Item, 0, len(rows))
+	for _, rr := range rows {
+		if !seen[rr.Spot] {
+			seen[rr.Spot] = true
+			items = append(items, listsdom.Item{Spot: rr.Spot, Futures: rr.Futures})
+		}
 	}
-
-	if err := tx.Commit(); err != nil { return 0, err }
-	return len(uniq), nil
+	return r.ReplaceByListID(ctx, listID, items)
 }
 
+// GetRowsBySlug — строки текущего (или закреплённого в ctx) поколения.
 func (r *ListsRepo) GetRowsBySlug(ctx context.Context, slug string) ([]listsdom.Row, error) {
-	return nil, nil
+	return (&ListsQueryRepo{db: r.db}).GetRowsBySlug(ctx, slug)
 }
 
+// itemsSince — created_at элементов списков ids в поколении gen: list_id → spot_symbol → created_at.
+func itemsSince(ctx context.Context, tx *sql.Tx, gen int64, ids []int64) (map[int16]map[string]*time.Time, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT li.list_id, li.spot_symbol, li.created_at FROM list_items li
+		JOIN list_generation_lists gl ON gl.list_id = li.list_id AND gl.items_gen = li.generation
+		WHERE gl.generation = $1 AND gl.list_id = ANY($2)`, gen, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("select list_items since: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[int16]map[string]*time.Time{}
+	for rows.Next() {
+		var id int16
+		var spot string
+		var at time.Time
+		if err := rows.Scan(&id, &spot, &at); err != nil {
+			return nil, err
+		}
+		if out[id] == nil {
+			out[id] = map[string]*time.Time{}
+		}
+		out[id][spot] = &at
+	}
+	return out, rows.Err()
+}
 
+// CurrentItems — все непустые списки текущего поколения.
+func (r *ListsRepo) CurrentItems(ctx context.Context) (map[int16][]listsdom.Item, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT li.list_id, li.spot_symbol, li.futures_symbol FROM list_items li
+		WHERE `+genCond(1), nil)
+	if err != nil {
+		return nil, fmt.Errorf("select current list_items: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[int16][]listsdom.Item{}
+	for rows.Next() {
+		var id int16
+		var it listsdom.Item
+		if err := rows.Scan(&id, &it.Spot, &it.Futures); err != nil {
+			return nil, err
+		}
+		out[id] = append(out[id], it)
+	}
+	return out, rows.Err()
+}
+
+var (
+	_ listsdom.Repo          = (*ListsRepo)(nil)
+	_ listsdom.CurrentReader = (*ListsRepo)(nil)
+)
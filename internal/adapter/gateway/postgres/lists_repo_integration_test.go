@@ -3,6 +3,7 @@ package postgres_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"os"
 	"reflect"
 	"testing"
@@ -88,6 +89,49 @@ func TestListsRepo_ReplaceBySlug_NotFound(t *testing.T) {
 	}
 }
 
+// Публикация одного списка пишет строки только его: остальные списки поколение берёт ссылкой на их версию.
+func TestListsRepo_Publish_CopyOnWrite(t *testing.T) {
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN not set; integration test skipped")
+	}
+	db, err := store.OpenPostgres(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	repo := pg.NewListsRepo(db)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := repo.ReplaceBySlug(ctx, "okx_to_upbit", []listsdom.Item{{Spot: "AAA-USDT"}, {Spot: "BBB-USDT"}}); err != nil {
+		t.Fatal(err)
+	}
+	count := func() (n int) {
+		if err := db.QueryRow(`SELECT COUNT(*) FROM list_items`).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+	before := count()
+	if _, err := repo.ReplaceBySlug(ctx, "okx_to_bithumb", []listsdom.Item{{Spot: "ZZZ-USDT"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := count() - before; got != 1 {
+		t.Fatalf("new list_items rows = %d, want 1", got)
+	}
+	// неизменившийся список виден в новом поколении и через запросы
+	want := []row{{"AAA-USDT", nil}, {"BBB-USDT", nil}}
+	if got := selectBySlug(t, db, "okx_to_upbit"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("carried list: %v", got)
+	}
+	rows, err := pg.NewListsQueryRepo(db).GetRowsBySlug(ctx, "okx_to_upbit")
+	if err != nil || len(rows) != 2 {
+		t.Fatalf("GetRowsBySlug = %v, %v", rows, err)
+	}
+}
+
 type row struct {
 	Spot string
 	Fut  *string
@@ -99,7 +143,8 @@ func selectBySlug(t *testing.T, db *sql.DB, slug string) []row {
 SELECT li.spot_symbol, li.futures_symbol
 FROM list_items li
 JOIN list_defs ld ON ld.id = li.list_id
-WHERE ld.slug = $1
+JOIN list_generation_lists gl ON gl.list_id = li.list_id AND gl.items_gen = li.generation
+WHERE ld.slug = $1 AND gl.generation = (SELECT generation FROM list_state)
 ORDER BY li.spot_symbol`
 	rs, err := db.Query(q, slug)
 	if err != nil {
@@ -122,3 +167,99 @@ ORDER BY li.spot_symbol`
 }
 
 func strPtr(s string) *string { return &s }
+
+func TestListsQueryRepo_FindRowsBySlug_Pagination(t *testing.T) {
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN not set; integration test skipped")
+	}
+	db, err := store.OpenPostgres(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := pg.NewListsRepo(db).ReplaceBySlug(ctx, "okx_to_bithumb", []listsdom.Item{
+		{Spot: "AAA-USDT", Futures: strPtr("AAA-USDT-SWAP")},
+		{Spot: "BBB-USDT"},
+		{Spot: "CCC-USDT"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	q := pg.NewListsQueryRepo(db)
+
+	p1, err := q.FindRowsBySlug(ctx, "okx_to_bithumb", listsdom.RowsFilter{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p1.Total != 3 || len(p1.Rows) != 2 || p1.NextCursor == "" {
+		t.Fatalf("page1: total=%d rows=%d cursor=%q", p1.Total, len(p1.Rows), p1.NextCursor)
+	}
+	p2, err := q.FindRowsBySlug(ctx, "okx_to_bithumb", listsdom.RowsFilter{Limit: 2, Cursor: p1.NextCursor})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p2.Total != 3 || len(p2.Rows) != 1 || p2.Rows[0].Spot != "CCC-USDT" || p2.NextCursor != "" {
+		t.Fatalf("page2: %+v", p2)
+	}
+
+	yes := true
+	fut, err := q.FindRowsBySlug(ctx, "okx_to_bithumb", listsdom.RowsFilter{HasFutures: &yes, Q: "aaa"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fut.Total != 1 || len(fut.Rows) != 1 || fut.Rows[0].Spot != "AAA-USDT" {
+		t.Fatalf("has_futures+q: %+v", fut)
+	}
+
+	if _, err := q.FindRowsBySlug(ctx, "okx_to_bithumb", listsdom.RowsFilter{Cursor: "!!"}); !errors.Is(err, listsdom.ErrBadCursor) {
+		t.Fatalf("want ErrBadCursor, got %v", err)
+	}
+}
+
+// Публикация другим процессом (tickerctl) доходит до подписчиков API через NOTIFY, и ровно один раз.
+func TestListsRepo_Listen_OtherProcess(t *testing.T) {
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN not set; integration test skipped")
+	}
+	db, err := store.OpenPostgres(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	api, cli := pg.NewListsRepo(db), pg.NewListsRepo(db)
+	got := make(chan listsdom.Generation, 4)
+	api.OnPublish(func(g listsdom.Generation) { got <- g })
+	if err := api.Listen(ctx, dsn); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cli.ReplaceBySlug(ctx, "okx_to_bithumb", []listsdom.Item{{Spot: "AAA-USDT"}}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case g := <-got:
+		if g.ID == 0 || g.CreatedAt.IsZero() {
+			t.Fatalf("generation = %+v", g)
+		}
+	case <-ctx.Done():
+		t.Fatal("no notification from other process")
+	}
+
+	// своя публикация: после коммита и через NOTIFY — подписчик видит её один раз
+	if _, err := api.ReplaceBySlug(ctx, "okx_to_bithumb", []listsdom.Item{{Spot: "BBB-USDT"}}); err != nil {
+		t.Fatal(err)
+	}
+	<-got
+	select {
+	case g := <-got:
+		t.Fatalf("duplicate dispatch: %+v", g)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
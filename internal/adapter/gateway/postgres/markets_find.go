@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+const (
+	defaultMarketsLimit = 100
+	maxMarketsLimit     = 1000
+)
+
+// выражение и SQL-тип ключа сортировки (для keyset-сравнения со значением из курсора)
+var marketSorts = map[markets.Sort]struct{ expr, typ string }{
+	markets.SortSymbol:     {"m.symbol", "text"},
+	markets.SortBase:       {"m.base_asset", "text"},
+	markets.SortListedAt:   {"m.listed_at", "timestamptz"},
+	markets.SortDelistedAt: {"COALESCE(m.delisted_at, 'infinity'::timestamptz)", "timestamptz"},
+	markets.SortID:         {"m.id", "bigint"},
+}
+
+// marketsCursor — позиция последней отданной строки; сортировка зашита, чтобы курсор
+// нельзя было применить к выдаче с другим порядком.
+type marketsCursor struct {
+	Sort  markets.Sort `json:"s"`
+	Desc  bool         `json:"d,omitempty"`
+	Value string       `json:"v"`
+	ID    int64        `json:"id"`
+}
+
+func (c marketsCursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeMarketsCursor(s string) (marketsCursor, error) {
+	var c marketsCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, markets.ErrBadCursor
+	}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return c, markets.ErrBadCursor
+	}
+	return c, nil
+}
+
+func (r *MarketsRepo) FindMarkets(ctx context.Context, f markets.Filter) (markets.Page, error) {
+	if f.Sort == "" {
+		f.Sort = markets.SortSymbol
+	}
+	sortCol, ok := marketSorts[f.Sort]
+	if !ok {
+		return markets.Page{}, markets.ErrBadSort
+	}
+	if f.Limit <= 0 {
+		f.Limit = defaultMarketsLimit
+	}
+	if f.Limit > maxMarketsLimit {
+		f.Limit = maxMarketsLimit
+	}
+
+	var where []string
+	var args []any
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(f.Exchanges) > 0 {
+		where = append(where, "e.slug = ANY("+arg(pq.Array(lowerAll(f.Exchanges)))+")")
+	}
+	if len(f.Bases) > 0 {
+		where = append(where, "m.base_asset = ANY("+arg(pq.Array(upperAll(f.Bases)))+")")
+	}
+	if len(f.Quotes) > 0 {
+		where = append(where, "m.quote_asset = ANY("+arg(pq.Array(upperAll(f.Quotes)))+")")
+	}
+	if f.Type != "" {
+		where = append(where, "m.mtype = "+arg(string(f.Type))+"::market_type")
+	}
+	if len(f.Contracts) > 0 {
+		ks := make([]string, 0, len(f.Contracts))
+		for _, k := range f.Contracts {
+			ks = append(ks, string(k))
+		}
+		where = append(where, "m.contract_kind = ANY("+arg(pq.Array(ks))+")")
+	}
+	if f.Active != nil {
+		where = append(where, "m.is_active = "+arg(*f.Active))
+	}
+	if f.ListedFrom != nil {
+		where = append(where, "m.listed_at >= "+arg(*f.ListedFrom))
+	}
+	if f.ListedTo != nil {
+		where = append(where, "m.listed_at < "+arg(*f.ListedTo))
+	}
+	if f.DelistedFrom != nil {
+		where = append(where, "m.delisted_at >= "+arg(*f.DelistedFrom))
+	}
+	if f.DelistedTo != nil {
+		where = append(where, "m.delisted_at < "+arg(*f.DelistedTo))
+	}
+
+	cmp, dir := ">", "ASC"
+	if f.Desc {
+		cmp, dir = "<", "DESC"
+	}
+	if f.Cursor != "" {
+		c, err := decodeMarketsCursor(f.Cursor)
+		if err != nil {
+			return markets.Page{}, err
+		}
+		if c.Sort != f.Sort || c.Desc != f.Desc {
+			return markets.Page{}, fmt.Errorf("%w: sort changed", markets.ErrBadCursor)
+		}
+		where = append(where, fmt.Sprintf("(%s, m.id) %s (%s::%s, %s)",
+			sortCol.expr, cmp, arg(c.Value), sortCol.typ, arg(c.ID)))
+	}
+
+	q := `
+		SELECT ` + marketCols + `, (` + sortCol.expr + `)::text
+		FROM markets m
+		JOIN exchanges e ON e.id = m.exchange_id`
+	if len(where) > 0 {
+		q += "\n\t\tWHERE " + strings.Join(where, "\n\t\t  AND ")
+	}
+	// +1 строка — узнать, есть ли следующая страница
+	q += fmt.Sprintf("\n\t\tORDER BY %s %s, m.id %s\n\t\tLIMIT %d", sortCol.expr, dir, dir, f.Limit+1)
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return markets.Page{}, fmt.Errorf("markets find: %w", err)
+	}
+	defer rows.Close()
+
+	var page markets.Page
+	var lastKey string
+	for rows.Next() {
+		var key string
+		m, err := scanMarket(rows, &key)
+		if err != nil {
+			return markets.Page{}, err
+		}
+		if len(page.Items) == f.Limit {
+			last := page.Items[len(page.Items)-1]
+			page.NextCursor = marketsCursor{Sort: f.Sort, Desc: f.Desc, Value: lastKey, ID: last.ID}.encode()
+			break
+		}
+		page.Items = append(page.Items, m)
+		lastKey = key
+	}
+	return page, rows.Err()
+}
+
+func upperAll(ss []string) []string {
+	out := make([]string, len(ss))
+	for i, s := range ss {
+		out[i] = strings.ToUpper(strings.TrimSpace(s))
+	}
+	return out
+}
+
+func lowerAll(ss []string) []string {
+	out := make([]string, len(ss))
+	for i, s := range ss {
+		out[i] = strings.ToLower(strings.TrimSpace(s))
+	}
+	return out
+}
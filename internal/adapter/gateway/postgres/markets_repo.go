@@ -5,19 +5,13 @@ import (
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/lib/pq"
 
 	"github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
 )
 
-type MarketRow struct {
-	ExchangeID int16
-	MType      string // 'spot' | 'futures'
-	Symbol     string
-	Base       string
-	Quote      string
-	IsActive   bool
-}
-
 type MarketsRepo struct {
 	db *sql.DB
 }
@@ -25,11 +19,11 @@ type MarketsRepo struct {
 func NewMarketsRepo(db *sql.DB) *MarketsRepo { return &MarketsRepo{db: db} }
 
 // SyncSnapshot atomically synchronizes a snapshot of markets for one exchange:
-// 1) loads items into staging (incoming_tickers)
+// 1) loads items into a transaction-scoped staging table (COPY)
 // 2) inserts new ones, updates changed ones/reactivates in markets
-// 3) archives missing ones (is_active=false, delisted_at=now())
+// 3) archives missing ones of the given types (is_active=false, delisted_at=now())
 // returns: added, updated, archived
-func (r *MarketsRepo) SyncSnapshot(ctx context.Context, exID int16, items []markets.Item) (added, updated, archived int, err error) {
+func (r *MarketsRepo) SyncSnapshot(ctx context.Context, exID int16, types []markets.Type, items []markets.Item) (added, updated, archived int, err error) {
 	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
 	if err != nil {
 		return 0, 0, 0, err
@@ -47,36 +41,9 @@ func (r *MarketsRepo) SyncSnapshot(ctx context.Context, exID int16, items []mark
 		return 0, 0, 0, fmt.Errorf("advisory lock: %w", err)
 	}
 
-	// 2) очистить staging этой биржи
-	if _, err = tx.ExecContext(ctx, `DELETE FROM incoming_tickers WHERE exchange_id = $1`, exID); err != nil {
-		return 0, 0, 0, fmt.Errorf("clear incoming: %w", err)
-	}
-
-	// 3) загрузить снапшот (upsert в staging)
-	insStaging := `
-		INSERT INTO incoming_tickers
-			(exchange_id, symbol, base_asset, quote_asset, is_futures, contract_size, project_tick)
-		VALUES ($1,$2,$3,$4,$5,$6,$7)
-		ON CONFLICT (exchange_id, symbol, is_futures) DO UPDATE SET
-			base_asset    = EXCLUDED.base_asset,
-			quote_asset   = EXCLUDED.quote_asset,
-			contract_size = EXCLUDED.contract_size,
-			project_tick  = EXCLUDED.project_tick
-	`
-	for _, it := range items {
-		isFut := it.Type == markets.TypeFutures
-		var cs any
-		if it.ContractSize != nil {
-			cs = *it.ContractSize
-		} else {
-			cs = nil
-		}
-		// project_tick — кладём базовый тикер проекта (у нас = Base)
-		if _, err = tx.ExecContext(ctx, insStaging,
-			exID, it.Symbol, it.Base, it.Quote, isFut, cs, it.Base,
-		); err != nil {
-			return 0, 0, 0, fmt.Errorf("insert staging: %w", err)
-		}
+	// 2) снапшот — во временную таблицу транзакции одним COPY
+	if err = stageSnapshot(ctx, tx, exID, items); err != nil {
+		return 0, 0, 0, err
 	}
 
 	// 4) обновить существующие в markets
@@ -86,13 +53,30 @@ func (r *MarketsRepo) SyncSnapshot(ctx context.Context, exID int16, items []mark
 		SET base_asset    = it.base_asset,
 		    quote_asset   = it.quote_asset,
 		    contract_size = it.contract_size,
+		    multiplier    = it.multiplier,
+		    tick_size     = it.tick_size,
+		    lot_size      = it.lot_size,
+		    min_qty       = it.min_qty,
+		    min_notional  = it.min_notional,
+		    max_leverage  = it.max_leverage,
+		    contract_kind = it.contract_kind,
+		    settle_asset  = it.settle_asset,
+		    expiry_at     = it.expiry_at,
 		    is_active     = TRUE,
 		    delisted_at   = NULL
-		FROM incoming_tickers it
+		FROM incoming_snapshot it
 		WHERE m.exchange_id = $1
 		  AND it.exchange_id = $1
 		  AND m.symbol = it.symbol
 		  AND m.mtype = CASE WHEN it.is_futures THEN 'futures'::market_type ELSE 'spot'::market_type END
+		  -- только реально изменившиеся: иначе updated > 0 на каждом тике и ChangedExchanges бесполезен
+		  AND (m.base_asset, m.quote_asset, m.contract_size, m.multiplier, m.tick_size, m.lot_size,
+		       m.min_qty, m.min_notional, m.max_leverage, m.contract_kind, m.settle_asset, m.expiry_at,
+		       m.is_active, m.delisted_at)
+		      IS DISTINCT FROM
+		      (it.base_asset, it.quote_asset, it.contract_size, it.multiplier, it.tick_size, it.lot_size,
+		       it.min_qty, it.min_notional, it.max_leverage, it.contract_kind, it.settle_asset, it.expiry_at,
+		       TRUE, NULL::timestamptz)
 		RETURNING 1
 	)
 	SELECT COUNT(*) FROM upd;
@@ -102,15 +86,55 @@ func (r *MarketsRepo) SyncSnapshot(ctx context.Context, exID int16, items []mark
 	}
 
 	// 5) вставить новые в markets
-	insSQL := `
+	if err = tx.QueryRowContext(ctx, insertNewSQL, exID).Scan(&added); err != nil {
+		return 0, 0, 0, fmt.Errorf("insert markets: %w", err)
+	}
+
+	// 6) архивировать отсутствующие — только тех типов, что скачались (сбой фьючерсов не снимает их с торгов)
+	archSQL := `
+	WITH arch AS (
+		UPDATE markets m
+		SET is_active = FALSE,
+		    delisted_at = now()
+		WHERE m.exchange_id = $1
+		  AND m.is_active = TRUE
+		  AND m.mtype::text = ANY($2::text[])
+		  AND NOT EXISTS (
+		    SELECT 1 FROM incoming_snapshot it
+		    WHERE it.exchange_id = $1
+		      AND it.symbol = m.symbol
+		      AND (CASE WHEN it.is_futures THEN 'futures'::market_type ELSE 'spot'::market_type END) = m.mtype
+		  )
+		RETURNING 1
+	)
+	SELECT COUNT(*) FROM arch;
+	`
+	mtypes := make([]string, len(types))
+	for i, t := range types {
+		mtypes[i] = string(t)
+	}
+	if err = tx.QueryRowContext(ctx, archSQL, exID, pq.Array(mtypes)).Scan(&archived); err != nil {
+		return 0, 0, 0, fmt.Errorf("archive markets: %w", err)
+	}
+
+	return added, updated, archived, nil
+}
+
+// insertNewSQL — рынки из incoming_snapshot, которых ещё нет в markets ($1 — биржа); отдаёт число вставленных.
+const insertNewSQL = `
 	WITH ins AS (
 		INSERT INTO markets
-			(exchange_id, mtype, symbol, base_asset, quote_asset, contract_size, is_active, listed_at, delisted_at)
+			(exchange_id, mtype, symbol, base_asset, quote_asset, contract_size, multiplier,
+			 tick_size, lot_size, min_qty, min_notional, max_leverage,
+			 contract_kind, settle_asset, expiry_at,
+			 is_active, listed_at, delisted_at)
 		SELECT  $1,
 		        CASE WHEN it.is_futures THEN 'futures'::market_type ELSE 'spot'::market_type END,
-		        it.symbol, it.base_asset, it.quote_asset, it.contract_size,
+		        it.symbol, it.base_asset, it.quote_asset, it.contract_size, it.multiplier,
+		        it.tick_size, it.lot_size, it.min_qty, it.min_notional, it.max_leverage,
+		        it.contract_kind, it.settle_asset, it.expiry_at,
 		        TRUE, now(), NULL
-		FROM incoming_tickers it
+		FROM incoming_snapshot it
 		WHERE it.exchange_id = $1
 		  AND NOT EXISTS (
 		    SELECT 1 FROM markets m
@@ -122,41 +146,134 @@ func (r *MarketsRepo) SyncSnapshot(ctx context.Context, exID int16, items []mark
 	)
 	SELECT COUNT(*) FROM ins;
 	`
-	if err = tx.QueryRowContext(ctx, insSQL, exID).Scan(&added); err != nil {
-		return 0, 0, 0, fmt.Errorf("insert markets: %w", err)
+
+// AddMarkets — вставка новых и возврат архивных рынков из частичного снимка (детектор листингов).
+// Существующие активные не трогаются (их спеки обновит полный синк), отсутствующие не архивируются.
+func (r *MarketsRepo) AddMarkets(ctx context.Context, exID int16, items []markets.Item) (added int, err error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelReadCommitted})
+	if err != nil {
+		return 0, err
 	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
 
-	// 6) архивировать отсутствующие
-	archSQL := `
-	WITH arch AS (
+	// та же сериализация по бирже, что и у SyncSnapshot
+	if _, err = tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, int64(exID)); err != nil {
+		return 0, fmt.Errorf("advisory lock: %w", err)
+	}
+	if err = stageSnapshot(ctx, tx, exID, items); err != nil {
+		return 0, err
+	}
+
+	var relisted int
+	if err = tx.QueryRowContext(ctx, `
+	WITH re AS (
 		UPDATE markets m
-		SET is_active = FALSE,
-		    delisted_at = now()
+		SET is_active = TRUE, delisted_at = NULL
+		FROM incoming_snapshot it
 		WHERE m.exchange_id = $1
-		  AND m.is_active = TRUE
-		  AND NOT EXISTS (
-		    SELECT 1 FROM incoming_tickers it
-		    WHERE it.exchange_id = $1
-		      AND it.symbol = m.symbol
-		      AND (CASE WHEN it.is_futures THEN 'futures'::market_type ELSE 'spot'::market_type END) = m.mtype
-		  )
+		  AND it.exchange_id = $1
+		  AND m.is_active = FALSE
+		  AND m.symbol = it.symbol
+		  AND m.mtype = CASE WHEN it.is_futures THEN 'futures'::market_type ELSE 'spot'::market_type END
 		RETURNING 1
 	)
-	SELECT COUNT(*) FROM arch;
-	`
-	if err = tx.QueryRowContext(ctx, archSQL, exID).Scan(&archived); err != nil {
-		return 0, 0, 0, fmt.Errorf("archive markets: %w", err)
+	SELECT COUNT(*) FROM re;
+	`, exID).Scan(&relisted); err != nil {
+		return 0, fmt.Errorf("relist markets: %w", err)
 	}
+	if err = tx.QueryRowContext(ctx, insertNewSQL, exID).Scan(&added); err != nil {
+		return 0, fmt.Errorf("insert markets: %w", err)
+	}
+	return added + relisted, nil
+}
 
-	return added, updated, archived, nil
+var _ markets.Appender = (*MarketsRepo)(nil)
+
+// stagingCols — колонки incoming_snapshot в порядке COPY.
+var stagingCols = []string{
+	"exchange_id", "symbol", "base_asset", "quote_asset", "is_futures", "contract_size", "project_tick", "multiplier",
+	"tick_size", "lot_size", "min_qty", "min_notional", "max_leverage",
+	"contract_kind", "settle_asset", "expiry_at",
+}
+
+// stageSnapshot создаёт incoming_snapshot (структура incoming_tickers, живёт до конца транзакции)
+// и заливает items через COPY — один поток вместо INSERT на каждый инструмент.
+// Дубли (symbol, spot/futures) схлопываются, побеждает последний — как раньше при ON CONFLICT DO UPDATE.
+func stageSnapshot(ctx context.Context, tx *sql.Tx, exID int16, items []markets.Item) error {
+	if _, err := tx.ExecContext(ctx, `CREATE TEMP TABLE incoming_snapshot (LIKE incoming_tickers) ON COMMIT DROP`); err != nil {
+		return fmt.Errorf("create staging: %w", err)
+	}
+
+	type key struct {
+		symbol string
+		fut    bool
+	}
+	last := make(map[key]int, len(items))
+	for i, it := range items {
+		last[key{it.Symbol, it.Type == markets.TypeFutures}] = i
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("incoming_snapshot", stagingCols...))
+	if err != nil {
+		return fmt.Errorf("copy staging: %w", err)
+	}
+	defer stmt.Close()
+	for i, it := range items {
+		isFut := it.Type == markets.TypeFutures
+		if last[key{it.Symbol, isFut}] != i {
+			continue
+		}
+		// project_tick — базовый тикер проекта (для 1000PEPE → PEPE)
+		if _, err := stmt.ExecContext(ctx,
+			exID, it.Symbol, it.Base, it.Quote, isFut, dec(it.ContractSize), it.Underlying(), it.Mult(),
+			dec(it.TickSize), dec(it.LotSize), dec(it.MinQty), dec(it.MinNotional), dec(it.MaxLeverage),
+			nullStr(string(it.Kind())), nullStr(it.Settle), it.Expiry,
+		); err != nil {
+			return fmt.Errorf("copy staging: %w", err)
+		}
+	}
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		return fmt.Errorf("copy staging: %w", err)
+	}
+	// у временных таблиц нет автоанализа — без статистики планировщик уходит в nested loop по markets
+	if _, err := tx.ExecContext(ctx, `ANALYZE incoming_snapshot`); err != nil {
+		return fmt.Errorf("analyze staging: %w", err)
+	}
+	return nil
 }
 
 func (r *MarketsRepo) LoadActiveByExchange(ctx context.Context, exchangeID int16) ([]markets.Item, error) {
+	return r.loadActive(ctx, `AND exchange_id = $1`, exchangeID)
+}
+
+// LoadActiveByExchanges — активные рынки нескольких бирж одним запросом (один снимок для всей пересборки).
+func (r *MarketsRepo) LoadActiveByExchanges(ctx context.Context, exIDs ...int16) (map[int16][]markets.Item, error) {
+	ids := make([]int64, len(exIDs))
+	for i, id := range exIDs {
+		ids[i] = int64(id)
+	}
+	items, err := r.loadActive(ctx, `AND exchange_id = ANY($1)`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	out := map[int16][]markets.Item{}
+	for _, it := range items {
+		out[it.ExchangeID] = append(out[it.ExchangeID], it)
+	}
+	return out, nil
+}
+
+func (r *MarketsRepo) loadActive(ctx context.Context, where string, args ...any) ([]markets.Item, error) {
 	rows, err := r.db.QueryContext(ctx, `
-		SELECT exchange_id, mtype, symbol, base_asset, quote_asset, contract_size, is_active
+		SELECT exchange_id, mtype, symbol, base_asset, quote_asset, is_active, multiplier, `+specCols+`, `+contractCols+`
 		FROM markets
-		WHERE exchange_id = $1 AND is_active = TRUE
-		`, exchangeID)
+		WHERE is_active = TRUE `+where, args...)
 	if err != nil { return nil, err }
 	defer rows.Close()
 
@@ -164,11 +281,12 @@ func (r *MarketsRepo) LoadActiveByExchange(ctx context.Context, exchangeID int16
 	for rows.Next() {
 		var it markets.Item
 		var mtype string
-		var csz *int64
-		if err := rows.Scan(&it.ExchangeID, &mtype, &it.Symbol, &it.Base, &it.Quote, &csz, &it.Active); err != nil {
+		dst := append([]any{&it.ExchangeID, &mtype, &it.Symbol, &it.Base, &it.Quote, &it.Active, &it.Multiplier},
+			specDest(&it.Specs)...)
+		dst = append(dst, contractDest(&it)...)
+		if err := rows.Scan(dst...); err != nil {
 			return nil, err
 		}
-		if csz != nil { it.ContractSize = csz }
 		switch mtype {
 		case "spot": it.Type = markets.TypeSpot
 		case "futures": it.Type = markets.TypeFutures
@@ -179,36 +297,144 @@ func (r *MarketsRepo) LoadActiveByExchange(ctx context.Context, exchangeID int16
 	return out, rows.Err()
 }
 
-func (r *MarketsRepo) ListActiveByExchanges(ctx context.Context, exIDs ...int16) ([]MarketRow, error) {
-	if len(exIDs) == 0 {
-		return nil, nil
+var _ markets.SnapshotLoader = (*MarketsRepo)(nil)
+
+// SaveVolumes — последний 24h-оборот спота в USD одним UPDATE по массивам symbol/volume.
+func (r *MarketsRepo) SaveVolumes(ctx context.Context, exID int16, vols map[string]float64, at time.Time) (int, error) {
+	if len(vols) == 0 {
+		return 0, nil
 	}
-	ph := make([]string, len(exIDs))
-	args := make([]any, len(exIDs))
-	for i, id := range exIDs {
-		ph[i] = fmt.Sprintf("$%d", i+1)
-		args[i] = id
+	syms := make([]string, 0, len(vols))
+	vals := make([]float64, 0, len(vols))
+	for s, v := range vols {
+		syms = append(syms, s)
+		vals = append(vals, v)
 	}
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE markets m
+		SET volume_usd_24h = v.vol, volume_at = $4
+		FROM unnest($2::text[], $3::float8[]) AS v(symbol, vol)
+		WHERE m.exchange_id = $1 AND m.mtype = 'spot' AND m.symbol = v.symbol`,
+		exID, pq.Array(syms), pq.Array(vals), at)
+	if err != nil {
+		return 0, fmt.Errorf("save volumes: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return int(n), nil
+}
 
-	q := `
-		SELECT exchange_id, mtype, symbol, base_asset, quote_asset, is_active
-		FROM markets
-		WHERE is_active = TRUE
-		  AND exchange_id IN (` + strings.Join(ph, ",") + `)
-	`
-	rows, err := r.db.QueryContext(ctx, q, args...)
+var _ markets.VolumeRepo = (*MarketsRepo)(nil)
+
+func (r *MarketsRepo) GetMarkets(ctx context.Context, exchange, symbol string) ([]markets.Market, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT `+marketCols+`
+		FROM markets m
+		JOIN exchanges e ON e.id = m.exchange_id
+		WHERE e.slug = $1 AND m.symbol = $2
+		ORDER BY m.mtype`,
+		strings.ToLower(strings.TrimSpace(exchange)), strings.ToUpper(strings.TrimSpace(symbol)))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("markets get: %w", err)
 	}
 	defer rows.Close()
 
-	var out []MarketRow
+	var out []markets.Market
 	for rows.Next() {
-		var m MarketRow
-		if err := rows.Scan(&m.ExchangeID, &m.MType, &m.Symbol, &m.Base, &m.Quote, &m.IsActive); err != nil {
+		m, err := scanMarket(rows)
+		if err != nil {
 			return nil, err
 		}
 		out = append(out, m)
 	}
 	return out, rows.Err()
-}
\ No newline at end of file
+}
+
+// marketCols — колонки для scanMarket (markets m JOIN exchanges e).
+var marketCols = `m.id, m.exchange_id, e.slug, m.mtype, m.symbol, m.base_asset, m.quote_asset, m.is_active, m.multiplier,
+		       m.listed_at, m.delisted_at, ` + prefixed("m.", specCols) + `, ` + prefixed("m.", contractCols)
+
+func scanMarket(rows *sql.Rows, extra ...any) (markets.Market, error) {
+	var m markets.Market
+	var mtype string
+	dst := append([]any{&m.ID, &m.ExchangeID, &m.Exchange, &mtype, &m.Symbol, &m.Base, &m.Quote, &m.Active, &m.Multiplier,
+		&m.ListedAt, &m.DelistedAt}, specDest(&m.Specs)...)
+	dst = append(dst, contractDest(&m.Item)...)
+	dst = append(dst, extra...)
+	if err := rows.Scan(dst...); err != nil {
+		return markets.Market{}, err
+	}
+	m.Type = markets.Type(mtype)
+	return m, nil
+}
+
+// prefixed("m.", "a, b") → "m.a, m.b"
+func prefixed(alias, cols string) string {
+	parts := strings.Split(cols, ",")
+	for i, p := range parts {
+		parts[i] = alias + strings.TrimSpace(p)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// specCols — NUMERIC-колонки Specs; ::text, чтобы не ходить через float64.
+const specCols = `contract_size::text, tick_size::text, lot_size::text, min_qty::text, min_notional::text, max_leverage::text`
+
+func specDest(sp *markets.Specs) []any {
+	return []any{
+		(*decCol)(&sp.ContractSize), (*decCol)(&sp.TickSize), (*decCol)(&sp.LotSize),
+		(*decCol)(&sp.MinQty), (*decCol)(&sp.MinNotional), (*decCol)(&sp.MaxLeverage),
+	}
+}
+
+// decCol сканирует NULL-able NUMERIC::text в markets.Decimal (NULL → "").
+type decCol markets.Decimal
+
+func (d *decCol) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*d = ""
+	case []byte:
+		*d = decCol(markets.ParseDecimal(string(v)))
+	case string:
+		*d = decCol(markets.ParseDecimal(v))
+	default:
+		return fmt.Errorf("decimal: unexpected %T", src)
+	}
+	return nil
+}
+
+// contractCols — вид фьючерса, валюта расчёта и экспирация (у спота NULL).
+const contractCols = `contract_kind, settle_asset, expiry_at`
+
+func contractDest(it *markets.Item) []any {
+	return []any{(*strCol)(&it.Contract), (*strCol)(&it.Settle), &it.Expiry}
+}
+
+// strCol сканирует NULL-able TEXT в строку (NULL → "").
+type strCol string
+
+func (s *strCol) Scan(src any) error {
+	var ns sql.NullString
+	if err := ns.Scan(src); err != nil {
+		return err
+	}
+	*s = strCol(ns.String)
+	return nil
+}
+
+func nullStr(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// dec: "" → NULL, иначе строка (Postgres сам приведёт к NUMERIC).
+func dec(d markets.Decimal) any {
+	if d == "" {
+		return nil
+	}
+	return string(d)
+}
+
+var _ markets.QueryRepo = (*MarketsRepo)(nil)
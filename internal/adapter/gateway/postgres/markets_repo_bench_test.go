@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	"github.com/berezovskyivalerii/tickersvc/internal/infra/store"
+)
+
+// stageRowByRow — прежняя загрузка staging: INSERT ... ON CONFLICT на каждый инструмент.
+func stageRowByRow(ctx context.Context, tx *sql.Tx, exID int16, items []markets.Item) error {
+	if _, err := tx.ExecContext(ctx, `CREATE TEMP TABLE incoming_snapshot (LIKE incoming_tickers INCLUDING INDEXES) ON COMMIT DROP`); err != nil {
+		return err
+	}
+	for _, it := range items {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO incoming_snapshot
+				(exchange_id, symbol, base_asset, quote_asset, is_futures, contract_size, project_tick, multiplier,
+				 tick_size, lot_size, min_qty, min_notional, max_leverage,
+				 contract_kind, settle_asset, expiry_at)
+			VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16)
+			ON CONFLICT (exchange_id, symbol, is_futures) DO UPDATE SET base_asset = EXCLUDED.base_asset`,
+			exID, it.Symbol, it.Base, it.Quote, it.Type == markets.TypeFutures, dec(it.ContractSize), it.Underlying(), it.Mult(),
+			dec(it.TickSize), dec(it.LotSize), dec(it.MinQty), dec(it.MinNotional), dec(it.MaxLeverage),
+			nullStr(string(it.Kind())), nullStr(it.Settle), it.Expiry,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Размер — как у Binance spot + futures. Транзакция откатывается, markets не трогаются.
+//
+//	DB_DSN=... go test ./internal/adapter/gateway/postgres -run '^$' -bench Staging
+func BenchmarkStaging(b *testing.B) {
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		b.Skip("DB_DSN not set; integration benchmark skipped")
+	}
+	db, err := store.OpenPostgres(dsn)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer db.Close()
+
+	const exID = int16(1)
+	var items []markets.Item
+	for i := 0; i < 3000; i++ {
+		base := fmt.Sprintf("B%d", i)
+		items = append(items, markets.Item{ExchangeID: exID, Type: markets.TypeSpot, Symbol: base + "USDT", Base: base, Quote: "USDT", Active: true,
+			Specs: markets.Specs{TickSize: "0.0001", LotSize: "0.1", MinNotional: "5"}})
+		if i%5 < 2 {
+			items = append(items, markets.Item{ExchangeID: exID, Type: markets.TypeFutures, Symbol: base + "USDT", Base: base, Quote: "USDT", Active: true,
+				Contract: markets.ContractLinearPerp, Settle: "USDT"})
+		}
+	}
+
+	for _, bc := range []struct {
+		name  string
+		stage func(context.Context, *sql.Tx, int16, []markets.Item) error
+	}{
+		{"insert-per-row", stageRowByRow},
+		{"copy", stageSnapshot},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			ctx := context.Background()
+			for i := 0; i < b.N; i++ {
+				tx, err := db.BeginTx(ctx, nil)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if err := bc.stage(ctx, tx, exID, items); err != nil {
+					_ = tx.Rollback()
+					b.Fatal(err)
+				}
+				_ = tx.Rollback()
+			}
+			b.ReportMetric(float64(len(items)), "rows/op")
+		})
+	}
+}
@@ -2,6 +2,7 @@ package postgres_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
@@ -32,17 +33,110 @@ func TestMarketsRepo_SyncSnapshot(t *testing.T) {
         {ExchangeID: exID, Type: dm.TypeFutures, Symbol: fmt.Sprintf("T%v-USDT-SWAP", suf), Base: fmt.Sprintf("T%v", suf), Quote: "USDT", Active: true},
     }
 
-    added, updated, _, err := repo.SyncSnapshot(context.Background(), exID, items)
+    added, updated, _, err := repo.SyncSnapshot(context.Background(), exID, []dm.Type{dm.TypeSpot, dm.TypeFutures}, items)
     if err != nil { t.Fatal(err) }
     if added == 0 && updated == 0 {
         t.Fatalf("expected insert/update > 0, got a=%d u=%d", added, updated)
     }
 
-    // второй прогон — должны быть 0 добавлено, возможно 0 обновлено, 0 архивов
-    added2, updated2, archived2, err := repo.SyncSnapshot(context.Background(), exID, items)
+    // второй прогон того же снимка — ничего не изменилось: 0 добавлено, 0 обновлено, 0 архивов
+    added2, updated2, archived2, err := repo.SyncSnapshot(context.Background(), exID, []dm.Type{dm.TypeSpot, dm.TypeFutures}, items)
     if err != nil { t.Fatal(err) }
-    if added2 != 0 || archived2 != 0 {
+    if added2 != 0 || updated2 != 0 || archived2 != 0 {
         t.Fatalf("idempotency fail: a2=%d u2=%d d2=%d", added2, updated2, archived2)
     }
+
+    // фьючерсы не скачались: снимок только спота их не архивирует
+    _, _, archived3, err := repo.SyncSnapshot(context.Background(), exID, []dm.Type{dm.TypeSpot}, items[:1])
+    if err != nil { t.Fatal(err) }
+    if archived3 != 0 {
+        t.Fatalf("futures archived without a futures snapshot: d3=%d", archived3)
+    }
 }
 
+
+func TestMarketsRepo_FindMarkets_Pagination(t *testing.T) {
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN not set; integration test skipped")
+	}
+	db, err := store.OpenPostgres(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	repo := postgres.NewMarketsRepo(db)
+	ctx := context.Background()
+
+	// своя база на бирже без фетчеров в тестах (bithumb), три котировки
+	base := fmt.Sprintf("P%v", time.Now().UnixNano())
+	exID := int16(6)
+	var items []dm.Item
+	for _, q := range []string{"KRW", "USDT", "BTC"} {
+		items = append(items, dm.Item{ExchangeID: exID, Type: dm.TypeSpot, Symbol: base + "-" + q, Base: base, Quote: q, Active: true})
+	}
+	if _, _, _, err := repo.SyncSnapshot(ctx, exID, []dm.Type{dm.TypeSpot}, items); err != nil {
+		t.Fatal(err)
+	}
+
+	f := dm.Filter{Exchanges: []string{"bithumb"}, Bases: []string{base}, Limit: 2}
+	p1, err := repo.FindMarkets(ctx, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p1.Items) != 2 || p1.NextCursor == "" || p1.Items[0].Symbol != base+"-BTC" {
+		t.Fatalf("page1: %+v", p1)
+	}
+	f.Cursor = p1.NextCursor
+	p2, err := repo.FindMarkets(ctx, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p2.Items) != 1 || p2.NextCursor != "" || p2.Items[0].Symbol != base+"-USDT" {
+		t.Fatalf("page2: %+v", p2)
+	}
+
+	// курсор от другой сортировки не принимается
+	f.Sort = dm.SortListedAt
+	if _, err := repo.FindMarkets(ctx, f); !errors.Is(err, dm.ErrBadCursor) {
+		t.Fatalf("want ErrBadCursor, got %v", err)
+	}
+}
+
+func TestMarketsRepo_SaveVolumes(t *testing.T) {
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		t.Skip("DB_DSN not set; integration test skipped")
+	}
+	db, err := store.OpenPostgres(dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	repo := postgres.NewMarketsRepo(db)
+	ctx := context.Background()
+	exID := int16(3)
+	sym := fmt.Sprintf("V%v-USDT", time.Now().UnixNano())
+	if _, _, _, err := repo.SyncSnapshot(ctx, exID, []dm.Type{dm.TypeSpot}, []dm.Item{
+		{ExchangeID: exID, Type: dm.TypeSpot, Symbol: sym, Base: sym[:len(sym)-5], Quote: "USDT", Active: true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := repo.SaveVolumes(ctx, exID, map[string]float64{sym: 1234.5, "NOPE-USDT": 1}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("updated=%d want=1", n)
+	}
+	var vol float64
+	if err := db.QueryRowContext(ctx, `SELECT volume_usd_24h FROM markets WHERE exchange_id = $1 AND symbol = $2`, exID, sym).Scan(&vol); err != nil {
+		t.Fatal(err)
+	}
+	if vol != 1234.5 {
+		t.Fatalf("volume=%v", vol)
+	}
+}
@@ -0,0 +1,122 @@
+package listsfmt
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format — представление списка в ответе.
+type Format string
+
+const (
+	FormatJSON     Format = "json"      // исторический JSON конкретной ручки
+	FormatJSONMeta Format = "json-meta" // JSON + метаданные списка
+	FormatText     Format = "text"      // "SPOT, FUTURES" построчно
+	FormatCSV      Format = "csv"
+	FormatTSV      Format = "tsv"
+	FormatNDJSON   Format = "ndjson"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported format")
+
+// медиа-типы, которые понимаем в Accept
+var mediaTypes = map[string]Format{
+	"application/json":                    FormatJSON,
+	"application/vnd.tickersvc.meta+json": FormatJSONMeta,
+	"text/plain":                          FormatText,
+	"text/csv":                            FormatCSV,
+	"text/tab-separated-values":           FormatTSV,
+	"application/x-ndjson":                FormatNDJSON,
+	"application/ndjson":                  FormatNDJSON,
+}
+
+// ParseFormat разбирает значение ?format=.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "json":
+		return FormatJSON, nil
+	case "json-meta", "json_meta", "meta":
+		return FormatJSONMeta, nil
+	case "text", "txt":
+		return FormatText, nil
+	case "csv":
+		return FormatCSV, nil
+	case "tsv":
+		return FormatTSV, nil
+	case "ndjson", "jsonl":
+		return FormatNDJSON, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// Negotiate выбирает формат: ?format= → as_text=1 → Accept → JSON.
+// Ошибка возвращается только для явно заданного, но неизвестного ?format=.
+func Negotiate(format string, asText bool, accept string) (Format, error) {
+	if strings.TrimSpace(format) != "" {
+		return ParseFormat(format)
+	}
+	if asText {
+		return FormatText, nil
+	}
+	if f, ok := fromAccept(accept); ok {
+		return f, nil
+	}
+	return FormatJSON, nil
+}
+
+// ContentType — значение заголовка Content-Type для формата.
+func ContentType(f Format) string {
+	switch f {
+	case FormatText:
+		return "text/plain; charset=utf-8"
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatTSV:
+		return "text/tab-separated-values; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatJSONMeta:
+		return "application/vnd.tickersvc.meta+json"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// fromAccept — первый поддерживаемый тип с наибольшим q.
+func fromAccept(accept string) (Format, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return "", false
+	}
+	type cand struct {
+		f Format
+		q float64
+	}
+	var cs []cand
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		mt := strings.ToLower(strings.TrimSpace(fields[0]))
+		f, ok := mediaTypes[mt]
+		if !ok {
+			continue
+		}
+		q := 1.0
+		for _, p := range fields[1:] {
+			p = strings.TrimSpace(p)
+			if v, found := strings.CutPrefix(p, "q="); found {
+				if x, err := strconv.ParseFloat(v, 64); err == nil {
+					q = x
+				}
+			}
+		}
+		if q > 0 {
+			cs = append(cs, cand{f, q})
+		}
+	}
+	if len(cs) == 0 {
+		return "", false
+	}
+	sort.SliceStable(cs, func(i, j int) bool { return cs[i].q > cs[j].q })
+	return cs[0].f, true
+}
@@ -0,0 +1,46 @@
+package listsfmt_test
+
+import (
+	"errors"
+	"testing"
+
+	listsfmt "github.com/berezovskyivalerii/tickersvc/internal/adapter/presenter/lists"
+)
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		name   string
+		format string
+		asText bool
+		accept string
+		want   listsfmt.Format
+	}{
+		{"default", "", false, "", listsfmt.FormatJSON},
+		{"any", "", false, "*/*", listsfmt.FormatJSON},
+		{"as_text", "", true, "", listsfmt.FormatText},
+		{"format wins over as_text", "csv", true, "", listsfmt.FormatCSV},
+		{"format wins over accept", "tsv", false, "text/csv", listsfmt.FormatTSV},
+		{"accept csv", "", false, "text/csv", listsfmt.FormatCSV},
+		{"accept ndjson", "", false, "application/x-ndjson", listsfmt.FormatNDJSON},
+		{"accept meta", "", false, "application/vnd.tickersvc.meta+json", listsfmt.FormatJSONMeta},
+		{"accept q-values", "", false, "text/csv;q=0.5, text/tab-separated-values", listsfmt.FormatTSV},
+		{"accept unknown", "", false, "text/html, image/png", listsfmt.FormatJSON},
+		{"format case", "NDJSON", false, "", listsfmt.FormatNDJSON},
+	}
+	for _, tc := range cases {
+		got, err := listsfmt.Negotiate(tc.format, tc.asText, tc.accept)
+		if err != nil {
+			t.Fatalf("%s: err=%v", tc.name, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s: got=%s want=%s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestNegotiate_UnknownFormat(t *testing.T) {
+	_, err := listsfmt.Negotiate("xml", false, "")
+	if !errors.Is(err, listsfmt.ErrUnsupportedFormat) {
+		t.Fatalf("want ErrUnsupportedFormat, got %v", err)
+	}
+}
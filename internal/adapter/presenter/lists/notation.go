@@ -0,0 +1,41 @@
+package listsfmt
+
+import (
+	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	"github.com/berezovskyivalerii/tickersvc/internal/pkg/symbols"
+)
+
+// Notate переписывает Spot/Futures строк в нужную нотацию (raw — без изменений).
+// Биржа берётся из Row.Source, base/quote — из JOIN с markets.
+func Notate(rows []listsdom.Row, n symbols.Notation) []listsdom.Row {
+	if n == symbols.NotationRaw || n == "" {
+		return rows
+	}
+	out := make([]listsdom.Row, len(rows))
+	for i, r := range rows {
+		r.Spot = symbols.Format(n, symbols.Instrument{
+			Exchange: r.Source,
+			Symbol:   r.Spot,
+			Base:     r.Base,
+			Quote:    r.Quote,
+		})
+		if r.Futures != nil && *r.Futures != "" {
+			in := symbols.Instrument{
+				Exchange: r.Source,
+				Symbol:   *r.Futures,
+				Base:     r.FuturesBase,
+				Quote:    r.FuturesQuote,
+				Perp:     r.FuturesKind != string(dm.ContractDelivery),
+				Settle:   r.FuturesSettle,
+			}
+			if r.FuturesExpiry != nil {
+				in.Expiry = *r.FuturesExpiry
+			}
+			f := symbols.Format(n, in)
+			r.Futures = &f
+		}
+		out[i] = r
+	}
+	return out
+}
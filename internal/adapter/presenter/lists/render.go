@@ -0,0 +1,185 @@
+package listsfmt
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+)
+
+// Record — одна строка списка в табличных форматах.
+type Record struct {
+	Source    string   `json:"source,omitempty"`
+	Spot      string   `json:"spot"`
+	Futures   string   `json:"futures"`              // "none", если фьючерса нет
+	VolumeUSD *float64 `json:"volume_usd,omitempty"` // 24h-оборот спота, если известен
+}
+
+// Meta — метаданные для FormatJSONMeta.
+type Meta struct {
+	Slug      string     `json:"slug,omitempty"`
+	Kind      string     `json:"kind,omitempty"`
+	Source    string     `json:"source,omitempty"`
+	Target    string     `json:"target,omitempty"`
+	Segment   string     `json:"segment,omitempty"`
+	Expr      string     `json:"expr,omitempty"`
+	Sources   []string   `json:"sources,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+	Count     int        `json:"count"`
+}
+
+// Doc — то, что рендерим: строки + (опционально) колонка source.
+type Doc struct {
+	Meta       Meta
+	Records    []Record
+	WithSource bool // /api/lists?target=… — строки от нескольких источников
+	WithVolume bool // csv/tsv: колонка volume_usd
+	Ordered    bool // строки уже упорядочены (?sort=volume) — text не сортирует
+}
+
+type metaResp struct {
+	Meta  Meta     `json:"meta"`
+	Items []Record `json:"items"`
+}
+
+// FromRows переводит доменные строки в Record ("none" для пустого фьючерса).
+func FromRows(source string, rows []listsdom.Row) []Record {
+	out := make([]Record, 0, len(rows))
+	for _, r := range rows {
+		fs := "none"
+		if r.Futures != nil && *r.Futures != "" {
+			fs = *r.Futures
+		}
+		out = append(out, Record{Source: source, Spot: r.Spot, Futures: fs, VolumeUSD: r.VolumeUSD})
+	}
+	return out
+}
+
+// FromMeta — доменные метаданные списка → Meta.
+func FromMeta(m listsdom.Meta) Meta {
+	out := Meta{
+		Slug:    m.Slug,
+		Kind:    m.Kind,
+		Source:  m.SourceSlug,
+		Target:  m.TargetSlug,
+		Segment: m.Segment,
+		Expr:    m.Expr,
+		Count:   m.Count,
+	}
+	if !m.UpdatedAt.IsZero() {
+		t := m.UpdatedAt.UTC()
+		out.UpdatedAt = &t
+	}
+	return out
+}
+
+// Render пишет документ в выбранном формате.
+// FormatJSON здесь не обслуживается — у каждой ручки свой исторический JSON.
+func Render(w io.Writer, f Format, d Doc) error {
+	switch f {
+	case FormatText:
+		return writeText(w, d)
+	case FormatCSV:
+		return writeCSV(w, d)
+	case FormatTSV:
+		return writeTSV(w, d)
+	case FormatNDJSON:
+		return writeNDJSON(w, d)
+	case FormatJSONMeta:
+		items := d.Records
+		if items == nil {
+			items = []Record{}
+		}
+		m := d.Meta
+		m.Count = len(items)
+		return json.NewEncoder(w).Encode(metaResp{Meta: m, Items: items})
+	}
+	return fmt.Errorf("%w: %s", ErrUnsupportedFormat, f)
+}
+
+// text: для одного списка строки сортируются, для target — порядок источников сохраняется
+func writeText(w io.Writer, d Doc) error {
+	lines := make([]string, 0, len(d.Records))
+	for _, r := range d.Records {
+		lines = append(lines, r.Spot+", "+r.Futures)
+	}
+	if !d.WithSource && !d.Ordered {
+		sort.Strings(lines)
+	}
+	bw := bufio.NewWriter(w)
+	if len(lines) == 0 && !d.WithSource {
+		// как и раньше: пустой список → одна пустая строка
+		_ = bw.WriteByte('\n')
+	}
+	for _, l := range lines {
+		_, _ = bw.WriteString(l)
+		_ = bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+// table — шапка и строки для csv/tsv
+func table(d Doc) [][]string {
+	header := []string{"spot", "futures"}
+	if d.WithSource {
+		header = []string{"source", "spot", "futures"}
+	}
+	if d.WithVolume {
+		header = append(header, "volume_usd")
+	}
+	out := make([][]string, 0, len(d.Records)+1)
+	out = append(out, header)
+	for _, r := range d.Records {
+		rec := []string{r.Spot, r.Futures}
+		if d.WithSource {
+			rec = []string{r.Source, r.Spot, r.Futures}
+		}
+		if d.WithVolume {
+			v := ""
+			if r.VolumeUSD != nil {
+				v = strconv.FormatFloat(*r.VolumeUSD, 'f', 2, 64)
+			}
+			rec = append(rec, v)
+		}
+		out = append(out, rec)
+	}
+	return out
+}
+
+func writeCSV(w io.Writer, d Doc) error {
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(table(d)); err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+// tsv без кавычек: тикеры не содержат ни табов, ни переводов строк
+func writeTSV(w io.Writer, d Doc) error {
+	bw := bufio.NewWriter(w)
+	for _, rec := range table(d) {
+		_, _ = bw.WriteString(strings.Join(rec, "\t"))
+		_ = bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+func writeNDJSON(w io.Writer, d Doc) error {
+	enc := json.NewEncoder(w)
+	for _, r := range d.Records {
+		if !d.WithSource {
+			r.Source = ""
+		}
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
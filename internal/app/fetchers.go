@@ -0,0 +1,43 @@
+package app
+
+import (
+	exbinance "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/binance"
+	exbithumb "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/bithumb"
+	exbybit "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/bybit"
+	excoin "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/coinbase"
+	exokx "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/okx"
+	exrobin "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/robinhood"
+	exupbit "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/upbit"
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	marketsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// Fetchers — клиенты активных бирж: без exchanges.exclude и без is_active=false в БД (active — ExchangesRepo.ActiveMap).
+// Опции HTTP берутся из config.Active, поэтому до вызова нужен config.SetActive.
+func Fetchers(cfg config.Config, active map[int16]bool) []marketsdom.Fetcher {
+	exclude := map[string]bool{}
+	for _, e := range cfg.Exchanges.Exclude {
+		exclude[e] = true
+	}
+
+	all := []marketsdom.Fetcher{
+		exbinance.New(),
+		exbybit.New(),
+		exokx.New(),
+		exupbit.New(),
+		excoin.New(),
+		exbithumb.New(),
+		exrobin.New(),
+	}
+	var out []marketsdom.Fetcher
+	for _, f := range all {
+		if exclude[f.Name()] {
+			continue
+		}
+		if on, ok := active[f.ExchangeID()]; ok && !on {
+			continue
+		}
+		out = append(out, f)
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package app
+
+import (
+	"fmt"
+
+	notifygw "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/notify"
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/events"
+	notifyuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/notify"
+)
+
+// buildNotifier — каналы из конфигурации (уже провалидированной); ошибка — только в шаблонах.
+func buildNotifier(nc config.NotifyConfig, enr notifyuc.Enricher) (*notifyuc.Notifier, error) {
+	n := &notifyuc.Notifier{
+		Enricher: enr,
+		Window:   nc.BatchWindow.D(),
+		DedupTTL: nc.DedupTTL.D(),
+	}
+	for i, c := range nc.Channels {
+		var ch notifyuc.Channel
+		switch c.Type {
+		case "slack":
+			ch = &notifygw.Slack{Label: c.Name, WebhookURL: c.WebhookURL}
+		case "telegram":
+			ch = &notifygw.Telegram{Label: c.Name, Token: c.BotToken, ChatID: c.ChatID}
+		case "smtp":
+			ch = &notifygw.SMTP{Label: c.Name, Addr: c.Addr, Username: c.Username, Password: c.Password, From: c.From, To: c.To}
+		default:
+			return nil, fmt.Errorf("notify.channels[%d]: unknown type %q", i, c.Type)
+		}
+		text, err := notifyuc.ParseTemplate(ch.Name(), c.Template, notifyuc.DefaultText)
+		if err != nil {
+			return nil, fmt.Errorf("notify.channels[%d].template: %w", i, err)
+		}
+		subject, err := notifyuc.ParseTemplate(ch.Name()+"-subject", c.Subject, notifyuc.DefaultSubject)
+		if err != nil {
+			return nil, fmt.Errorf("notify.channels[%d].subject: %w", i, err)
+		}
+		route := notifyuc.Route{Exchanges: c.Exchanges}
+		for _, k := range c.Kinds {
+			route.Kinds = append(route.Kinds, events.Kind(k))
+		}
+		n.Sinks = append(n.Sinks, notifyuc.Sink{Channel: ch, Route: route, Text: text, Subject: subject})
+	}
+	return n, nil
+}
@@ -0,0 +1,190 @@
+package app
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	httpctrl "github.com/berezovskyivalerii/tickersvc/internal/adapter/controller/http"
+	"github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/cache"
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	marketsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	httpinfra "github.com/berezovskyivalerii/tickersvc/internal/infra/http"
+	adminauth "github.com/berezovskyivalerii/tickersvc/internal/infra/http/mw/adminauth"
+	usehealth "github.com/berezovskyivalerii/tickersvc/internal/usecase/health"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+	marketsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/markets"
+)
+
+// MarketsSyncer — синк рынков всех бирж (marketsuc.Orchestrator).
+type MarketsSyncer interface {
+	RunAll(ctx context.Context) (map[int16][3]int, error)
+}
+
+// ListsBuilder — сборка списков (listsuc.Interactor): пересборка, explain, присутствие, выражения, превью.
+type ListsBuilder interface {
+	RebuildAll(ctx context.Context, spec listsuc.RebuildSpec) (listsuc.RebuildResult, error)
+	httpctrl.ListExplainer
+	httpctrl.PresenceBuilder
+	httpctrl.SetQuerier
+	httpctrl.ListPreviewer
+}
+
+// Routes — то, над чем работают HTTP-ручки. Build собирает это из Postgres и бирж;
+// NewRouter только вешает ручки и ничего не запускает (им же пользуются тесты pkg/client).
+type Routes struct {
+	APIKey  func() string // читается на каждый запрос
+	Health  *usehealth.ReadinessInteractor
+	Lists   cache.ListsSource
+	Cache   *cache.ListsQuery // nil — кэш выключен
+	Markets marketsdom.QueryRepo
+	Assets  httpctrl.AssetViewer
+	Aliases assets.AliasRepo
+	Config  httpctrl.ConfigSource
+	Sync    MarketsSyncer
+	Builder ListsBuilder
+}
+
+func NewRouter(rt Routes) *gin.Engine {
+	router := httpinfra.NewRouter()
+
+	// Все ручки защищены ADMIN_API_KEY
+	router.Use(adminauth.New(rt.APIKey).Handler())
+
+	// Swagger (тоже под ключ; docs.SwaggerInfo заполняет Build)
+	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	_ = router.SetTrustedProxies(nil)
+
+	health := httpctrl.NewHealthController(httpctrl.ReadinessRunner{UC: rt.Health})
+	health.Register(router)
+
+	// Публичные (под ключом) списки и сегменты
+	pub := httpctrl.NewPublicListsController(rt.Lists)
+	pub.Gens = rt.Lists  // ?generation= и X-Generation
+	pub.Register(router) // /api/lists/:slug, /api/lists?target=..., /api/segments/:source/:seg
+
+	// Почему монета в списке/сегменте или вне его
+	httpctrl.NewExplainController(rt.Builder).Register(router) // /api/lists/:slug/explain/:base
+
+	// Карточка рынка со спеками (tick/lot/min notional/contract size)
+	httpctrl.NewMarketsController(rt.Markets).Register(router) // /api/markets/:exchange/:symbol
+
+	// Карточка актива: где торгуется и в каких списках
+	httpctrl.NewAssetsController(rt.Assets).Register(router) // /api/assets/:base
+
+	// Матрица присутствия база × биржа (JSON/CSV)
+	httpctrl.NewPresenceController(rt.Builder).Register(router) // /api/presence
+
+	// Ad hoc выражения над множествами присутствия (как у сегментов)
+	httpctrl.NewQueryController(rt.Builder).Register(router) // /api/query?expr=
+
+	// Превью списка/сегмента без записи (+ diff с сохранённым)
+	httpctrl.NewPreviewController(rt.Builder).Register(router) // POST /api/preview
+
+	// POST /update — sync + пересборка списков/сегментов
+	router.POST("/update", func(c *gin.Context) {
+		summary, err := rt.Sync.RunAll(c.Request.Context())
+		if err != nil {
+			c.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+
+		mode := strings.ToLower(strings.TrimSpace(c.Query("mode")))
+		if mode == "" {
+			mode = "all"
+		}
+
+		// raw для сегментов (поддержка нескольких источников)
+		srcRaw := strings.TrimSpace(c.Query("source"))
+		tgtRaw := strings.TrimSpace(c.Query("target"))
+
+		// targets: берём первый source/target
+		var srcPtr, tgtPtr *string
+		if srcRaw != "" {
+			first := strings.TrimSpace(strings.Split(srcRaw, ",")[0])
+			if first != "" {
+				srcPtr = &first
+			}
+		}
+		if tgtRaw != "" {
+			first := strings.TrimSpace(strings.Split(tgtRaw, ",")[0])
+			if first != "" {
+				tgtPtr = &first
+			}
+		}
+
+		var segSources []string
+		for _, v := range strings.Split(srcRaw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				segSources = append(segSources, v)
+			}
+		}
+
+		// Сегменты (binance/bybit/okx) и target-списки (okx_to_upbit и т.п.) — одним поколением.
+		// Строятся только списки бирж, изменившихся при синке; force=1 — все.
+		spec := listsuc.RebuildSpec{
+			Targets:        mode == "targets" || mode == "all",
+			Source:         srcPtr,
+			Target:         tgtPtr,
+			Segments:       mode == "segments" || mode == "all",
+			SegmentSources: segSources,
+			Changed:        marketsuc.ChangedExchanges(summary),
+		}
+		if force, _ := strconv.ParseBool(c.Query("force")); force {
+			spec.Changed = nil
+		}
+		res, err := rt.Builder.RebuildAll(c.Request.Context(), spec)
+		if err != nil {
+			c.JSON(500, gin.H{
+				"error":        err.Error(),
+				"markets_sync": summary,
+			})
+			return
+		}
+
+		resp := gin.H{"markets_sync": summary}
+		if spec.Segments {
+			resp["segments_updated"] = res.Segments
+		}
+		if spec.Targets {
+			resp["lists_updated"] = res.Lists
+		}
+		if len(res.Skipped) > 0 {
+			resp["skipped"] = res.Skipped
+		}
+		if res.Generation.ID != 0 {
+			resp["generation"] = res.Generation.ID
+			c.Header("X-Generation", strconv.FormatInt(res.Generation.ID, 10))
+		}
+		c.JSON(200, resp)
+	})
+
+	// /admin — только админ-ручки (middleware уже висит глобально)
+	admin := router.Group("/admin")
+	admin.POST("/markets/sync", func(c *gin.Context) {
+		summary, err := rt.Sync.RunAll(c.Request.Context())
+		if err != nil {
+			c.JSON(500, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(200, gin.H{"summary": summary})
+	})
+	// /admin/aliases — алиасы тикеров (MATIC → POL и т.п.)
+	httpctrl.NewAliasesController(rt.Aliases).Register(admin)
+	// /admin/config — действующая конфигурация, секреты скрыты
+	httpctrl.NewConfigController(rt.Config).Register(admin)
+	// /admin/cache — счётчики кэша списков
+	admin.GET("/cache", func(c *gin.Context) {
+		if rt.Cache == nil {
+			c.JSON(200, gin.H{"enabled": false})
+			return
+		}
+		c.JSON(200, gin.H{"enabled": true, "lists": rt.Cache.Stats()})
+	})
+
+	return router
+}
@@ -47,6 +47,7 @@ func _doc_health() {}
 // @Param       mode   query  string false "segments|targets|all"
 // @Param       source query  string false "binance,bybit,okx"
 // @Param       target query  string false "upbit|coinbase|bithumb"
+// @Param       force  query  bool   false "пересобрать все списки, а не только зависящие от изменившихся бирж"
 // @Produce     json
 // @Success     200 {object} UpdateResp
 // @Failure     500 {object} map[string]string
@@ -58,17 +59,175 @@ func _doc_update() {}
 // @Tags        public
 // @Param       slug     path   string true  "list slug" Example(binance_seg1)
 // @Param       as_text  query  int    false "1 → text/plain"
+// @Param       format   query  string false "json|json-meta|text|csv|tsv|ndjson (или заголовок Accept)"
+// @Param       notation query  string false "raw|tradingview|ccxt"
+// @Param       generation query int  false "поколение списков (по умолчанию текущее)"
+// @Param       q           query string false "префикс базы или подстрока spot-символа"
+// @Param       has_futures query bool   false "true|false"
+// @Param       quote       query string false "CSV котировок спота"
+// @Param       min_volume_usd query number false "минимальный 24h-оборот спота в USD"
+// @Param       sort        query string false "spot|volume"
+// @Param       limit       query int    false "1..1000; по умолчанию весь список"
+// @Param       cursor      query string false "next_cursor предыдущей страницы"
 // @Produce     json
+// @Produce     plain
+// @Produce     text/csv
+// @Produce     text/tab-separated-values
+// @Produce     application/x-ndjson
 // @Success     200 {object} ListGetJSON
+// @Header      200 {integer} X-Generation "поколение, из которого прочитан список"
+// @Header      200 {integer} X-Total-Count "сколько строк под фильтром (с q/has_futures/quote/min_volume_usd/sort/limit/cursor)"
+// @Header      200 {string}  X-Next-Cursor "курсор следующей страницы"
+// @Failure     400 {object} map[string]string
+// @Failure     404 {object} map[string]string
 // @Router      /api/lists/{slug} [get]
 func _doc_lists() {}
 
 // Segments sugar-redirect
-// @Summary     Segment view (redirects to /api/lists/{source}_seg{n})
+// @Summary     Segment view (same as /api/lists/{slug} of the segment)
 // @Tags        public
-// @Param       source path string true "binance|bybit|okx"
-// @Param       seg    path int    true "1|2|3|4"
+// @Param       source path string true "source exchange slug"
+// @Param       seg    path string true "segment number (4 → seg4) or custom segment name"
 // @Param       as_text query int  false "1 → text/plain"
+// @Param       format  query string false "json|json-meta|text|csv|tsv|ndjson"
+// @Param       notation query string false "raw|tradingview|ccxt"
+// @Param       q           query string false "префикс базы или подстрока spot-символа"
+// @Param       has_futures query bool   false "true|false"
+// @Param       quote       query string false "CSV котировок спота"
+// @Param       min_volume_usd query number false "минимальный 24h-оборот спота в USD"
+// @Param       sort        query string false "spot|volume"
+// @Param       limit       query int    false "1..1000; по умолчанию весь список"
+// @Param       cursor      query string false "next_cursor предыдущей страницы"
 // @Success     307 {string} string "Temporary Redirect"
+// @Failure     404 {object} map[string]string "no such segment"
 // @Router      /api/segments/{source}/{seg} [get]
 func _doc_segments() {}
+
+// Markets query
+// @Summary     Query markets with filters and cursor pagination
+// @Tags        public
+// @Param       exchange      query string false "CSV of exchange slugs"
+// @Param       base          query string false "CSV of base assets"
+// @Param       quote         query string false "CSV of quote assets"
+// @Param       type          query string false "spot|futures"
+// @Param       contract      query string false "linear_perp,inverse_perp,delivery"
+// @Param       active        query bool   false "true|false"
+// @Param       listed_from   query string false "RFC3339 or YYYY-MM-DD (inclusive)"
+// @Param       listed_to     query string false "RFC3339 or YYYY-MM-DD (exclusive)"
+// @Param       delisted_from query string false "RFC3339 or YYYY-MM-DD (inclusive)"
+// @Param       delisted_to   query string false "RFC3339 or YYYY-MM-DD (exclusive)"
+// @Param       sort          query string false "symbol|base|listed_at|delisted_at|id; prefix - for desc"
+// @Param       limit         query int    false "1..1000, default 100"
+// @Param       cursor        query string false "next_cursor from previous page"
+// @Produce     json
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} map[string]string
+// @Router      /api/markets [get]
+func _doc_markets_query() {}
+
+// Market detail
+// @Summary     Market detail with instrument specs
+// @Tags        public
+// @Param       exchange path  string true  "exchange slug" Example(okx)
+// @Param       symbol   path  string true  "exchange symbol" Example(PEPE-USDT-SWAP)
+// @Param       type     query string false "spot|futures"
+// @Produce     json
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} map[string]string
+// @Failure     404 {object} map[string]string
+// @Router      /api/markets/{exchange}/{symbol} [get]
+func _doc_market_detail() {}
+
+// Asset view
+// @Summary     Where an asset trades and which lists contain it
+// @Tags        public
+// @Param       base path string true "base asset or alias" Example(PEPE)
+// @Produce     json
+// @Success     200 {object} map[string]interface{}
+// @Failure     404 {object} map[string]string
+// @Router      /api/assets/{base} [get]
+func _doc_asset_view() {}
+
+// Presence matrix
+// @Summary     Base × exchange presence matrix
+// @Tags        public
+// @Param       exchange query string false "CSV of exchange slugs"
+// @Param       format   query string false "json|csv"
+// @Produce     json
+// @Produce     text/csv
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} map[string]string
+// @Router      /api/presence [get]
+func _doc_presence() {}
+
+// Set expression query
+// @Summary     Evaluate a segment set expression against live data
+// @Tags        public
+// @Param       expr   query string true  "e.g. S & U & !(C | H)"
+// @Param       source query string false "binance|bybit|okx (required when expr uses S)"
+// @Produce     json
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} map[string]interface{}
+// @Router      /api/query [get]
+func _doc_query() {}
+
+// Preview
+// @Summary     Build a list or segment without persisting
+// @Tags        public
+// @Accept      json
+// @Produce     json
+// @Param       body body map[string]interface{} true "source, target|segment, mode, futures_kinds, quotes, slug"
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} map[string]interface{}
+// @Router      /api/preview [post]
+func _doc_preview() {}
+
+// Aliases
+// @Summary     List asset aliases
+// @Tags        admin
+// @Produce     json
+// @Success     200 {object} map[string]interface{}
+// @Router      /admin/aliases [get]
+func _doc_aliases_list() {}
+
+// @Summary     Create or update an asset alias
+// @Tags        admin
+// @Accept      json
+// @Produce     json
+// @Success     200 {object} map[string]interface{}
+// @Failure     400 {object} map[string]string
+// @Router      /admin/aliases [put]
+func _doc_aliases_upsert() {}
+
+// @Summary     Delete an asset alias
+// @Tags        admin
+// @Param       ticker   path  string true  "exchange ticker" Example(MATIC)
+// @Param       exchange query string false "exchange slug; empty → global alias"
+// @Success     204
+// @Failure     404 {object} map[string]string
+// @Router      /admin/aliases/{ticker} [delete]
+func _doc_aliases_delete() {}
+
+// @Summary     Effective configuration, secrets redacted
+// @Tags        admin
+// @Produce     json
+// @Success     200 {object} map[string]interface{}
+// @Router      /admin/config [get]
+func _doc_admin_config() {}
+
+// @Summary     List read cache counters
+// @Tags        admin
+// @Produce     json
+// @Success     200 {object} map[string]interface{}
+// @Router      /admin/cache [get]
+func _doc_admin_cache() {}
+
+// @Summary     Explain why a base is in or out of a list or segment
+// @Tags        lists
+// @Produce     json
+// @Param       slug path string true "list slug" Example(binance_to_upbit)
+// @Param       base path string true "base asset (aliases resolved)" Example(PEPE)
+// @Success     200 {object} map[string]interface{}
+// @Failure     404 {object} map[string]string
+// @Router      /api/lists/{slug}/explain/{base} [get]
+func _doc_lists_explain() {}
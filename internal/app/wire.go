@@ -2,51 +2,54 @@ package app
 
 import (
 	"context"
-	"os"
-	"strings"
+	"fmt"
+	"log/slog"
+	"net"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 
 	docs "github.com/berezovskyivalerii/tickersvc/docs"
-	httpctrl "github.com/berezovskyivalerii/tickersvc/internal/adapter/controller/http"
+	grpcctrl "github.com/berezovskyivalerii/tickersvc/internal/adapter/controller/grpc"
+	"github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/cache"
 	"github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/dbping"
-	exbinance "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/binance"
-	exbithumb "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/bithumb"
-	exbybit "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/bybit"
-	excoin "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/coinbase"
-	exokx "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/okx"
-	exrobin "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/robinhood"
-	exupbit "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/exchange/upbit"
 	pgrepo "github.com/berezovskyivalerii/tickersvc/internal/adapter/gateway/postgres"
 	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/events"
 	healthdom "github.com/berezovskyivalerii/tickersvc/internal/domain/health"
+	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
 	marketsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
-	httpinfra "github.com/berezovskyivalerii/tickersvc/internal/infra/http"
 	adminauth "github.com/berezovskyivalerii/tickersvc/internal/infra/http/mw/adminauth"
+	"github.com/berezovskyivalerii/tickersvc/internal/infra/logx"
 	"github.com/berezovskyivalerii/tickersvc/internal/infra/scheduler"
 	"github.com/berezovskyivalerii/tickersvc/internal/infra/store"
+	assetsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/assets"
 	usehealth "github.com/berezovskyivalerii/tickersvc/internal/usecase/health"
 	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
 	marketsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/markets"
 )
 
-type envErr string
-
-func (e envErr) Error() string { return "missing env: " + string(e) }
-func ErrEnv(name string) error { return envErr(name) }
-
-func Build() (*gin.Engine, error) {
-	dsn := os.Getenv("DB_DSN")
-	if dsn == "" {
-		return nil, ErrEnv("DB_DSN")
-	}
+// Build собирает роутер по уже загруженной и провалидированной конфигурации (см. config.Load).
+// Второе значение — запущенный gRPC-сервер (nil, если grpc.port не задан); останавливает его вызывающий.
+func Build(cfgStore *config.Store) (*gin.Engine, *grpc.Server, error) {
+	config.SetActive(cfgStore) // LoadQuotes, опции HTTP-клиентов бирж
+	cfg := cfgStore.Get()
+	dsn := cfg.DB.DSN
+
+	slog.SetDefault(logx.NewWith(cfg.Log.Level, cfg.Log.Format))
+	cfgStore.OnReload(func(c config.Config) {
+		if err := logx.SetLevel(c.Log.Level); err != nil {
+			slog.Error("config reload", "err", err)
+		}
+	})
+	cfgStore.WatchSIGHUP(context.Background())
 
 	db, err := store.OpenPostgres(dsn)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// --- Health (/health) ---
@@ -63,78 +66,66 @@ func Build() (*gin.Engine, error) {
 		Timeout:   500 * time.Millisecond,
 	}
 
-	router := httpinfra.NewRouter()
-
-	// Все ручки защищены ADMIN_API_KEY
-	router.Use(adminauth.NewFromEnv().Handler())
+	// Ключ перечитывается на каждый запрос (смена по SIGHUP); общий для HTTP и gRPC
+	apiKey := func() string { return cfgStore.Get().Admin.APIKey }
+	auth := adminauth.New(apiKey)
 
 	// Swagger (тоже под ключ)
 	docs.SwaggerInfo.Title = "TickerSvc API"
 	docs.SwaggerInfo.Version = "1.0"
 	docs.SwaggerInfo.BasePath = "/"
-	docs.SwaggerInfo.Schemes = []string{"http"}
-	if h := strings.TrimSpace(os.Getenv("SWAGGER_HOST")); h != "" {
-		docs.SwaggerInfo.Host = h
+	docs.SwaggerInfo.Schemes = cfg.HTTP.SwaggerSchemes
+	if cfg.HTTP.SwaggerHost != "" {
+		docs.SwaggerInfo.Host = cfg.HTTP.SwaggerHost
 	}
-	if s := strings.TrimSpace(os.Getenv("SWAGGER_SCHEMES")); s != "" {
-		var parts []string
-		for _, p := range strings.Split(s, ",") {
-			p = strings.TrimSpace(p)
-			if p != "" {
-				parts = append(parts, p)
-			}
-		}
-		if len(parts) > 0 {
-			docs.SwaggerInfo.Schemes = parts
-		}
-	}
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-	_ = router.SetTrustedProxies(nil)
-
-	health := httpctrl.NewHealthController(httpctrl.ReadinessRunner{UC: ucHealth})
-	health.Register(router)
 
 	// --- Repos ---
 	marketsRepo := pgrepo.NewMarketsRepo(db)
 	defsRepo := pgrepo.NewListDefsRepo(db)
 	listsSaver := pgrepo.NewListsRepo(db)
 	listsReader := pgrepo.NewListsQueryRepo(db)
+
+	// Кэш чтения публичных списков; сбрасывается коммитом каждого нового поколения
+	var pubLists cache.ListsSource = listsReader
+	var listsCache *cache.ListsQuery
+	if lc := cfg.ListsCache; !lc.Disable {
+		listsCache = cache.NewListsQuery(listsReader, cache.Options{
+			TTL:        lc.TTL.D(),
+			MaxStale:   lc.MaxStale.D(),
+			MaxEntries: lc.MaxEntries,
+		})
+		listsSaver.OnPublish(func(listsdom.Generation) { listsCache.Invalidate() })
+		pubLists = listsCache
+	}
+	// Публикации из других процессов (tickerctl rebuild) — через NOTIFY; подписчики OnPublish ниже
+	if err := listsSaver.Listen(context.Background(), dsn); err != nil {
+		return nil, nil, err
+	}
 	exchangesRepo := pgrepo.NewExchangesRepo(db)
+	aliasesRepo := pgrepo.NewAliasesRepo(db)
 
-	// --- Active exchanges + excludes from ENV ---
+	// --- Active exchanges + excludes из конфигурации → fetchers ---
 	actMap, err := exchangesRepo.ActiveMap(context.Background())
 	if err != nil {
-		return nil, err
-	}
-	exclude := map[string]bool{}
-	if v := os.Getenv("EXCLUDE_EXCHANGES"); v != "" {
-		for _, s := range strings.Split(v, ",") {
-			s = strings.TrimSpace(strings.ToLower(s))
-			if s != "" {
-				exclude[s] = true
-			}
-		}
+		return nil, nil, err
 	}
+	fetchers := Fetchers(cfg, actMap)
 
-	// Fetchers (по активным биржам)
-	allFetchers := []marketsdom.Fetcher{
-		exbinance.New(),
-		exbybit.New(),
-		exokx.New(),
-		exupbit.New(),
-		excoin.New(),
-		exbithumb.New(),
-		exrobin.New(),
+	assetsViewer := &assetsuc.Viewer{
+		Markets: marketsRepo,
+		Lists:   listsReader,
+		Aliases: aliasesRepo,
 	}
-	var fetchers []marketsdom.Fetcher
-	for _, f := range allFetchers {
-		if exclude[f.Name()] {
-			continue
-		}
-		if on, ok := actMap[f.ExchangeID()]; ok && !on {
-			continue
+
+	// Уведомления о листингах/делистингах (Slack, Telegram, SMTP); без каналов — выключены
+	var marketEvents events.Publisher
+	if len(cfg.Notify.Channels) > 0 {
+		notifier, err := buildNotifier(cfg.Notify, assetsViewer)
+		if err != nil {
+			return nil, nil, err
 		}
-		fetchers = append(fetchers, f)
+		notifier.Start(context.Background())
+		marketEvents = notifier
 	}
 
 	// Use-cases
@@ -142,116 +133,93 @@ func Build() (*gin.Engine, error) {
 		Repo:     marketsRepo,
 		Fetchers: fetchers,
 		Timeout:  45 * time.Second,
+		Events:   marketEvents,
+	}
+	if listsCache != nil {
+		marketsOrc.OnVolumes = listsCache.Invalidate // обороты в строках списков меняются без нового поколения
 	}
 	listsInteractor := &listsuc.Interactor{
 		Defs:    defsRepo,
 		Markets: marketsRepo,
 		Lists:   listsSaver,
+		Aliases: aliasesRepo,
 	}
 
-	// Авто-обновление каждые N минут (по умолчанию 10m)
-	if os.Getenv("AUTO_UPDATE_DISABLE") != "1" {
-		interval := 10 * time.Minute
-		if s := os.Getenv("AUTO_UPDATE_INTERVAL"); s != "" {
-			if d, err := time.ParseDuration(s); err == nil && d > 0 {
-				interval = d
-			}
-		}
+	// Авто-обновление каждые N минут (по умолчанию 10m; интервал перечитывается по SIGHUP)
+	if !cfg.AutoUpdate.Disable {
 		au := &scheduler.AutoUpdater{
-			Markets:  marketsOrc,
-			Lists:    listsInteractor, // внутри вызывает BuildAndSaveFiltered(nil,nil)
-			Interval: interval,
-			Timeout:  4 * time.Minute,
+			Markets:    marketsOrc,
+			Lists:      listsInteractor, // списки и сегменты одним поколением (RebuildAll)
+			Interval:   cfg.AutoUpdate.Interval.D(),
+			IntervalFn: func() time.Duration { return cfgStore.Get().AutoUpdate.Interval.D() },
+			Timeout:    4 * time.Minute,
 		}
 		au.Start(context.Background())
+		cfgStore.OnReload(func(config.Config) { au.Retune() }) // новый интервал — сразу, а не на старом тике
 	}
 
-	// Публичные (под ключом) списки и сегменты
-	pub := httpctrl.NewPublicListsController(listsReader)
-	pub.Register(router) // /api/lists/:slug, /api/lists?target=..., /api/segments/:source/:seg
-
-	// POST /update — sync + пересборка списков/сегментов
-	router.POST("/update", func(c *gin.Context) {
-		summary, err := marketsOrc.RunAll(c.Request.Context())
-		if err != nil {
-			c.JSON(500, gin.H{"error": err.Error()})
-			return
-		}
-
-		mode := strings.ToLower(strings.TrimSpace(c.Query("mode")))
-		if mode == "" {
-			mode = "all"
+	// Детектор листингов: частый условный опрос списков рынков upbit/bithumb между циклами авто-обновления
+	if lw := cfg.ListingWatch; !lw.Disable {
+		watch := map[string]bool{}
+		for _, e := range lw.Exchanges {
+			watch[e] = true
 		}
-
-		// raw для сегментов (поддержка нескольких источников)
-		srcRaw := strings.TrimSpace(c.Query("source"))
-		tgtRaw := strings.TrimSpace(c.Query("target"))
-
-		// targets: берём первый source/target
-		var srcPtr, tgtPtr *string
-		if srcRaw != "" {
-			first := strings.TrimSpace(strings.Split(srcRaw, ",")[0])
-			if first != "" {
-				srcPtr = &first
+		var pollers []marketsdom.ListingPoller
+		for _, f := range fetchers {
+			if p, ok := f.(marketsdom.ListingPoller); ok && watch[f.Name()] {
+				pollers = append(pollers, p)
 			}
 		}
-		if tgtRaw != "" {
-			first := strings.TrimSpace(strings.Split(tgtRaw, ",")[0])
-			if first != "" {
-				tgtPtr = &first
-			}
+		if len(pollers) > 0 {
+			(&scheduler.ListingWatcher{
+				Pollers:  pollers,
+				Markets:  marketsRepo,
+				Lists:    listsInteractor,
+				Events:   marketEvents,
+				Quotes:   lw.Quotes,
+				Interval: lw.Interval.D(),
+			}).Start(context.Background())
 		}
+	}
 
-		resp := gin.H{"markets_sync": summary}
-
-		// Сегменты (binance/bybit/okx)
-		if mode == "segments" || mode == "all" {
-			var segSources []string
-			if srcRaw != "" {
-				for _, v := range strings.Split(srcRaw, ",") {
-					v = strings.TrimSpace(v)
-					if v != "" {
-						segSources = append(segSources, v)
-					}
-				}
-			}
-			segUpd, err := listsInteractor.RebuildSegments(c.Request.Context(), segSources...)
-			if err != nil {
-				c.JSON(500, gin.H{
-					"error":        err.Error(),
-					"markets_sync": summary,
-				})
-				return
-			}
-			resp["segments_updated"] = segUpd
-		}
-
-		// Target-списки (okx_to_upbit и т.п.)
-		if mode == "targets" || mode == "all" {
-			updated, err := listsInteractor.BuildAndSaveFiltered(c.Request.Context(), srcPtr, tgtPtr)
-			if err != nil {
-				c.JSON(500, gin.H{
-					"error":        err.Error(),
-					"markets_sync": summary,
-				})
-				return
-			}
-			resp["lists_updated"] = updated
-		}
-
-		c.JSON(200, resp)
-	})
-
-	// /admin — только админ-ручки (middleware уже висит глобально)
-	admin := router.Group("/admin")
-	admin.POST("/markets/sync", func(c *gin.Context) {
-		summary, err := marketsOrc.RunAll(c.Request.Context())
+	// gRPC рядом с gin: те же репозитории и ключ; WatchLists — по публикациям поколений
+	var gs *grpc.Server
+	if cfg.GRPC.Port != 0 {
+		feed := grpcctrl.NewFeed()
+		listsSaver.OnPublish(feed.Publish)
+		gs = grpc.NewServer(
+			grpc.ChainUnaryInterceptor(auth.UnaryInterceptor()),
+			grpc.ChainStreamInterceptor(auth.StreamInterceptor()),
+		)
+		(&grpcctrl.Server{
+			Lists:   pubLists,
+			Gens:    pubLists,
+			Assets:  assetsViewer,
+			Markets: marketsRepo,
+			Feed:    feed,
+		}).Register(gs)
+		reflection.Register(gs) // grpcurl без .proto
+		lis, err := net.Listen("tcp", ":"+strconv.Itoa(cfg.GRPC.Port))
 		if err != nil {
-			c.JSON(500, gin.H{"error": err.Error()})
-			return
+			return nil, nil, fmt.Errorf("grpc listen: %w", err)
 		}
-		c.JSON(200, gin.H{"summary": summary})
-	})
+		go func() {
+			if err := gs.Serve(lis); err != nil {
+				slog.Error("grpc serve", "err", err)
+			}
+		}()
+	}
 
-	return router, nil
+	return NewRouter(Routes{
+		APIKey:  apiKey,
+		Health:  ucHealth,
+		Lists:   pubLists,
+		Cache:   listsCache,
+		Markets: marketsRepo,
+		Assets:  assetsViewer,
+		Aliases: aliasesRepo,
+		Config:  cfgStore,
+		Sync:    marketsOrc,
+		Builder: listsInteractor,
+	}), gs, nil
 }
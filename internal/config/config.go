@@ -0,0 +1,474 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config — вся конфигурация сервиса. Порядок: значения по умолчанию → файл (YAML или JSON) → env.
+// Имена env — прежние (PORT, DB_DSN, AUTO_UPDATE_INTERVAL, ...), см. envBindings.
+type Config struct {
+	HTTP         HTTPConfig         `yaml:"http" json:"http"`
+	GRPC         GRPCConfig         `yaml:"grpc" json:"grpc"`
+	DB           DBConfig           `yaml:"db" json:"db"`
+	Admin        AdminConfig        `yaml:"admin" json:"admin"`
+	Log          LogConfig          `yaml:"log" json:"log"`
+	Quotes       QuotesSection      `yaml:"quotes" json:"quotes"`
+	Exchanges    ExchangesConfig    `yaml:"exchanges" json:"exchanges"`
+	AutoUpdate   AutoUpdateConfig   `yaml:"auto_update" json:"auto_update"`
+	ListsCache   ListsCacheConfig   `yaml:"lists_cache" json:"lists_cache"`
+	ListingWatch ListingWatchConfig `yaml:"listing_watch" json:"listing_watch"`
+	Notify       NotifyConfig       `yaml:"notify" json:"notify"`
+}
+
+type HTTPConfig struct {
+	Port           int      `yaml:"port" json:"port"`
+	SwaggerHost    string   `yaml:"swagger_host" json:"swagger_host"`
+	SwaggerSchemes []string `yaml:"swagger_schemes" json:"swagger_schemes"`
+}
+
+// GRPCConfig — gRPC API рядом с HTTP (тот же ключ); port 0 — выключен.
+type GRPCConfig struct {
+	Port int `yaml:"port" json:"port"`
+}
+
+type DBConfig struct {
+	DSN string `yaml:"dsn" json:"dsn"` // секрет: в /admin/config пароль скрыт
+}
+
+type AdminConfig struct {
+	APIKey string `yaml:"api_key" json:"api_key"` // секрет; перечитывается по SIGHUP
+}
+
+type LogConfig struct {
+	Level  string `yaml:"level" json:"level"`   // debug|info|warn|error; перечитывается
+	Format string `yaml:"format" json:"format"` // text|json
+}
+
+// QuotesSection — файловое представление QuotesConfig; перечитывается.
+type QuotesSection struct {
+	SourceSpot    string   `yaml:"source_spot" json:"source_spot"`
+	TargetAllowed []string `yaml:"target_allowed" json:"target_allowed"`
+}
+
+type ExchangesConfig struct {
+	Exclude []string         `yaml:"exclude" json:"exclude"`
+	HTTP    ExchangeHTTPConf `yaml:"http" json:"http"`
+}
+
+type ExchangeHTTPConf struct {
+	Timeout    Duration `yaml:"timeout" json:"timeout"`
+	Retries    int      `yaml:"retries" json:"retries"`
+	BackoffMin Duration `yaml:"backoff_min" json:"backoff_min"`
+	BackoffMax Duration `yaml:"backoff_max" json:"backoff_max"`
+	UserAgent  string   `yaml:"user_agent" json:"user_agent"`
+}
+
+type AutoUpdateConfig struct {
+	Disable  bool     `yaml:"disable" json:"disable"`
+	Interval Duration `yaml:"interval" json:"interval"` // перечитывается
+}
+
+// ListsCacheConfig — кэш чтения списков (/api/lists); сбрасывается каждой публикацией.
+type ListsCacheConfig struct {
+	Disable    bool     `yaml:"disable" json:"disable"`
+	TTL        Duration `yaml:"ttl" json:"ttl"`
+	MaxStale   Duration `yaml:"max_stale" json:"max_stale"` // сколько отдавать устаревшее, пока БД недоступна
+	MaxEntries int      `yaml:"max_entries" json:"max_entries"`
+}
+
+// ListingWatchConfig — быстрый детектор листингов (частый опрос списков рынков целевых бирж).
+type ListingWatchConfig struct {
+	Disable   bool     `yaml:"disable" json:"disable"`
+	Interval  Duration `yaml:"interval" json:"interval"`
+	Exchanges []string `yaml:"exchanges" json:"exchanges"` // upbit, bithumb
+	Quotes    []string `yaml:"quotes" json:"quotes"`       // новые рынки в этих котировках — листинг
+}
+
+// NotifyConfig — уведомления о листингах/делистингах в чаты и почту; без каналов выключены.
+type NotifyConfig struct {
+	BatchWindow Duration        `yaml:"batch_window" json:"batch_window"` // события за окно — одним сообщением
+	DedupTTL    Duration        `yaml:"dedup_ttl" json:"dedup_ttl"`       // повтор того же рынка за это время не шлётся
+	Channels    []NotifyChannel `yaml:"channels" json:"channels"`
+}
+
+// NotifyChannel — один канал: type slack|telegram|smtp и поля своего типа.
+type NotifyChannel struct {
+	Name      string   `yaml:"name" json:"name"` // для логов; "" — type
+	Type      string   `yaml:"type" json:"type"`
+	Exchanges []string `yaml:"exchanges" json:"exchanges"` // маршрут: пусто — все биржи
+	Kinds     []string `yaml:"kinds" json:"kinds"`         // listing|delisting; пусто — все
+	Template  string   `yaml:"template" json:"template"`   // text/template; "" — по умолчанию
+	Subject   string   `yaml:"subject" json:"subject"`     // шаблон темы (письмо, первая строка в чатах)
+
+	WebhookURL string `yaml:"webhook_url" json:"webhook_url"` // slack; секрет
+	BotToken   string `yaml:"bot_token" json:"bot_token"`     // telegram; секрет
+	ChatID     string `yaml:"chat_id" json:"chat_id"`         // telegram
+
+	Addr     string   `yaml:"addr" json:"addr"` // smtp host:port
+	Username string   `yaml:"username" json:"username"`
+	Password string   `yaml:"password" json:"password"` // секрет
+	From     string   `yaml:"from" json:"from"`
+	To       []string `yaml:"to" json:"to"`
+}
+
+// Duration — time.Duration, которая в файле и в JSON пишется строкой: "10m", "200ms".
+type Duration time.Duration
+
+func (d Duration) D() time.Duration { return time.Duration(d) }
+
+func (d Duration) MarshalJSON() ([]byte, error) { return json.Marshal(time.Duration(d).String()) }
+
+func (d *Duration) UnmarshalYAML(n *yaml.Node) error {
+	x, err := time.ParseDuration(n.Value)
+	if err != nil {
+		return fmt.Errorf("line %d: invalid duration %q", n.Line, n.Value)
+	}
+	*d = Duration(x)
+	return nil
+}
+
+// Defaults — то, что раньше было зашито в местах чтения env.
+func Defaults() Config {
+	return Config{
+		HTTP:   HTTPConfig{Port: 8080, SwaggerSchemes: []string{"http"}},
+		GRPC:   GRPCConfig{Port: 9090},
+		Log:    LogConfig{Level: "info", Format: "text"},
+		Quotes: QuotesSection{SourceSpot: "USDT", TargetAllowed: []string{"USDT", "USD", "KRW"}},
+		Exchanges: ExchangesConfig{HTTP: ExchangeHTTPConf{
+			Timeout:    Duration(8 * time.Second),
+			Retries:    2,
+			BackoffMin: Duration(200 * time.Millisecond),
+			BackoffMax: Duration(3 * time.Second),
+			UserAgent:  "tickersvc (+https://github.com/berezovskyivalerii/tickersvc)",
+		}},
+		AutoUpdate: AutoUpdateConfig{Interval: Duration(10 * time.Minute)},
+		ListsCache: ListsCacheConfig{TTL: Duration(10 * time.Minute), MaxStale: Duration(5 * time.Minute), MaxEntries: 1000},
+		ListingWatch: ListingWatchConfig{
+			Interval:  Duration(5 * time.Second),
+			Exchanges: []string{"upbit", "bithumb"},
+			Quotes:    []string{"KRW"},
+		},
+		Notify: NotifyConfig{BatchWindow: Duration(3 * time.Second), DedupTTL: Duration(time.Hour)},
+	}
+}
+
+// Load: defaults → файл path ("" — без файла) → env → Validate.
+// Неизвестные ключи файла и нераспознанные значения env — ошибка, а не молчаливый default.
+func Load(path string) (Config, error) { return load(path, true) }
+
+// LoadTool — как Load, но admin.api_key не обязателен: утилитам (cmd/tickerctl) нужна только БД.
+func LoadTool(path string) (Config, error) { return load(path, false) }
+
+func load(path string, needKey bool) (Config, error) {
+	cfg := Defaults()
+	if path != "" {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return Config{}, fmt.Errorf("config file: %w", err)
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(b)) // JSON — подмножество YAML; пустой файл — io.EOF
+		dec.KnownFields(true)
+		if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return Config{}, fmt.Errorf("config file %s: %w", path, err)
+		}
+	}
+	if err := applyEnv(&cfg, os.LookupEnv); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.validate(needKey); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+type envBinding struct {
+	name string
+	set  func(c *Config, v string) error
+}
+
+func csvList(v string) []string {
+	var out []string
+	for _, p := range strings.Split(v, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func envDuration(dst *Duration) func(string) error {
+	return func(v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.New("invalid duration")
+		}
+		*dst = Duration(d)
+		return nil
+	}
+}
+
+func envInt(dst *int) func(string) error {
+	return func(v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return errors.New("invalid integer")
+		}
+		*dst = n
+		return nil
+	}
+}
+
+// envBindings — env-переопределения (пустая переменная = не задана).
+var envBindings = []envBinding{
+	{"PORT", func(c *Config, v string) error { return envInt(&c.HTTP.Port)(v) }},
+	{"SWAGGER_HOST", func(c *Config, v string) error { c.HTTP.SwaggerHost = v; return nil }},
+	{"SWAGGER_SCHEMES", func(c *Config, v string) error { c.HTTP.SwaggerSchemes = csvList(v); return nil }},
+	{"GRPC_PORT", func(c *Config, v string) error { return envInt(&c.GRPC.Port)(v) }},
+	{"DB_DSN", func(c *Config, v string) error { c.DB.DSN = v; return nil }},
+	{"ADMIN_API_KEY", func(c *Config, v string) error { c.Admin.APIKey = v; return nil }},
+	{"LOG_LEVEL", func(c *Config, v string) error { c.Log.Level = strings.ToLower(v); return nil }},
+	{"LOG_FORMAT", func(c *Config, v string) error { c.Log.Format = strings.ToLower(v); return nil }},
+	{"SOURCE_SPOT_QUOTE", func(c *Config, v string) error { c.Quotes.SourceSpot = v; return nil }},
+	{"TARGET_ALLOWED_QUOTES", func(c *Config, v string) error { c.Quotes.TargetAllowed = csvList(v); return nil }},
+	{"EXCLUDE_EXCHANGES", func(c *Config, v string) error { c.Exchanges.Exclude = csvList(v); return nil }},
+	{"HTTP_TIMEOUT", func(c *Config, v string) error { return envDuration(&c.Exchanges.HTTP.Timeout)(v) }},
+	{"HTTP_RETRIES", func(c *Config, v string) error { return envInt(&c.Exchanges.HTTP.Retries)(v) }},
+	{"HTTP_BACKOFF_MIN", func(c *Config, v string) error { return envDuration(&c.Exchanges.HTTP.BackoffMin)(v) }},
+	{"HTTP_BACKOFF_MAX", func(c *Config, v string) error { return envDuration(&c.Exchanges.HTTP.BackoffMax)(v) }},
+	{"HTTP_USER_AGENT", func(c *Config, v string) error { c.Exchanges.HTTP.UserAgent = v; return nil }},
+	{"AUTO_UPDATE_DISABLE", func(c *Config, v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New("invalid boolean")
+		}
+		c.AutoUpdate.Disable = b
+		return nil
+	}},
+	{"AUTO_UPDATE_INTERVAL", func(c *Config, v string) error { return envDuration(&c.AutoUpdate.Interval)(v) }},
+	{"LISTS_CACHE_DISABLE", func(c *Config, v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New("invalid boolean")
+		}
+		c.ListsCache.Disable = b
+		return nil
+	}},
+	{"LISTS_CACHE_TTL", func(c *Config, v string) error { return envDuration(&c.ListsCache.TTL)(v) }},
+	{"LISTS_CACHE_MAX_STALE", func(c *Config, v string) error { return envDuration(&c.ListsCache.MaxStale)(v) }},
+	{"LISTS_CACHE_MAX_ENTRIES", func(c *Config, v string) error { return envInt(&c.ListsCache.MaxEntries)(v) }},
+	{"LISTING_WATCH_DISABLE", func(c *Config, v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return errors.New("invalid boolean")
+		}
+		c.ListingWatch.Disable = b
+		return nil
+	}},
+	{"LISTING_WATCH_INTERVAL", func(c *Config, v string) error { return envDuration(&c.ListingWatch.Interval)(v) }},
+	{"LISTING_WATCH_EXCHANGES", func(c *Config, v string) error { c.ListingWatch.Exchanges = csvList(v); return nil }},
+	{"LISTING_WATCH_QUOTES", func(c *Config, v string) error { c.ListingWatch.Quotes = csvList(v); return nil }},
+	{"NOTIFY_BATCH_WINDOW", func(c *Config, v string) error { return envDuration(&c.Notify.BatchWindow)(v) }},
+	{"NOTIFY_DEDUP_TTL", func(c *Config, v string) error { return envDuration(&c.Notify.DedupTTL)(v) }},
+}
+
+func applyEnv(c *Config, lookup func(string) (string, bool)) error {
+	var errs []error
+	for _, b := range envBindings {
+		v, ok := lookup(b.name)
+		if v = strings.TrimSpace(v); !ok || v == "" {
+			continue
+		}
+		if err := b.set(c, v); err != nil {
+			errs = append(errs, fmt.Errorf("%s=%q: %w", b.name, v, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var knownExchanges = map[string]bool{
+	"binance": true, "bybit": true, "okx": true, "coinbase": true, "upbit": true, "bithumb": true, "robinhood": true,
+}
+
+// Validate нормализует регистр (котировки — верхний, slug-и — нижний) и проверяет значения.
+// Ошибки собираются все сразу.
+func (c *Config) Validate() error { return c.validate(true) }
+
+func (c *Config) validate(needKey bool) error {
+	var errs []error
+	bad := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+
+	if c.HTTP.Port < 1 || c.HTTP.Port > 65535 {
+		bad("http.port", "must be 1..65535, got %d", c.HTTP.Port)
+	}
+	for _, s := range c.HTTP.SwaggerSchemes {
+		if s != "http" && s != "https" {
+			bad("http.swagger_schemes", "unknown scheme %q", s)
+		}
+	}
+	if c.GRPC.Port < 0 || c.GRPC.Port > 65535 {
+		bad("grpc.port", "must be 0..65535, got %d", c.GRPC.Port)
+	} else if c.GRPC.Port != 0 && c.GRPC.Port == c.HTTP.Port {
+		bad("grpc.port", "must differ from http.port")
+	}
+	if strings.TrimSpace(c.DB.DSN) == "" {
+		bad("db.dsn", "required (DB_DSN)")
+	}
+	if needKey && strings.TrimSpace(c.Admin.APIKey) == "" {
+		bad("admin.api_key", "required (ADMIN_API_KEY)")
+	}
+	switch c.Log.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		bad("log.level", "must be one of: debug, info, warn, error")
+	}
+	switch c.Log.Format {
+	case "text", "json":
+	default:
+		bad("log.format", "must be text or json")
+	}
+
+	c.Quotes.SourceSpot = strings.ToUpper(strings.TrimSpace(c.Quotes.SourceSpot))
+	if c.Quotes.SourceSpot == "" {
+		bad("quotes.source_spot", "required")
+	}
+	if len(c.Quotes.TargetAllowed) == 0 {
+		bad("quotes.target_allowed", "at least one quote is required")
+	}
+	for i, q := range c.Quotes.TargetAllowed {
+		c.Quotes.TargetAllowed[i] = strings.ToUpper(strings.TrimSpace(q))
+	}
+
+	for i, e := range c.Exchanges.Exclude {
+		e = strings.ToLower(strings.TrimSpace(e))
+		c.Exchanges.Exclude[i] = e
+		if !knownExchanges[e] {
+			bad("exchanges.exclude", "unknown exchange %q", e)
+		}
+	}
+	h := c.Exchanges.HTTP
+	if h.Timeout <= 0 {
+		bad("exchanges.http.timeout", "must be > 0")
+	}
+	if h.Retries < 0 {
+		bad("exchanges.http.retries", "must be >= 0")
+	}
+	if h.BackoffMin <= 0 || h.BackoffMax < h.BackoffMin {
+		bad("exchanges.http.backoff_min/backoff_max", "need 0 < backoff_min <= backoff_max")
+	}
+	if c.AutoUpdate.Interval <= 0 {
+		bad("auto_update.interval", "must be > 0")
+	}
+	if c.ListsCache.TTL <= 0 {
+		bad("lists_cache.ttl", "must be > 0")
+	}
+	if c.ListsCache.MaxStale < 0 {
+		bad("lists_cache.max_stale", "must be >= 0")
+	}
+	if c.ListsCache.MaxEntries < 1 {
+		bad("lists_cache.max_entries", "must be >= 1")
+	}
+	if c.ListingWatch.Interval.D() < time.Second {
+		bad("listing_watch.interval", "must be >= 1s")
+	}
+	for _, e := range c.ListingWatch.Exchanges {
+		if e != "upbit" && e != "bithumb" {
+			bad("listing_watch.exchanges", "supported: upbit, bithumb")
+		}
+	}
+	if c.Notify.BatchWindow < 0 || c.Notify.DedupTTL < 0 {
+		bad("notify.batch_window/dedup_ttl", "must be >= 0")
+	}
+	for i := range c.Notify.Channels {
+		ch := &c.Notify.Channels[i]
+		key := fmt.Sprintf("notify.channels[%d]", i)
+		ch.Type = strings.ToLower(strings.TrimSpace(ch.Type))
+		switch ch.Type {
+		case "slack":
+			if ch.WebhookURL == "" {
+				bad(key, "slack needs webhook_url")
+			}
+		case "telegram":
+			if ch.BotToken == "" || ch.ChatID == "" {
+				bad(key, "telegram needs bot_token and chat_id")
+			}
+		case "smtp":
+			if ch.Addr == "" || ch.From == "" || len(ch.To) == 0 {
+				bad(key, "smtp needs addr, from and to")
+			}
+		default:
+			bad(key, "type must be slack, telegram or smtp")
+		}
+		for j, e := range ch.Exchanges {
+			e = strings.ToLower(strings.TrimSpace(e))
+			ch.Exchanges[j] = e
+			if !knownExchanges[e] {
+				bad(key+".exchanges", "unknown exchange %q", e)
+			}
+		}
+		for j, k := range ch.Kinds {
+			k = strings.ToLower(strings.TrimSpace(k))
+			ch.Kinds[j] = k
+			if k != "listing" && k != "delisting" {
+				bad(key+".kinds", "must be listing or delisting")
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// QuotesConfig — котировки в том виде, в каком их ждёт сборка списков.
+func (c Config) QuotesConfig() QuotesConfig {
+	return QuotesConfig{
+		SourceSpotQuote:     c.Quotes.SourceSpot,
+		TargetAllowedQuotes: toSetCSV(strings.Join(c.Quotes.TargetAllowed, ",")),
+	}
+}
+
+const redacted = "xxxxx"
+
+var dsnPassword = regexp.MustCompile(`(password\s*=\s*)('[^']*'|\S+)`)
+
+// Redacted — копия для показа наружу: ключ API, пароль в DSN и секреты каналов уведомлений скрыты.
+func (c Config) Redacted() Config {
+	if c.Admin.APIKey != "" {
+		c.Admin.APIKey = redacted
+	}
+	c.Notify.Channels = append([]NotifyChannel(nil), c.Notify.Channels...)
+	for i := range c.Notify.Channels {
+		ch := &c.Notify.Channels[i]
+		for _, sec := range []*string{&ch.WebhookURL, &ch.BotToken, &ch.Password} {
+			if *sec != "" {
+				*sec = redacted
+			}
+		}
+	}
+	if u, err := url.Parse(c.DB.DSN); err == nil && u.Scheme != "" {
+		// lib/pq берёт пароль и из ?password= (и sslpassword)
+		if q := u.Query(); q.Has("password") || q.Has("sslpassword") {
+			for _, k := range []string{"password", "sslpassword"} {
+				if q.Has(k) {
+					q.Set(k, redacted)
+				}
+			}
+			u.RawQuery = q.Encode()
+		}
+		c.DB.DSN = u.Redacted()
+	} else {
+		c.DB.DSN = dsnPassword.ReplaceAllString(c.DB.DSN, "${1}"+redacted)
+	}
+	return c
+}
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearEnv — чтобы окружение машины не влияло на тест.
+func clearEnv(t *testing.T) {
+	for _, b := range envBindings {
+		t.Setenv(b.name, "")
+	}
+}
+
+func writeFile(t *testing.T, name, body string) string {
+	p := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(p, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+const baseYAML = `
+db:
+  dsn: postgres://app:pw@db/tickers
+admin:
+  api_key: k1
+quotes:
+  source_spot: usdt
+  target_allowed: [usdt, krw]
+exchanges:
+  exclude: [Robinhood]
+  http:
+    timeout: 5s
+auto_update:
+  interval: 15m
+`
+
+func TestLoad_FileThenEnv(t *testing.T) {
+	clearEnv(t)
+	p := writeFile(t, "c.yaml", baseYAML)
+	t.Setenv("AUTO_UPDATE_INTERVAL", "30m")
+	t.Setenv("HTTP_RETRIES", "5")
+
+	c, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.AutoUpdate.Interval.D() != 30*time.Minute || c.Exchanges.HTTP.Retries != 5 {
+		t.Fatalf("env overrides: %+v", c)
+	}
+	if c.Exchanges.HTTP.Timeout.D() != 5*time.Second || c.Exchanges.HTTP.BackoffMax.D() != 3*time.Second {
+		t.Fatalf("file/defaults: %+v", c.Exchanges.HTTP)
+	}
+	if c.Quotes.SourceSpot != "USDT" || !slices.Equal(c.Quotes.TargetAllowed, []string{"USDT", "KRW"}) ||
+		!slices.Equal(c.Exchanges.Exclude, []string{"robinhood"}) {
+		t.Fatalf("normalize: %+v %+v", c.Quotes, c.Exchanges.Exclude)
+	}
+	if _, ok := c.QuotesConfig().TargetAllowedQuotes["KRW"]; !ok || c.HTTP.Port != 8080 {
+		t.Fatalf("quotes/port: %+v %d", c.QuotesConfig(), c.HTTP.Port)
+	}
+}
+
+func TestLoad_JSONAndEnvOnly(t *testing.T) {
+	clearEnv(t)
+	p := writeFile(t, "c.json", `{"db":{"dsn":"x"},"admin":{"api_key":"k"},"http":{"port":9000}}`)
+	c, err := Load(p)
+	if err != nil || c.HTTP.Port != 9000 {
+		t.Fatalf("json: %+v %v", c.HTTP, err)
+	}
+
+	t.Setenv("DB_DSN", "x")
+	if _, err := LoadTool(""); err != nil {
+		t.Fatalf("tool without key: %v", err)
+	}
+	if _, err := Load(""); err == nil || !strings.Contains(err.Error(), "admin.api_key") {
+		t.Fatalf("server without key: %v", err)
+	}
+	t.Setenv("ADMIN_API_KEY", "k")
+	if _, err := Load(""); err != nil {
+		t.Fatalf("env only: %v", err)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	cases := []struct {
+		name, file string
+		env        map[string]string
+		want       []string
+	}{
+		{"unknown key", baseYAML + "\nauto_updte:\n  interval: 1m\n", nil, []string{"auto_updte"}},
+		{"bad duration in file", strings.Replace(baseYAML, "15m", "soon", 1), nil, []string{`invalid duration "soon"`}},
+		{"bad env", baseYAML, map[string]string{"AUTO_UPDATE_INTERVAL": "ten", "PORT": "x"}, []string{"AUTO_UPDATE_INTERVAL", "PORT"}},
+		{"validation", "log:\n  level: loud\nexchanges:\n  exclude: [kraken]\n  http:\n    backoff_min: 5s\n",
+			nil, []string{"db.dsn", "admin.api_key", "log.level", `unknown exchange "kraken"`, "backoff_min"}},
+		{"grpc port", baseYAML, map[string]string{"GRPC_PORT": "8080"}, []string{"grpc.port: must differ"}},
+		{"listing watch", baseYAML, map[string]string{"LISTING_WATCH_INTERVAL": "100ms", "LISTING_WATCH_EXCHANGES": "upbit,okx"},
+			[]string{"listing_watch.interval", "listing_watch.exchanges"}},
+		{"notify", baseYAML + "notify:\n  channels:\n    - type: slack\n    - type: smtp\n      addr: mx:25\n      from: a@b\n      to: [c@d]\n      kinds: [listed]\n    - type: sms\n",
+			nil, []string{"notify.channels[0]: slack needs webhook_url", "notify.channels[1].kinds", "notify.channels[2]: type must be"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearEnv(t)
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+			_, err := Load(writeFile(t, "c.yaml", tc.file))
+			if err == nil {
+				t.Fatal("want error")
+			}
+			for _, w := range tc.want {
+				if !strings.Contains(err.Error(), w) {
+					t.Fatalf("error %q does not mention %q", err, w)
+				}
+			}
+		})
+	}
+}
+
+func TestStore_Reload(t *testing.T) {
+	clearEnv(t)
+	p := writeFile(t, "c.yaml", baseYAML)
+	c, err := Load(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewStore(p, c)
+	var seen []string
+	s.OnReload(func(c Config) { seen = append(seen, c.Admin.APIKey) })
+
+	next := strings.NewReplacer("k1", "k2", "15m", "20m", "5s", "9s").Replace(baseYAML) + "http:\n  port: 9999\n"
+	if err := os.WriteFile(p, []byte(next), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	restart, err := s.Reload()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := s.Get()
+	if got.Admin.APIKey != "k2" || got.AutoUpdate.Interval.D() != 20*time.Minute {
+		t.Fatalf("reloadable not applied: %+v", got)
+	}
+	if got.HTTP.Port != 8080 || got.Exchanges.HTTP.Timeout.D() != 5*time.Second {
+		t.Fatalf("non-reloadable applied: %+v", got)
+	}
+	if !slices.Equal(restart, []string{"http", "exchanges"}) || !slices.Equal(seen, []string{"k2"}) {
+		t.Fatalf("restart=%v seen=%v", restart, seen)
+	}
+
+	// невалидный файл — остаётся прежняя конфигурация
+	if err := os.WriteFile(p, []byte("log:\n  level: loud\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Reload(); err == nil {
+		t.Fatal("want error")
+	}
+	if s.Get().Admin.APIKey != "k2" || len(seen) != 1 {
+		t.Fatalf("config replaced by invalid one: %+v", s.Get())
+	}
+}
+
+func TestActive_LoadQuotes(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("SOURCE_SPOT_QUOTE", "USDC")
+	if q := LoadQuotes(); q.SourceSpotQuote != "USDC" {
+		t.Fatalf("env: %+v", q)
+	}
+	c := Defaults()
+	c.Quotes.TargetAllowed = []string{"KRW"}
+	SetActive(NewStore("", c))
+	defer SetActive(nil)
+	if q := LoadQuotes(); q.SourceSpotQuote != "USDT" || len(q.TargetAllowedQuotes) != 1 {
+		t.Fatalf("active: %+v", q)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	c := Defaults()
+	c.Admin.APIKey = "k"
+	c.DB.DSN = "host=db user=app password='p w' dbname=t"
+	r := c.Redacted()
+	if r.Admin.APIKey != "xxxxx" || r.DB.DSN != "host=db user=app password=xxxxx dbname=t" {
+		t.Fatalf("%+v", r)
+	}
+	if c.Admin.APIKey != "k" {
+		t.Fatal("original modified")
+	}
+
+	c.DB.DSN = "postgres://app:secret@db:5432/t?password=q1&sslmode=disable"
+	if got := c.Redacted().DB.DSN; got != "postgres://app:xxxxx@db:5432/t?password=xxxxx&sslmode=disable" {
+		t.Fatalf("url dsn: %s", got)
+	}
+
+	c.Notify.Channels = []NotifyChannel{{Type: "telegram", BotToken: "123:abc", ChatID: "-1"}}
+	r = c.Redacted()
+	if r.Notify.Channels[0].BotToken != "xxxxx" || r.Notify.Channels[0].ChatID != "-1" || c.Notify.Channels[0].BotToken != "123:abc" {
+		t.Fatalf("notify: %+v / %+v", r.Notify.Channels, c.Notify.Channels)
+	}
+}
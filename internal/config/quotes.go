@@ -10,7 +10,11 @@ type QuotesConfig struct {
 	TargetAllowedQuotes map[string]struct{}
 }
 
+// LoadQuotes — котировки из активной конфигурации (см. SetActive), иначе из env.
 func LoadQuotes() QuotesConfig {
+	if c, ok := Active(); ok {
+		return c.QuotesConfig()
+	}
 	src := getenv("SOURCE_SPOT_QUOTE", "USDT")
 	tgt := getenv("TARGET_ALLOWED_QUOTES", "USDT,USD,KRW")
 	return QuotesConfig{
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// ReloadableKeys — что применяется по SIGHUP без рестарта. Остальное — только при старте.
+var ReloadableKeys = []string{"admin.api_key", "log.level", "quotes", "auto_update.interval"}
+
+// Store — текущая конфигурация + перечитывание файла.
+type Store struct {
+	path     string
+	cur      atomic.Pointer[Config]
+	loadedAt atomic.Pointer[time.Time]
+
+	mu   sync.Mutex // сериализует Reload и подписчиков
+	subs []func(Config)
+}
+
+func NewStore(path string, cfg Config) *Store {
+	s := &Store{path: path}
+	s.set(cfg)
+	return s
+}
+
+func (s *Store) set(cfg Config) {
+	now := time.Now().UTC()
+	s.cur.Store(&cfg)
+	s.loadedAt.Store(&now)
+}
+
+func (s *Store) Get() Config         { return *s.cur.Load() }
+func (s *Store) Path() string        { return s.path }
+func (s *Store) LoadedAt() time.Time { return *s.loadedAt.Load() }
+
+// OnReload — fn вызывается после каждого успешного Reload с новой конфигурацией.
+func (s *Store) OnReload(fn func(Config)) {
+	s.mu.Lock()
+	s.subs = append(s.subs, fn)
+	s.mu.Unlock()
+}
+
+// Reload перечитывает файл и env. При ошибке валидации остаётся старая конфигурация.
+// Применяются только ReloadableKeys; изменённые нерелоадируемые секции возвращаются в restart.
+func (s *Store) Reload() (restart []string, err error) {
+	next, err := Load(s.path)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur := s.Get()
+	restart = restartRequired(cur, next)
+	cur.Admin.APIKey = next.Admin.APIKey
+	cur.Log.Level = next.Log.Level
+	cur.Quotes = next.Quotes
+	cur.AutoUpdate.Interval = next.AutoUpdate.Interval
+	s.set(cur)
+	for _, fn := range s.subs {
+		fn(cur)
+	}
+	return restart, nil
+}
+
+// restartRequired — секции, отличающиеся вне ReloadableKeys.
+func restartRequired(old, next Config) []string {
+	strip := func(c Config) Config {
+		c.Admin.APIKey, c.Log.Level = "", ""
+		c.Quotes = QuotesSection{}
+		c.AutoUpdate.Interval = 0
+		return c
+	}
+	o, n := strip(old), strip(next)
+	var out []string
+	check := func(key string, a, b any) {
+		if !reflect.DeepEqual(a, b) {
+			out = append(out, key)
+		}
+	}
+	check("http", o.HTTP, n.HTTP)
+	check("grpc", o.GRPC, n.GRPC)
+	check("db", o.DB, n.DB)
+	check("log.format", o.Log, n.Log)
+	check("exchanges", o.Exchanges, n.Exchanges)
+	check("auto_update.disable", o.AutoUpdate, n.AutoUpdate)
+	check("lists_cache", o.ListsCache, n.ListsCache)
+	check("listing_watch", o.ListingWatch, n.ListingWatch)
+	check("notify", o.Notify, n.Notify)
+	return out
+}
+
+// WatchSIGHUP перечитывает конфигурацию по SIGHUP до отмены ctx.
+func (s *Store) WatchSIGHUP(ctx context.Context) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(ch)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ch:
+				restart, err := s.Reload()
+				if err != nil {
+					log.Printf("config: reload rejected, keeping previous: %v", err)
+					continue
+				}
+				log.Printf("config: reloaded %s", s.path)
+				if len(restart) > 0 {
+					log.Printf("config: changes in %v need a restart, ignored", restart)
+				}
+			}
+		}
+	}()
+}
+
+// active — конфигурация процесса для мест, которые читают её без явной передачи (LoadQuotes и т.п.).
+var active atomic.Pointer[Store]
+
+// SetActive делает s источником для LoadQuotes; nil — снова env (тесты).
+func SetActive(s *Store) { active.Store(s) }
+
+// Active — текущая конфигурация процесса, если она загружена.
+func Active() (Config, bool) {
+	s := active.Load()
+	if s == nil {
+		return Config{}, false
+	}
+	return s.Get(), true
+}
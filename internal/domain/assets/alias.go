@@ -0,0 +1,89 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrUnknownExchange = errors.New("unknown exchange")
+
+// Alias — биржевой тикер базы → канонический ID актива.
+// ExchangeID == 0 — алиас действует на всех биржах.
+type Alias struct {
+	ExchangeID int16
+	Exchange   string // slug; "" для глобальных
+	Ticker     string // как на бирже: MATIC, BTT
+	Asset      string // канонический ID: POL, BTTC
+	UpdatedAt  time.Time
+}
+
+type AliasRepo interface {
+	ListAliases(ctx context.Context) ([]Alias, error)
+	// exchange: slug биржи или "" (глобальный алиас)
+	UpsertAlias(ctx context.Context, exchange, ticker, asset string) (Alias, error)
+	DeleteAlias(ctx context.Context, exchange, ticker string) (bool, error)
+}
+
+// Resolver приводит базовые тикеры к каноническим активам.
+// Нулевое значение валидно и ничего не переименовывает.
+type Resolver struct {
+	global map[string]string
+	byEx   map[int16]map[string]string
+}
+
+func NewResolver(aliases []Alias) Resolver {
+	r := Resolver{global: map[string]string{}, byEx: map[int16]map[string]string{}}
+	for _, a := range aliases {
+		t, asset := strings.ToUpper(a.Ticker), strings.ToUpper(a.Asset)
+		if a.ExchangeID == 0 {
+			r.global[t] = asset
+			continue
+		}
+		if r.byEx[a.ExchangeID] == nil {
+			r.byEx[a.ExchangeID] = map[string]string{}
+		}
+		r.byEx[a.ExchangeID][t] = asset
+	}
+	return r
+}
+
+// Canon: алиас биржи → глобальный алиас → сам тикер (в верхнем регистре).
+func (r Resolver) Canon(exchangeID int16, base string) string {
+	b := strings.ToUpper(base)
+	if a, ok := r.byEx[exchangeID][b]; ok {
+		return a
+	}
+	if a, ok := r.global[b]; ok {
+		return a
+	}
+	return b
+}
+
+func (r Resolver) Empty() bool { return len(r.global) == 0 && len(r.byEx) == 0 }
+
+// Tickers — сам актив и все тикеры, которые к нему приводятся (на любой бирже), по алфавиту.
+func (r Resolver) Tickers(asset string) []string {
+	a := strings.ToUpper(asset)
+	set := map[string]struct{}{a: {}}
+	for t, to := range r.global {
+		if to == a {
+			set[t] = struct{}{}
+		}
+	}
+	for _, m := range r.byEx {
+		for t, to := range m {
+			if to == a {
+				set[t] = struct{}{}
+			}
+		}
+	}
+	out := make([]string, 0, len(set))
+	for t := range set {
+		out = append(out, t)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,18 @@
+package assets
+
+import (
+	"errors"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+var ErrAssetNotFound = errors.New("asset not found")
+
+// View — всё об одном активе: где торгуется (с учётом алиасов и множителей) и в каких списках.
+type View struct {
+	Asset   string
+	Tickers []string         // Asset + алиасы
+	Markets []markets.Market // спот и фьючерсы, включая делистнутые
+	Lists   []lists.Membership
+}
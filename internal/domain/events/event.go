@@ -0,0 +1,44 @@
+// Package events — события сервиса для внешних получателей (уведомления, стримы).
+package events
+
+import (
+	"context"
+	"time"
+)
+
+// Kind — тип события.
+type Kind string
+
+const (
+	KindListing   Kind = "listing"   // новый рынок на бирже (детектор листингов, полный синк)
+	KindDelisting Kind = "delisting" // рынок пропал из снимка биржи и ушёл в архив (полный синк)
+)
+
+// Priority — срочность доставки.
+type Priority string
+
+const (
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+)
+
+// Event — одно событие о рынке.
+type Event struct {
+	Kind     Kind
+	Priority Priority
+	At       time.Time
+
+	Exchange string // slug биржи
+	Market   string // spot|futures
+	Symbol   string
+	Base     string
+	Quote    string
+
+	Generation int64    // поколение списков, в которое рынок уже попал (0 — пересборки не было)
+	Lists      []string // slug пересобранных списков и сегментов
+}
+
+// Publisher — получатель событий. Publish не должен блокироваться на доставке.
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
@@ -1,6 +1,10 @@
 package lists
 
-import "context"
+import (
+	"context"
+
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
 
 type Def struct {
 	ID          int16
@@ -9,6 +13,17 @@ type Def struct {
 	SourceSlug  string
 	TargetID    int16
 	TargetSlug  string
+	FuturesKinds []dm.ContractKind // какие контракты идут в колонку фьючерсов; nil → linear_perp
+}
+
+// SegmentDef — сегмент из list_defs: выражение над множествами присутствия (см. pkg/setexpr).
+type SegmentDef struct {
+	ID           int16
+	Slug         string
+	SourceSlug   string
+	Segment      string // seg1, seg4, ...
+	Expr         string // "S & U & !(C | H)"
+	FuturesKinds []dm.ContractKind
 }
 
 type DefsRepo interface {
@@ -17,6 +32,8 @@ type DefsRepo interface {
     GetByID(ctx context.Context, id int16) (Def, error)
 
     IDsBySlugs(ctx context.Context, slugs []string) (map[string]int16, error)
+    // все сегменты (list_kind = 'segment') с выражениями
+    SegmentDefs(ctx context.Context) ([]SegmentDef, error)
 }
 
 type QueryRepo interface {
@@ -28,4 +45,21 @@ type QueryRepo interface {
 	GetAllText(ctx context.Context) (map[string]map[string][]string, error)
 	
 	GetRowsBySlug(ctx context.Context, slug string) ([]Row, error)
+	// For /api/lists?target= in tabular formats - rows grouped by source
+	GetRowsByTarget(ctx context.Context, targetSlug string) (map[string][]Row, error)
+	// List metadata (ErrNotFound if slug is unknown)
+	GetMeta(ctx context.Context, slug string) (Meta, error)
+	// Фильтр и keyset-страница строк списка / всех списков цели (ErrBadCursor)
+	FindRowsBySlug(ctx context.Context, slug string, f RowsFilter) (RowsPage, error)
+	FindRowsByTarget(ctx context.Context, targetSlug string, f RowsFilter) (RowsPage, error)
+}
+
+// MetaLister — метаданные всех списков и сегментов (gRPC ListLists); по slug.
+type MetaLister interface {
+	ListMetas(ctx context.Context) ([]Meta, error)
+}
+
+type MembershipRepo interface {
+	// Все вхождения в списки, где база спота источника — одна из bases
+	MembershipsByBases(ctx context.Context, bases []string) ([]Membership, error)
 }
@@ -0,0 +1,30 @@
+package lists
+
+import "errors"
+
+// ErrBadCursor — курсор испорчен или выдан для другой выборки.
+var ErrBadCursor = errors.New("bad cursor")
+
+// Порядок строк в RowsPage.
+const (
+	SortSpot   = "spot"   // по (source, spot) — по умолчанию
+	SortVolume = "volume" // по 24h-обороту спота в USD, сначала крупные; без оборота — в конце
+)
+
+// RowsFilter — поиск и страница по строкам списка (или всех списков цели); пустые поля не фильтруют.
+type RowsFilter struct {
+	Q          string   // префикс базы или подстрока spot-символа, без учёта регистра
+	HasFutures *bool    // есть ли фьючерс в строке
+	Quotes     []string // котировка спота
+	MinVolume  float64  // минимальный 24h-оборот спота в USD; > 0 отсекает строки без оборота
+	Sort       string   // SortSpot | SortVolume; пусто — SortSpot
+	Limit      int      // 0 — все строки
+	Cursor     string   // непрозрачный, из RowsPage.NextCursor
+}
+
+// RowsPage — страница в порядке RowsFilter.Sort; Total — сколько строк подходит под фильтр всего.
+type RowsPage struct {
+	Rows       []Row
+	Total      int
+	NextCursor string
+}
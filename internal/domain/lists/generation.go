@@ -0,0 +1,34 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrGenerationNotFound — поколение не публиковалось или уже удалено (хранятся последние несколько).
+var ErrGenerationNotFound = errors.New("generation not found")
+
+// Generation — опубликованный срез всех списков. Пересборка пишет новое поколение целиком
+// и переключает на него указатель одной транзакцией: читатель видит либо старые списки, либо новые.
+type Generation struct {
+	ID        int64
+	CreatedAt time.Time
+}
+
+type GenerationRepo interface {
+	// Generation: id == 0 — текущее опубликованное.
+	Generation(ctx context.Context, id int64) (Generation, error)
+}
+
+type generationKey struct{}
+
+// WithGeneration закрепляет чтение списков за поколением (?generation=); без него — текущее.
+func WithGeneration(ctx context.Context, id int64) context.Context {
+	return context.WithValue(ctx, generationKey{}, id)
+}
+
+func GenerationFrom(ctx context.Context) (int64, bool) {
+	id, ok := ctx.Value(generationKey{}).(int64)
+	return id, ok && id > 0
+}
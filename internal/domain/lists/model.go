@@ -1,7 +1,54 @@
 package lists
 
+import (
+	"errors"
+	"time"
+)
+
+// ErrNotFound — список с таким slug не заведён в list_defs.
+var ErrNotFound = errors.New("list not found")
+
+// ErrExists — slug уже занят в list_defs.
+var ErrExists = errors.New("list already exists")
 
 type Row struct {
-	Spot    string 
+	Spot    string
 	Futures *string
+
+	// Заполняются только при чтении из list_items (JOIN markets) — нужны для нотаций.
+	Source        string // slug биржи-источника
+	Base          string
+	Quote         string
+	FuturesBase   string
+	FuturesQuote  string
+	FuturesKind   string     // linear_perp | inverse_perp | delivery
+	FuturesSettle string     // валюта расчёта фьючерса
+	FuturesExpiry *time.Time // только delivery
+	VolumeUSD     *float64   // 24h-оборот спота в USD (markets.volume_usd_24h); nil — неизвестен
+}
+
+// Meta — описание списка из list_defs (для форматов с метаданными).
+type Meta struct {
+	Slug       string
+	Kind       string // target|segment
+	SourceSlug string
+	TargetSlug string // "" для сегментов
+	Segment    string // "" для target-списков
+	Expr       string // выражение сегмента; "" для target-списков
+	UpdatedAt  time.Time
+	Count      int
+}
+
+// Membership — строка list_items глазами актива: в каком списке он есть и с какого момента.
+type Membership struct {
+	Slug       string
+	Kind       string // target|segment
+	SourceID   int16
+	SourceSlug string
+	TargetSlug string // "" для сегментов
+	Segment    string // "" для target-списков
+	Base       string // база спота источника
+	Spot       string
+	Futures    *string
+	Since      time.Time // created_at; переживает пересборки, пока символ остаётся в списке
 }
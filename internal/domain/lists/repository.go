@@ -16,4 +16,13 @@ type Repo interface {
 	ReplaceBySlug(ctx context.Context, slug string, items []Item) (inserted int, err error)
 
 	GetRowsBySlug(ctx context.Context, slug string) ([]Row, error)
+
+	// Publish — новое поколение: lists заменяются, остальные списки переносятся как есть.
+	Publish(ctx context.Context, lists map[int16][]Item) (Generation, error)
+}
+
+// CurrentReader — опционально у Repo: содержимое списков текущего поколения (list_id → items).
+// Пустые списки в ответ не попадают. Нужен, чтобы не переписывать списки, которые не изменились.
+type CurrentReader interface {
+	CurrentItems(ctx context.Context) (map[int16][]Item, error)
 }
@@ -0,0 +1,104 @@
+package markets
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ContractKind — вид фьючерсного контракта (у спота пусто).
+type ContractKind string
+
+const (
+	// бессрочный, маржа и расчёт в quote (USDT/USDC): BTCUSDT, BTC-USDT-SWAP
+	ContractLinearPerp ContractKind = "linear_perp"
+	// бессрочный coin-margined, расчёт в базовой монете: BTCUSD_PERP, BTC-USD-SWAP
+	ContractInversePerp ContractKind = "inverse_perp"
+	// срочный с датой экспирации (линейный или инверсный — см. Settle): BTCUSDT_250926
+	ContractDelivery ContractKind = "delivery"
+)
+
+// DefaultContractKinds — что попадает в колонку фьючерсов списка, если правило не задано.
+var DefaultContractKinds = []ContractKind{ContractLinearPerp}
+
+var ErrUnknownContractKind = errors.New("unknown contract kind")
+
+// ParseContractKinds: "linear_perp,delivery" → kinds; пустая строка → nil.
+func ParseContractKinds(s string) ([]ContractKind, error) {
+	var out []ContractKind
+	for _, p := range strings.Split(s, ",") {
+		k := ContractKind(strings.ToLower(strings.TrimSpace(p)))
+		switch k {
+		case "":
+			continue
+		case ContractLinearPerp, ContractInversePerp, ContractDelivery:
+			out = append(out, k)
+		default:
+			return nil, ErrUnknownContractKind
+		}
+	}
+	return out, nil
+}
+
+// Kind — вид контракта; фьючерсы без явного вида (старые снапшоты) считаются linear_perp.
+func (it Item) Kind() ContractKind {
+	if it.Type != TypeFutures {
+		return ""
+	}
+	if it.Contract == "" {
+		return ContractLinearPerp
+	}
+	return it.Contract
+}
+
+// rank — порядок предпочтения при выборе одного фьючерса на базу:
+// бессрочные раньше срочных, линейные раньше инверсных.
+func (k ContractKind) rank() int {
+	switch k {
+	case ContractLinearPerp, "":
+		return 0
+	case ContractInversePerp:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Preferred: it лучше cur для колонки фьючерсов (вид → множитель → ближняя экспирация).
+func (it Item) Preferred(cur Item) bool {
+	if a, b := it.Kind().rank(), cur.Kind().rank(); a != b {
+		return a < b
+	}
+	if a, b := it.Mult(), cur.Mult(); a != b {
+		return a < b
+	}
+	return it.Expiry != nil && cur.Expiry != nil && it.Expiry.Before(*cur.Expiry)
+}
+
+// FilterContracts оставляет спот и фьючерсы только указанных видов (nil → DefaultContractKinds).
+func FilterContracts(items []Item, kinds []ContractKind) []Item {
+	if len(kinds) == 0 {
+		kinds = DefaultContractKinds
+	}
+	allow := make(map[ContractKind]bool, len(kinds))
+	for _, k := range kinds {
+		allow[k] = true
+	}
+	out := make([]Item, 0, len(items))
+	for _, it := range items {
+		if it.Type == TypeFutures && !allow[it.Kind()] {
+			continue
+		}
+		out = append(out, it)
+	}
+	return out
+}
+
+// ExpiryFromMillis — биржи отдают экспирацию в мс; "0"/"" у бессрочных → nil.
+func ExpiryFromMillis(ms int64) *time.Time {
+	if ms <= 0 {
+		return nil
+	}
+	t := time.UnixMilli(ms).UTC()
+	return &t
+}
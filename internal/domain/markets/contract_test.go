@@ -0,0 +1,42 @@
+package markets_test
+
+import (
+	"testing"
+
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+func TestParseContractKinds(t *testing.T) {
+	got, err := dm.ParseContractKinds(" linear_perp, DELIVERY ,")
+	if err != nil || len(got) != 2 || got[0] != dm.ContractLinearPerp || got[1] != dm.ContractDelivery {
+		t.Fatalf("got=%v err=%v", got, err)
+	}
+	if got, err := dm.ParseContractKinds(""); err != nil || got != nil {
+		t.Fatalf("empty: got=%v err=%v", got, err)
+	}
+	if _, err := dm.ParseContractKinds("option"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestFilterContracts(t *testing.T) {
+	items := []dm.Item{
+		{Type: dm.TypeSpot, Symbol: "BTCUSDT"},
+		{Type: dm.TypeFutures, Symbol: "BTCUSDT"}, // старый снапшот без вида → linear_perp
+		{Type: dm.TypeFutures, Symbol: "BTCUSD_PERP", Contract: dm.ContractInversePerp},
+		{Type: dm.TypeFutures, Symbol: "BTCUSDT_250926", Contract: dm.ContractDelivery},
+	}
+	syms := func(items []dm.Item) (out []string) {
+		for _, it := range items {
+			out = append(out, it.Symbol)
+		}
+		return out
+	}
+	if got := syms(dm.FilterContracts(items, nil)); len(got) != 2 || got[1] != "BTCUSDT" {
+		t.Fatalf("default: %v", got)
+	}
+	got := syms(dm.FilterContracts(items, []dm.ContractKind{dm.ContractInversePerp, dm.ContractDelivery}))
+	if len(got) != 3 || got[0] != "BTCUSDT" || got[1] != "BTCUSD_PERP" || got[2] != "BTCUSDT_250926" {
+		t.Fatalf("inverse+delivery: %v", got)
+	}
+}
@@ -1,5 +1,7 @@
 package markets
 
+import "time"
+
 type Type string
 
 const (
@@ -8,11 +10,17 @@ const (
 )
 
 type Item struct {
-	ExchangeID   int16
-	Type         Type
-	Symbol       string
-	Base         string
-	Quote        string
-	ContractSize *int64 // nil for spot
-	Active       bool   // true by defaut
+	ExchangeID int16
+	Type       Type
+	Symbol     string
+	Base       string
+	Quote      string
+	Active     bool  // true by defaut
+	Multiplier int64 // 1000 для 1000PEPEUSDT (см. AnnotateMultipliers); 0/1 — без множителя
+	Specs            // tick/lot/min notional/contract size, если биржа их отдаёт
+
+	// только фьючерсы
+	Contract ContractKind // linear_perp | inverse_perp | delivery; "" → linear_perp
+	Settle   string       // валюта расчёта: USDT у линейных, BTC у BTCUSD_PERP
+	Expiry   *time.Time   // nil у бессрочных
 }
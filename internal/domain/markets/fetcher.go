@@ -8,3 +8,11 @@ type Fetcher interface {
 	FetchSpot(ctx context.Context) ([]Item, error)
 	FetchFutures(ctx context.Context) ([]Item, error) // может вернуть nil,nil если нет фьючей
 }
+
+// ListingPoller — опционально у Fetcher: дешёвый список спот-рынков для частого опроса (детектор листингов).
+// changed == false — биржа ответила 304, список тот же, что в прошлый раз (items == nil).
+type ListingPoller interface {
+	ExchangeID() int16
+	Name() string
+	PollSpot(ctx context.Context) (items []Item, changed bool, err error)
+}
@@ -0,0 +1,55 @@
+package markets
+
+import (
+	"errors"
+	"time"
+)
+
+// Market — строка markets целиком: Item + slug биржи и даты листинга.
+type Market struct {
+	ID int64
+	Item
+	Exchange   string
+	ListedAt   time.Time
+	DelistedAt *time.Time // nil — торгуется
+}
+
+// Sort — ключ сортировки выдачи GET /api/markets.
+type Sort string
+
+const (
+	SortSymbol     Sort = "symbol"
+	SortBase       Sort = "base"
+	SortListedAt   Sort = "listed_at"
+	SortDelistedAt Sort = "delisted_at" // торгующиеся (NULL) — в конце
+	SortID         Sort = "id"
+)
+
+var (
+	ErrBadSort   = errors.New("unknown sort")
+	ErrBadCursor = errors.New("bad cursor")
+)
+
+// Filter — выборка рынков; пустые поля не фильтруют.
+type Filter struct {
+	Exchanges []string // slug-и бирж
+	Bases     []string
+	Quotes    []string
+	Type      Type
+	Contracts []ContractKind
+	Active    *bool
+
+	ListedFrom, ListedTo     *time.Time // [from, to)
+	DelistedFrom, DelistedTo *time.Time // [from, to); задан хоть один — только архивные
+
+	Sort   Sort // "" → symbol
+	Desc   bool
+	Limit  int
+	Cursor string // непрозрачный, из Page.NextCursor
+}
+
+// Page — страница выдачи; NextCursor == "" — дальше пусто.
+type Page struct {
+	Items      []Market
+	NextCursor string
+}
@@ -0,0 +1,86 @@
+package markets
+
+import "strings"
+
+// Префиксы-множители перпов: 1000PEPE, 10000LADYS, 1000000MOG, 1MBABYDOGE.
+// "1INCH" и т.п. не трогаем — множитель только 10^k, k ≥ 3, или "1M".
+var multPrefixes = []struct {
+	pfx  string
+	mult int64
+}{
+	// длинные раньше коротких
+	{"100000000", 100_000_000},
+	{"10000000", 10_000_000},
+	{"1000000", 1_000_000},
+	{"100000", 100_000},
+	{"10000", 10_000},
+	{"1000", 1_000},
+	{"1M", 1_000_000},
+}
+
+// ParseMultiplier отделяет префикс-множитель от базы: "1000PEPE" → ("PEPE", 1000, true).
+// Остаток должен начинаться с буквы, иначе это не множитель ("1000" → false).
+func ParseMultiplier(base string) (core string, mult int64, ok bool) {
+	b := strings.ToUpper(strings.TrimSpace(base))
+	for _, p := range multPrefixes {
+		rest, found := strings.CutPrefix(b, p.pfx)
+		if !found || rest == "" {
+			continue
+		}
+		if c := rest[0]; c < 'A' || c > 'Z' {
+			continue
+		}
+		return rest, p.mult, true
+	}
+	return b, 1, false
+}
+
+// AnnotateMultipliers проставляет Multiplier фьючерсам с префиксом-множителем.
+// Если такая «база с префиксом» сама торгуется на споте этой биржи (1000SATS, 1000CAT) —
+// это отдельный токен, а не множитель, и фьючерс остаётся как есть.
+func AnnotateMultipliers(spot, futures []Item) {
+	spotBases := make(map[string]struct{}, len(spot))
+	for _, it := range spot {
+		spotBases[strings.ToUpper(it.Base)] = struct{}{}
+	}
+	for i := range futures {
+		it := &futures[i]
+		if it.Type != TypeFutures {
+			continue
+		}
+		if _, ok := spotBases[strings.ToUpper(it.Base)]; ok {
+			continue
+		}
+		if _, m, ok := ParseMultiplier(it.Base); ok {
+			it.Multiplier = m
+		}
+	}
+}
+
+// Mult — множитель контракта (0 в старых данных трактуем как 1).
+func (it Item) Mult() int64 {
+	if it.Multiplier <= 1 {
+		return 1
+	}
+	return it.Multiplier
+}
+
+// Underlying — база, к которой относится инструмент: для 1000PEPE (x1000) это PEPE.
+func (it Item) Underlying() string {
+	if it.Mult() > 1 {
+		if core, _, ok := ParseMultiplier(it.Base); ok {
+			return core
+		}
+	}
+	return strings.ToUpper(it.Base)
+}
+
+// MultiplierVariants — базы с префиксами-множителями для core: PEPE → 1000PEPE, 1MPEPE, ...
+func MultiplierVariants(core string) []string {
+	b := strings.ToUpper(strings.TrimSpace(core))
+	out := make([]string, 0, len(multPrefixes))
+	for _, p := range multPrefixes {
+		out = append(out, p.pfx+b)
+	}
+	return out
+}
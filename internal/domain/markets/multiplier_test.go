@@ -0,0 +1,50 @@
+package markets_test
+
+import (
+	"testing"
+
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+func TestParseMultiplier(t *testing.T) {
+	cases := []struct {
+		in   string
+		core string
+		mult int64
+		ok   bool
+	}{
+		{"1000PEPE", "PEPE", 1000, true},
+		{"10000LADYS", "LADYS", 10000, true},
+		{"1000000MOG", "MOG", 1000000, true},
+		{"1MBABYDOGE", "BABYDOGE", 1000000, true},
+		{"1INCH", "1INCH", 1, false},
+		{"1000", "1000", 1, false},
+		{"100X", "100X", 1, false},
+		{"PEPE", "PEPE", 1, false},
+	}
+	for _, tc := range cases {
+		core, mult, ok := dm.ParseMultiplier(tc.in)
+		if core != tc.core || mult != tc.mult || ok != tc.ok {
+			t.Fatalf("%s: got (%s,%d,%v)", tc.in, core, mult, ok)
+		}
+	}
+}
+
+func TestAnnotateMultipliers_SkipsRealPrefixedTokens(t *testing.T) {
+	spot := []dm.Item{
+		{Type: dm.TypeSpot, Base: "PEPE", Quote: "USDT", Symbol: "PEPEUSDT"},
+		{Type: dm.TypeSpot, Base: "1000SATS", Quote: "USDT", Symbol: "1000SATSUSDT"},
+	}
+	fut := []dm.Item{
+		{Type: dm.TypeFutures, Base: "1000PEPE", Quote: "USDT", Symbol: "1000PEPEUSDT"},
+		{Type: dm.TypeFutures, Base: "1000SATS", Quote: "USDT", Symbol: "1000SATSUSDT"},
+	}
+	dm.AnnotateMultipliers(spot, fut)
+
+	if fut[0].Multiplier != 1000 || fut[0].Underlying() != "PEPE" {
+		t.Fatalf("1000PEPE: %+v underlying=%s", fut[0], fut[0].Underlying())
+	}
+	if fut[1].Mult() != 1 || fut[1].Underlying() != "1000SATS" {
+		t.Fatalf("1000SATS is a real token: %+v", fut[1])
+	}
+}
@@ -3,6 +3,26 @@ package markets
 import "context"
 
 type Repo interface {
-	SyncSnapshot(ctx context.Context, exchangeID int16, items []Item) (added, updated, archived int, err error)
+	// types — типы, снимок которых получен целиком: архивируются только их отсутствующие рынки
+	SyncSnapshot(ctx context.Context, exchangeID int16, types []Type, items []Item) (added, updated, archived int, err error)
 	LoadActiveByExchange(ctx context.Context, exchangeID int16) ([]Item, error)
 }
+
+// SnapshotLoader — активные рынки нескольких бирж одним запросом: согласованный срез для пересборки списков.
+type SnapshotLoader interface {
+	LoadActiveByExchanges(ctx context.Context, exchangeIDs ...int16) (map[int16][]Item, error)
+}
+
+// QueryRepo — чтение рынков для API (включая архивные).
+type QueryRepo interface {
+	// GetMarkets: все рынки биржи с этим символом — spot и futures могут совпадать (BTCUSDT).
+	GetMarkets(ctx context.Context, exchange, symbol string) ([]Market, error)
+	// FindMarkets: фильтры + keyset-пагинация по (ключ сортировки, id).
+	FindMarkets(ctx context.Context, f Filter) (Page, error)
+}
+
+// Appender — добавить появившиеся рынки (и вернуть в оборот архивные) без архивации отсутствующих:
+// частичный снимок детектора листингов не должен снимать с торгов то, чего в нём нет.
+type Appender interface {
+	AddMarkets(ctx context.Context, exchangeID int16, items []Item) (added int, err error)
+}
@@ -0,0 +1,59 @@
+package markets
+
+import "strings"
+
+// Decimal — десятичное число в каноничной строковой форме ("0.1", "1000", "0.00001").
+// Биржи отдают шаги цены/объёма строками; float64 их портит, поэтому храним как есть.
+// "" — значение неизвестно (биржа не отдаёт или не распарсилось).
+type Decimal string
+
+// ParseDecimal нормализует строку биржи: "0.01000000" → "0.01", "001" → "1", "1." → "1".
+// Отрицательные, экспоненты и мусор → "".
+func ParseDecimal(s string) Decimal {
+	s = strings.TrimSpace(s)
+	intPart, frac, hasDot := strings.Cut(s, ".")
+	if intPart == "" && frac == "" {
+		return ""
+	}
+	if !digits(intPart) || !digits(frac) {
+		return ""
+	}
+	intPart = strings.TrimLeft(intPart, "0")
+	if intPart == "" {
+		intPart = "0"
+	}
+	if hasDot {
+		frac = strings.TrimRight(frac, "0")
+	}
+	if frac == "" {
+		return Decimal(intPart)
+	}
+	return Decimal(intPart + "." + frac)
+}
+
+func digits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (d Decimal) String() string { return string(d) }
+
+// IsZero: пусто или "0" — для биржевых фильтров это одно и то же «нет ограничения».
+func (d Decimal) IsZero() bool { return d == "" || d == "0" }
+
+// Specs — торговые параметры инструмента. Все поля опциональны.
+type Specs struct {
+	ContractSize Decimal // номинал контракта в базовой валюте (OKX ctVal, Bybit contractSize); только фьючерсы
+	TickSize     Decimal // шаг цены
+	LotSize      Decimal // шаг количества (в контрактах для OKX SWAP)
+	MinQty       Decimal // минимальное количество в заявке
+	MinNotional  Decimal // минимальная сумма заявки в quote
+	MaxLeverage  Decimal // максимальное плечо; только фьючерсы
+}
+
+// Empty: биржа не отдала ни одного параметра.
+func (s Specs) Empty() bool { return s == Specs{} }
@@ -0,0 +1,30 @@
+package markets_test
+
+import (
+	"testing"
+
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+func TestParseDecimal(t *testing.T) {
+	for in, want := range map[string]dm.Decimal{
+		"0.01000000": "0.01",
+		"0.1":        "0.1",
+		"1":          "1",
+		"001":        "1",
+		"10.":        "10",
+		".5":         "0.5",
+		"100.000":    "100",
+		"0":          "0",
+		" 0.0001 ":   "0.0001",
+		"":           "",
+		".":          "",
+		"-1":         "",
+		"1e-5":       "",
+		"abc":        "",
+	} {
+		if got := dm.ParseDecimal(in); got != want {
+			t.Fatalf("%q: got=%q want=%q", in, got, want)
+		}
+	}
+}
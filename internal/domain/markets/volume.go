@@ -0,0 +1,77 @@
+package markets
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Ticker — 24h статистика спот-рынка: последняя цена и оборот в котируемой валюте.
+type Ticker struct {
+	Symbol      string
+	Last        float64
+	QuoteVolume float64
+}
+
+// TickerFetcher — опционально у Fetcher: 24h тикеры спота одним запросом (или несколькими страницами).
+type TickerFetcher interface {
+	FetchSpotTickers(ctx context.Context) ([]Ticker, error)
+}
+
+// VolumeRepo — хранение последнего 24h-оборота в USD по спот-рынкам биржи.
+type VolumeRepo interface {
+	// SaveVolumes: symbol → оборот в USD; рынки, которых нет в vols, не трогаются.
+	SaveVolumes(ctx context.Context, exchangeID int16, vols map[string]float64, at time.Time) (int, error)
+}
+
+// usdQuotes — котировки, оборот в которых считаем равным обороту в USD.
+var usdQuotes = map[string]bool{"USD": true, "USDT": true, "USDC": true, "FDUSD": true, "DAI": true, "TUSD": true}
+
+// USDVolumes переводит оборот тикеров в USD. base/quote берутся из спот-рынков той же биржи,
+// курс котировки — из её же пары со стейблкоином (BTCUSDT для BTC, KRW-USDT для KRW).
+// Тикеры без рынка или с неизвестным курсом котировки пропускаются.
+func USDVolumes(tickers []Ticker, spot []Item) map[string]float64 {
+	bySymbol := make(map[string]Item, len(spot))
+	for _, it := range spot {
+		if it.Type == TypeSpot {
+			bySymbol[it.Symbol] = it
+		}
+	}
+
+	// курс в USD: quote=стейбл → Last; base=стейбл → 1/Last
+	rate := map[string]float64{}
+	for _, t := range tickers {
+		it, ok := bySymbol[t.Symbol]
+		if !ok || t.Last <= 0 {
+			continue
+		}
+		base, quote := strings.ToUpper(it.Base), strings.ToUpper(it.Quote)
+		switch {
+		case usdQuotes[base] && usdQuotes[quote]:
+		case usdQuotes[quote]:
+			if _, seen := rate[base]; !seen {
+				rate[base] = t.Last
+			}
+		case usdQuotes[base]:
+			if _, seen := rate[quote]; !seen {
+				rate[quote] = 1 / t.Last
+			}
+		}
+	}
+
+	out := make(map[string]float64, len(tickers))
+	for _, t := range tickers {
+		it, ok := bySymbol[t.Symbol]
+		if !ok || t.QuoteVolume < 0 {
+			continue
+		}
+		q := strings.ToUpper(it.Quote)
+		switch {
+		case usdQuotes[q]:
+			out[t.Symbol] = t.QuoteVolume
+		case rate[q] > 0:
+			out[t.Symbol] = t.QuoteVolume * rate[q]
+		}
+	}
+	return out
+}
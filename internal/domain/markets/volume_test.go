@@ -0,0 +1,34 @@
+package markets_test
+
+import (
+	"math"
+	"testing"
+
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+func TestUSDVolumes(t *testing.T) {
+	spot := []dm.Item{
+		{Type: dm.TypeSpot, Symbol: "KRW-USDT", Base: "USDT", Quote: "KRW"},
+		{Type: dm.TypeSpot, Symbol: "KRW-PEPE", Base: "PEPE", Quote: "KRW"},
+		{Type: dm.TypeSpot, Symbol: "USDT-ETH", Base: "ETH", Quote: "USDT"},
+		{Type: dm.TypeSpot, Symbol: "BTC-ETH", Base: "ETH", Quote: "BTC"}, // курса BTC нет
+	}
+	tickers := []dm.Ticker{
+		{Symbol: "KRW-USDT", Last: 1400, QuoteVolume: 14e9},
+		{Symbol: "KRW-PEPE", Last: 0.02, QuoteVolume: 2.8e9},
+		{Symbol: "USDT-ETH", Last: 3000, QuoteVolume: 5e6},
+		{Symbol: "BTC-ETH", Last: 0.05, QuoteVolume: 10},
+		{Symbol: "KRW-GONE", Last: 1, QuoteVolume: 1}, // рынка нет
+	}
+	got := dm.USDVolumes(tickers, spot)
+	want := map[string]float64{"KRW-USDT": 1e7, "KRW-PEPE": 2e6, "USDT-ETH": 5e6}
+	if len(got) != len(want) {
+		t.Fatalf("got %v", got)
+	}
+	for s, v := range want {
+		if math.Abs(got[s]-v) > 1e-6*v {
+			t.Fatalf("%s = %v, want %v", s, got[s], v)
+		}
+	}
+}
@@ -9,37 +9,47 @@ import (
 )
 
 type Middleware struct {
-	apiKey string
+	apiKey func() string
 }
 
 func NewFromEnv() *Middleware {
-	return &Middleware{
-		apiKey: strings.TrimSpace(os.Getenv("ADMIN_API_KEY")),
-	}
+	key := strings.TrimSpace(os.Getenv("ADMIN_API_KEY"))
+	return New(func() string { return key })
+}
+
+// New — ключ читается на каждый запрос (смена ключа по SIGHUP без рестарта).
+func New(key func() string) *Middleware {
+	return &Middleware{apiKey: key}
+}
+
+func (m *Middleware) checkKey(r *http.Request, apiKey string) bool {
+	return Match(apiKey, r.Header.Get("X-API-Key"), r.Header.Get("Authorization"))
 }
 
-func (m *Middleware) checkKey(r *http.Request) bool {
-	if m.apiKey == "" {
+// Match — X-API-Key или Authorization: Bearer совпадает с ключом (общая проверка для HTTP и gRPC).
+func Match(apiKey, xAPIKey, authorization string) bool {
+	if apiKey == "" {
 		return false
 	}
-	if k := strings.TrimSpace(r.Header.Get("X-API-Key")); k != "" {
-		return k == m.apiKey
+	if k := strings.TrimSpace(xAPIKey); k != "" {
+		return k == apiKey
 	}
 	const pfx = "Bearer "
-	if auth := strings.TrimSpace(r.Header.Get("Authorization")); strings.HasPrefix(auth, pfx) {
-		return strings.TrimSpace(auth[len(pfx):]) == m.apiKey
+	if auth := strings.TrimSpace(authorization); strings.HasPrefix(auth, pfx) {
+		return strings.TrimSpace(auth[len(pfx):]) == apiKey
 	}
 	return false
 }
 
 func (m *Middleware) Handler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if m.apiKey == "" {
+		apiKey := strings.TrimSpace(m.apiKey())
+		if apiKey == "" {
 			c.AbortWithStatusJSON(http.StatusInternalServerError,
 				gin.H{"error": "server not configured (ADMIN_API_KEY is empty)"})
 			return
 		}
-		if !m.checkKey(c.Request) {
+		if !m.checkKey(c.Request, apiKey) {
 			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
 			return
 		}
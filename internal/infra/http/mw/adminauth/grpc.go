@@ -0,0 +1,48 @@
+package adminauth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authorize — тот же ключ, что и у HTTP: metadata x-api-key или authorization: Bearer.
+func (m *Middleware) authorize(ctx context.Context) error {
+	apiKey := strings.TrimSpace(m.apiKey())
+	if apiKey == "" {
+		return status.Error(codes.Internal, "server not configured (ADMIN_API_KEY is empty)")
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(k string) string {
+		if v := md.Get(k); len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+	if !Match(apiKey, first("x-api-key"), first("authorization")) {
+		return status.Error(codes.PermissionDenied, "forbidden")
+	}
+	return nil
+}
+
+func (m *Middleware) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, h grpc.UnaryHandler) (any, error) {
+		if err := m.authorize(ctx); err != nil {
+			return nil, err
+		}
+		return h(ctx, req)
+	}
+}
+
+func (m *Middleware) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, h grpc.StreamHandler) error {
+		if err := m.authorize(ss.Context()); err != nil {
+			return err
+		}
+		return h(srv, ss)
+	}
+}
@@ -1,29 +1,52 @@
 package logx
 
 import (
+	"fmt"
 	"log/slog"
 	"os"
 	"strings"
 )
 
+// level — общий для всех логгеров из New; меняется через SetLevel (SIGHUP-reload).
+var level = new(slog.LevelVar)
+
 func New() *slog.Logger {
-	level := new(slog.LevelVar)
-	switch strings.ToLower(os.Getenv("LOG_LEVEL")) {
-	case "debug":
-		level.Set(slog.LevelDebug)
-	case "warn":
-		level.Set(slog.LevelWarn)
-	case "error":
-		level.Set(slog.LevelError)
-	default:
+	return NewWith(os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+}
+
+// NewWith — как New, но уровень/формат из конфигурации. Неизвестный уровень — info и предупреждение в лог.
+func NewWith(lvl, format string) *slog.Logger {
+	lvlErr := SetLevel(lvl)
+	if lvlErr != nil {
 		level.Set(slog.LevelInfo)
 	}
 
 	var h slog.Handler
-	if strings.ToLower(os.Getenv("LOG_FORMAT")) == "json" {
+	if strings.ToLower(format) == "json" {
 		h = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: level})
 	} else {
 		h = slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: level})
 	}
-	return slog.New(h)
+	l := slog.New(h)
+	if lvlErr != nil {
+		l.Warn("logx: using info", "err", lvlErr)
+	}
+	return l
+}
+
+// SetLevel: debug|info|warn|error (пусто — info). Неизвестный уровень — ошибка, текущий не меняется.
+func SetLevel(lvl string) error {
+	switch strings.ToLower(lvl) {
+	case "debug":
+		level.Set(slog.LevelDebug)
+	case "info", "":
+		level.Set(slog.LevelInfo)
+	case "warn":
+		level.Set(slog.LevelWarn)
+	case "error":
+		level.Set(slog.LevelError)
+	default:
+		return fmt.Errorf("unknown log level %q (debug, info, warn, error)", lvl)
+	}
+	return nil
 }
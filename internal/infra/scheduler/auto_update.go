@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"math/rand/v2"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -18,7 +19,27 @@ type AutoUpdater struct {
 	Interval time.Duration
 	Timeout  time.Duration
 
+	// IntervalFn — если задан, интервал перечитывается после каждого тика и по Retune (SIGHUP-reload).
+	IntervalFn func() time.Duration
+
 	running int32
+
+	retuneOnce sync.Once
+	retune     chan struct{}
+}
+
+// Retune — перечитать IntervalFn сейчас: иначе сокращённый интервал применится только после
+// тика по старому (до часа ожидания). Не блокирует.
+func (a *AutoUpdater) Retune() {
+	select {
+	case a.retuneCh() <- struct{}{}:
+	default:
+	}
+}
+
+func (a *AutoUpdater) retuneCh() chan struct{} {
+	a.retuneOnce.Do(func() { a.retune = make(chan struct{}, 1) })
+	return a.retune
 }
 
 func (a *AutoUpdater) Start(ctx context.Context) {
@@ -35,6 +56,16 @@ func (a *AutoUpdater) Start(ctx context.Context) {
 	time.Sleep(time.Duration(5+rand.IntN(20)) * time.Second)
 
 	t := time.NewTicker(interval)
+	setInterval := func() {
+		if a.IntervalFn != nil {
+			if d := a.IntervalFn(); d > 0 && d != interval {
+				log.Printf("auto-update: interval %s -> %s", interval, d)
+				interval = d
+				t.Reset(interval)
+			}
+		}
+	}
+	retune := a.retuneCh()
 	go func() {
 		defer t.Stop()
 		for {
@@ -42,7 +73,12 @@ func (a *AutoUpdater) Start(ctx context.Context) {
 			case <-ctx.Done():
 				return
 
+			case <-retune:
+				setInterval()
+
 			case <-t.C:
+				setInterval()
+
 				if !atomic.CompareAndSwapInt32(&a.running, 0, 1) {
 					// уже идёт автоапдейт — пропускаем тик
 					continue
@@ -55,28 +91,29 @@ func (a *AutoUpdater) Start(ctx context.Context) {
 					defer cancel()
 
 					// 1) синк рынков со всех бирж
+					spec := listsuc.RebuildSpec{Targets: true, Segments: true}
 					if a.Markets != nil {
-						if summary, err := a.Markets.RunAll(cctx); err != nil {
+						summary, err := a.Markets.RunAll(cctx)
+						if err != nil {
 							log.Printf("auto-update: markets sync error: %v", err)
 						} else {
 							log.Printf("auto-update: markets summary: %+v", summary)
 						}
+						// только биржи с изменениями (упавшие в сводке нулевые)
+						spec.Changed = marketsuc.ChangedExchanges(summary)
 					}
 
-					// 2) пересборка списков + 3) пересборка сегментов
+					// 2) пересборка зависящих от них списков и сегментов одним поколением
 					if uc := a.Lists; uc != nil {
-						// обычные списки (binance_to_xxx)
-						if updated, err := uc.BuildAndSaveFiltered(cctx, nil, nil); err != nil {
+						res, err := uc.RebuildAll(cctx, spec)
+						switch {
+						case err != nil:
 							log.Printf("auto-update: lists rebuild error: %v", err)
-						} else {
-							log.Printf("auto-update: lists rebuilt (count per slug): %+v", updated)
-						}
-
-						// сегменты (binance_seg*, bybit_seg*, okx_seg*)
-						if segs, err := uc.RebuildSegments(cctx /* все источники */); err != nil {
-							log.Printf("auto-update: segments rebuild error: %v", err)
-						} else {
-							log.Printf("auto-update: segments rebuilt (count per slug): %+v", segs)
+						case res.Generation.ID == 0:
+							log.Printf("auto-update: lists unchanged, skipped %d", len(res.Skipped))
+						default:
+							log.Printf("auto-update: generation %d: lists %+v, segments %+v, skipped %+v",
+								res.Generation.ID, res.Lists, res.Segments, res.Skipped)
 						}
 					}
 				}()
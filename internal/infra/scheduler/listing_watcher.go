@@ -0,0 +1,222 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/events"
+	marketsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+// ListingWatcher — быстрый детектор листингов между циклами AutoUpdater: каждые Interval опрашивает
+// дешёвые списки рынков бирж (условными запросами), новые рынки сразу пишет в markets,
+// пересобирает зависящие от биржи списки и сегменты и шлёт событие с высоким приоритетом.
+type ListingWatcher struct {
+	Pollers []marketsdom.ListingPoller
+	Markets marketsdom.Repo  // LoadActiveByExchange — начальный набор; Appender — запись новых рынков
+	Lists   ListsRebuilder   // nil — без пересборки
+	Events  events.Publisher // nil — только лог
+	Quotes  []string         // котировки, новые рынки в которых — листинг (KRW); пусто — любые
+
+	Interval time.Duration
+	Timeout  time.Duration
+
+	mu      sync.Mutex
+	known   map[int16]map[string]bool   // символы спота по бирже на последний опрос
+	pending map[int16][]marketsdom.Item // опрошенный, но ещё не записанный список (ошибка записи)
+	unbuilt map[int16]bool              // рынки записаны, а пересборка упала — повторяется на следующем тике
+	held    []events.Event              // листинги, ждущие удачной пересборки
+}
+
+// ListsRebuilder — пересборка списков (listsuc.Interactor).
+type ListsRebuilder interface {
+	RebuildAll(ctx context.Context, spec listsuc.RebuildSpec) (listsuc.RebuildResult, error)
+}
+
+func (w *ListingWatcher) Start(ctx context.Context) {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	timeout := w.Timeout
+	if timeout <= 0 {
+		timeout = time.Minute
+	}
+
+	t := time.NewTicker(interval)
+	go func() {
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-t.C:
+				// тики во время долгого прохода (пересборка) просто теряются — ticker не копит их
+				cctx, cancel := context.WithTimeout(ctx, timeout)
+				if _, err := w.Poll(cctx); err != nil {
+					log.Printf("listing-watch: %v", err)
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
+// Poll — один проход по всем биржам. Возвращает отправленные события о листингах.
+// Набор известных рынков при первом проходе берётся из БД, так что листинг между последним синком
+// и стартом тоже будет замечен. Биржа без рынков в БД (ещё не синкалась) — только запись, без событий.
+// События уходят только после удачной пересборки — с поколением, в котором рынок уже есть в списках;
+// при ошибке биржи и события ждут следующего тика (known уже сдвинут, второй раз рынок не найдётся).
+func (w *ListingWatcher) Poll(ctx context.Context) ([]events.Event, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.known == nil {
+		w.known = map[int16]map[string]bool{}
+		w.pending = map[int16][]marketsdom.Item{}
+		w.unbuilt = map[int16]bool{}
+	}
+
+	var errs []error
+	listed := w.held
+	changed := maps.Clone(w.unbuilt)
+	for _, p := range w.Pollers {
+		fresh, quiet, err := w.pollOne(ctx, p)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name(), err))
+			continue
+		}
+		if len(fresh) == 0 {
+			continue
+		}
+		changed[p.ExchangeID()] = true
+		for _, it := range fresh {
+			if quiet || !w.isListing(it) {
+				continue
+			}
+			listed = append(listed, events.Event{
+				Kind:     events.KindListing,
+				Priority: events.PriorityHigh,
+				At:       time.Now().UTC(),
+				Exchange: p.Name(),
+				Market:   string(it.Type),
+				Symbol:   it.Symbol,
+				Base:     it.Base,
+				Quote:    it.Quote,
+			})
+		}
+	}
+	if len(changed) == 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// пересборка только зависящих от этих бирж списков и сегментов, одним поколением
+	if w.Lists != nil {
+		res, err := w.Lists.RebuildAll(ctx, listsuc.RebuildSpec{Targets: true, Segments: true, Changed: changed})
+		if err != nil {
+			w.unbuilt, w.held = changed, listed
+			errs = append(errs, fmt.Errorf("rebuild (%d listing events held): %w", len(listed), err))
+			return nil, errors.Join(errs...)
+		}
+		w.unbuilt, w.held = map[int16]bool{}, nil
+		slugs := make([]string, 0, len(res.Lists)+len(res.Segments))
+		for s := range res.Lists {
+			slugs = append(slugs, s)
+		}
+		for s := range res.Segments {
+			slugs = append(slugs, s)
+		}
+		sort.Strings(slugs)
+		// поколение 0 — публиковать было нечего: списки уже пересобрал AutoUpdater
+		for i := range listed {
+			listed[i].Generation, listed[i].Lists = res.Generation.ID, slugs
+		}
+		log.Printf("listing-watch: generation %d, rebuilt %v", res.Generation.ID, slugs)
+	}
+
+	for _, e := range listed {
+		log.Printf("listing-watch: NEW %s %s (%s/%s)", e.Exchange, e.Symbol, e.Base, e.Quote)
+		if w.Events == nil {
+			continue
+		}
+		if err := w.Events.Publish(ctx, e); err != nil {
+			errs = append(errs, fmt.Errorf("publish %s %s: %w", e.Exchange, e.Symbol, err))
+		}
+	}
+	return listed, errors.Join(errs...)
+}
+
+// pollOne опрашивает биржу и записывает появившиеся рынки; возвращает их (nil — новых нет).
+// quiet — набор был пуст (биржа ещё не синкалась): это не листинги, а первое наполнение.
+func (w *ListingWatcher) pollOne(ctx context.Context, p marketsdom.ListingPoller) (fresh []marketsdom.Item, quiet bool, err error) {
+	id := p.ExchangeID()
+	if w.known[id] == nil {
+		cur, err := w.Markets.LoadActiveByExchange(ctx, id)
+		if err != nil {
+			return nil, false, fmt.Errorf("load known: %w", err)
+		}
+		w.known[id] = spotSymbols(cur)
+	}
+	quiet = len(w.known[id]) == 0
+
+	items, ok, err := p.PollSpot(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	if ok {
+		w.pending[id] = items
+	}
+	items, ok = w.pending[id]
+	if !ok {
+		return nil, false, nil // 304 и записывать нечего
+	}
+
+	for _, it := range items {
+		if it.Type == marketsdom.TypeSpot && !w.known[id][it.Symbol] {
+			fresh = append(fresh, it)
+		}
+	}
+	if len(fresh) > 0 {
+		app, ok := w.Markets.(marketsdom.Appender)
+		if !ok {
+			return nil, false, errors.New("markets repo cannot append markets")
+		}
+		// ошибка — pending остаётся, запись повторится на следующем тике даже при 304
+		if _, err := app.AddMarkets(ctx, id, fresh); err != nil {
+			return nil, false, fmt.Errorf("add markets: %w", err)
+		}
+	}
+	// набор — ровно текущий список: снятый и вернувшийся рынок снова станет листингом
+	w.known[id] = spotSymbols(items)
+	delete(w.pending, id)
+	return fresh, quiet, nil
+}
+
+func (w *ListingWatcher) isListing(it marketsdom.Item) bool {
+	if len(w.Quotes) == 0 {
+		return true
+	}
+	for _, q := range w.Quotes {
+		if strings.EqualFold(q, it.Quote) {
+			return true
+		}
+	}
+	return false
+}
+
+func spotSymbols(items []marketsdom.Item) map[string]bool {
+	out := make(map[string]bool, len(items))
+	for _, it := range items {
+		if it.Type == marketsdom.TypeSpot {
+			out[it.Symbol] = true
+		}
+	}
+	return out
+}
@@ -0,0 +1,156 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/events"
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+)
+
+type fakePoller struct {
+	items   []dm.Item
+	changed bool
+}
+
+func (p *fakePoller) ExchangeID() int16 { return 5 }
+func (p *fakePoller) Name() string      { return "upbit" }
+func (p *fakePoller) PollSpot(ctx context.Context) ([]dm.Item, bool, error) {
+	if !p.changed {
+		return nil, false, nil
+	}
+	p.changed = false // как 304 на следующий запрос
+	return p.items, true, nil
+}
+
+type appendRepo struct {
+	known []dm.Item
+	added []string
+	fail  error
+}
+
+func (r *appendRepo) SyncSnapshot(ctx context.Context, ex int16, types []dm.Type, items []dm.Item) (int, int, int, error) {
+	return 0, 0, 0, nil
+}
+func (r *appendRepo) LoadActiveByExchange(ctx context.Context, ex int16) ([]dm.Item, error) {
+	return r.known, nil
+}
+func (r *appendRepo) AddMarkets(ctx context.Context, ex int16, items []dm.Item) (int, error) {
+	if r.fail != nil {
+		return 0, r.fail
+	}
+	for _, it := range items {
+		r.added = append(r.added, it.Symbol)
+	}
+	return len(items), nil
+}
+
+type eventsSink struct{ got []events.Event }
+
+func (s *eventsSink) Publish(ctx context.Context, e events.Event) error {
+	s.got = append(s.got, e)
+	return nil
+}
+
+func spotItem(sym, base, quote string) dm.Item {
+	return dm.Item{ExchangeID: 5, Type: dm.TypeSpot, Symbol: sym, Base: base, Quote: quote, Active: true}
+}
+
+func TestListingWatcher_Poll(t *testing.T) {
+	btc := spotItem("KRW-BTC", "BTC", "KRW")
+	p := &fakePoller{changed: true, items: []dm.Item{btc, spotItem("KRW-NEW", "NEW", "KRW"), spotItem("BTC-NEW", "NEW", "BTC")}}
+	repo := &appendRepo{known: []dm.Item{btc}, fail: errors.New("db down")}
+	sink := &eventsSink{}
+	w := &ListingWatcher{Pollers: []dm.ListingPoller{p}, Markets: repo, Events: sink, Quotes: []string{"KRW"}}
+	ctx := context.Background()
+
+	// запись упала — событий нет, список держится до следующего тика
+	if ev, err := w.Poll(ctx); err == nil || len(ev) != 0 {
+		t.Fatalf("want error and no events, got %v %v", ev, err)
+	}
+
+	// следующий тик: биржа отвечает 304, но отложенный список дописывается
+	repo.fail = nil
+	ev, err := w.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.added) != 2 || repo.added[0] != "KRW-NEW" || repo.added[1] != "BTC-NEW" {
+		t.Fatalf("added = %v", repo.added)
+	}
+	// событие — только о KRW-рынке, с высоким приоритетом
+	if len(ev) != 1 || len(sink.got) != 1 {
+		t.Fatalf("events = %+v", ev)
+	}
+	if e := sink.got[0]; e.Kind != events.KindListing || e.Priority != events.PriorityHigh || e.Symbol != "KRW-NEW" || e.Exchange != "upbit" {
+		t.Fatalf("event = %+v", e)
+	}
+
+	// без изменений — тишина
+	if ev, err := w.Poll(ctx); err != nil || len(ev) != 0 || len(sink.got) != 1 {
+		t.Fatalf("idle poll: %v %v", ev, err)
+	}
+}
+
+func TestListingWatcher_FirstFillIsQuiet(t *testing.T) {
+	p := &fakePoller{changed: true, items: []dm.Item{spotItem("KRW-BTC", "BTC", "KRW")}}
+	repo := &appendRepo{} // биржа ещё не синкалась
+	sink := &eventsSink{}
+	w := &ListingWatcher{Pollers: []dm.ListingPoller{p}, Markets: repo, Events: sink}
+
+	if _, err := w.Poll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.added) != 1 || len(sink.got) != 0 {
+		t.Fatalf("added=%v events=%v", repo.added, sink.got)
+	}
+}
+
+type fakeRebuilder struct {
+	fail  error
+	specs []listsuc.RebuildSpec
+}
+
+func (f *fakeRebuilder) RebuildAll(ctx context.Context, spec listsuc.RebuildSpec) (listsuc.RebuildResult, error) {
+	f.specs = append(f.specs, spec)
+	if f.fail != nil {
+		return listsuc.RebuildResult{}, f.fail
+	}
+	return listsuc.RebuildResult{Generation: ldom.Generation{ID: 42}, Lists: map[string]int{"okx_to_upbit": 3}}, nil
+}
+
+// Упавшая пересборка не теряет листинг: биржа остаётся «изменившейся», событие ждёт поколения со списками.
+func TestListingWatcher_RebuildFailureHoldsEvents(t *testing.T) {
+	btc := spotItem("KRW-BTC", "BTC", "KRW")
+	p := &fakePoller{changed: true, items: []dm.Item{btc, spotItem("KRW-NEW", "NEW", "KRW")}}
+	repo := &appendRepo{known: []dm.Item{btc}}
+	sink := &eventsSink{}
+	lists := &fakeRebuilder{fail: errors.New("db down")}
+	w := &ListingWatcher{Pollers: []dm.ListingPoller{p}, Markets: repo, Lists: lists, Events: sink}
+	ctx := context.Background()
+
+	if ev, err := w.Poll(ctx); err == nil || len(ev) != 0 || len(sink.got) != 0 {
+		t.Fatalf("failed rebuild: events=%v sent=%v err=%v", ev, sink.got, err)
+	}
+
+	// биржа отвечает 304, рынок уже в markets — но пересборка и событие повторяются
+	lists.fail = nil
+	ev, err := w.Poll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lists.specs) != 2 || !lists.specs[1].Changed[5] {
+		t.Fatalf("rebuild specs = %+v", lists.specs)
+	}
+	if len(ev) != 1 || len(sink.got) != 1 || sink.got[0].Symbol != "KRW-NEW" || sink.got[0].Generation != 42 {
+		t.Fatalf("events = %+v", sink.got)
+	}
+
+	// дальше — тишина, без лишних пересборок
+	if ev, err := w.Poll(ctx); err != nil || len(ev) != 0 || len(lists.specs) != 2 {
+		t.Fatalf("idle poll: %v %v rebuilds=%d", ev, err, len(lists.specs))
+	}
+}
@@ -0,0 +1,321 @@
+// Package setexpr — маленький язык выражений над множествами баз: "S & U & !(C | H)".
+//
+// Грамматика (приоритет по возрастанию, все бинарные — левоассоциативные):
+//
+//	expr   = term { "|" term }             объединение
+//	term   = unary { ("&" | "-") unary }   пересечение, разность
+//	unary  = "!" unary | atom              дополнение до универсума
+//	atom   = ident | "(" expr ")"
+//	ident  = буква { буква | цифра | "_" }
+//
+// Имена множеств задаёт вызывающий (S, U, binance, ...), см. Validate и Eval.
+package setexpr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Set — множество баз (PEPE, ARB, ...).
+type Set map[string]struct{}
+
+// Sorted — элементы по алфавиту.
+func (s Set) Sorted() []string {
+	out := make([]string, 0, len(s))
+	for k := range s {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// SyntaxError — ошибка разбора с позицией (в байтах от начала выражения).
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string { return fmt.Sprintf("at %d: %s", e.Pos, e.Msg) }
+
+var ErrUnknownSet = errors.New("unknown set")
+
+type op byte
+
+const (
+	opRef   op = 0
+	opNot   op = '!'
+	opAnd   op = '&'
+	opOr    op = '|'
+	opMinus op = '-'
+)
+
+// Expr — разобранное выражение.
+type Expr struct {
+	op   op
+	name string // opRef
+	l, r *Expr  // r == nil для opNot
+}
+
+// Parse разбирает выражение; пустая строка — ошибка.
+func Parse(src string) (*Expr, error) {
+	p := &parser{src: src}
+	p.next()
+	e, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != tokEOF {
+		return nil, p.errorf("unexpected %q", p.lit)
+	}
+	return e, nil
+}
+
+// Names — имена множеств в выражении, без повторов, по алфавиту.
+func (e *Expr) Names() []string {
+	set := Set{}
+	e.walk(func(x *Expr) {
+		if x.op == opRef {
+			set[x.name] = struct{}{}
+		}
+	})
+	return set.Sorted()
+}
+
+// Validate: все имена должны быть известны.
+func (e *Expr) Validate(known func(name string) bool) error {
+	for _, n := range e.Names() {
+		if !known(n) {
+			return fmt.Errorf("%w: %s", ErrUnknownSet, n)
+		}
+	}
+	return nil
+}
+
+// Eval вычисляет выражение. "!X" — дополнение до universe; nil universe — объединение всех sets.
+func (e *Expr) Eval(sets map[string]Set, universe Set) (Set, error) {
+	if err := e.Validate(func(n string) bool { _, ok := sets[n]; return ok }); err != nil {
+		return nil, err
+	}
+	if universe == nil {
+		universe = Set{}
+		for _, s := range sets {
+			for k := range s {
+				universe[k] = struct{}{}
+			}
+		}
+	}
+	return e.eval(sets, universe), nil
+}
+
+func (e *Expr) eval(sets map[string]Set, universe Set) Set {
+	switch e.op {
+	case opRef:
+		return sets[e.name]
+	case opNot:
+		return minus(universe, e.l.eval(sets, universe))
+	}
+	l, r := e.l.eval(sets, universe), e.r.eval(sets, universe)
+	out := Set{}
+	switch e.op {
+	case opAnd:
+		for k := range l {
+			if _, ok := r[k]; ok {
+				out[k] = struct{}{}
+			}
+		}
+	case opOr:
+		for k := range l {
+			out[k] = struct{}{}
+		}
+		for k := range r {
+			out[k] = struct{}{}
+		}
+	case opMinus:
+		out = minus(l, r)
+	}
+	return out
+}
+
+func minus(a, b Set) Set {
+	out := Set{}
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			out[k] = struct{}{}
+		}
+	}
+	return out
+}
+
+// String — каноническая запись с минимумом скобок: "S & U & !(C | H)".
+func (e *Expr) String() string {
+	var b strings.Builder
+	e.format(&b, 0)
+	return b.String()
+}
+
+func (o op) prec() int {
+	switch o {
+	case opOr:
+		return 1
+	case opAnd, opMinus:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func (e *Expr) format(b *strings.Builder, parent int) {
+	switch e.op {
+	case opRef:
+		b.WriteString(e.name)
+		return
+	case opNot:
+		b.WriteByte('!')
+		e.l.format(b, 3)
+		return
+	}
+	p := e.op.prec()
+	if p < parent {
+		b.WriteByte('(')
+	}
+	e.l.format(b, p)
+	b.WriteString(" " + string(e.op) + " ")
+	e.r.format(b, p+1) // левоассоциативность: справа того же уровня — в скобках
+	if p < parent {
+		b.WriteByte(')')
+	}
+}
+
+func (e *Expr) walk(fn func(*Expr)) {
+	fn(e)
+	if e.l != nil {
+		e.l.walk(fn)
+	}
+	if e.r != nil {
+		e.r.walk(fn)
+	}
+}
+
+// --- лексер + рекурсивный спуск ---
+
+type tok int
+
+const (
+	tokEOF tok = iota
+	tokIdent
+	tokOp // ! & | - ( )
+	tokBad
+)
+
+type parser struct {
+	src string
+	pos int // начало текущего токена
+	end int // конец текущего токена
+	tok tok
+	lit string
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &SyntaxError{Pos: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func isLetter(c byte) bool { return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+func (p *parser) next() {
+	i := p.end
+	for i < len(p.src) && (p.src[i] == ' ' || p.src[i] == '\t' || p.src[i] == '\n') {
+		i++
+	}
+	p.pos = i
+	if i >= len(p.src) {
+		p.tok, p.lit, p.end = tokEOF, "", i
+		return
+	}
+	c := p.src[i]
+	switch {
+	case isLetter(c):
+		j := i + 1
+		for j < len(p.src) && (isLetter(p.src[j]) || isDigit(p.src[j]) || p.src[j] == '_') {
+			j++
+		}
+		p.tok, p.lit, p.end = tokIdent, p.src[i:j], j
+	case strings.IndexByte("!&|-()", c) >= 0:
+		p.tok, p.lit, p.end = tokOp, string(c), i+1
+	default:
+		p.tok, p.lit, p.end = tokBad, string(c), i+1
+	}
+}
+
+func (p *parser) is(s string) bool { return p.tok == tokOp && p.lit == s }
+
+func (p *parser) expr() (*Expr, error) {
+	l, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("|") {
+		p.next()
+		r, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		l = &Expr{op: opOr, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) term() (*Expr, error) {
+	l, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.is("&") || p.is("-") {
+		o := op(p.lit[0])
+		p.next()
+		r, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		l = &Expr{op: o, l: l, r: r}
+	}
+	return l, nil
+}
+
+func (p *parser) unary() (*Expr, error) {
+	if p.is("!") {
+		p.next()
+		x, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &Expr{op: opNot, l: x}, nil
+	}
+	return p.atom()
+}
+
+func (p *parser) atom() (*Expr, error) {
+	switch {
+	case p.tok == tokIdent:
+		e := &Expr{op: opRef, name: p.lit}
+		p.next()
+		return e, nil
+	case p.is("("):
+		p.next()
+		e, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.is(")") {
+			return nil, p.errorf("expected )")
+		}
+		p.next()
+		return e, nil
+	case p.tok == tokEOF:
+		return nil, p.errorf("unexpected end of expression")
+	default:
+		return nil, p.errorf("unexpected %q", p.lit)
+	}
+}
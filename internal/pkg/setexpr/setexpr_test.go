@@ -0,0 +1,109 @@
+package setexpr
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func set(xs ...string) Set {
+	s := Set{}
+	for _, x := range xs {
+		s[x] = struct{}{}
+	}
+	return s
+}
+
+func TestParse_String(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"S", "S"},
+		{"S&U", "S & U"},
+		{"S & U & !(C | H)", "S & U & !(C | H)"},
+		{"((S))", "S"},
+		{"S | U & C", "S | U & C"},
+		{"(S | U) & C", "(S | U) & C"},
+		{"S - (U - C)", "S - (U - C)"},
+		{"S - U - C", "S - U - C"},
+		{"!!S", "!!S"},
+		{"binance & upbit_krw", "binance & upbit_krw"},
+	}
+	for _, c := range cases {
+		e, err := Parse(c.in)
+		if err != nil {
+			t.Fatalf("%q: %v", c.in, err)
+		}
+		if got := e.String(); got != c.want {
+			t.Fatalf("%q: got %q, want %q", c.in, got, c.want)
+		}
+		// канонический вид разбирается в то же самое
+		if e2, err := Parse(e.String()); err != nil || e2.String() != c.want {
+			t.Fatalf("%q: round trip %v %v", c.in, e2, err)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	cases := []struct {
+		in  string
+		pos int
+	}{
+		{"", 0},
+		{"S &", 3},
+		{"(S | U", 6},
+		{"S U", 2},
+		{"S & 1U", 4},
+		{"S ^ U", 2},
+		{")", 0},
+	}
+	for _, c := range cases {
+		_, err := Parse(c.in)
+		var se *SyntaxError
+		if !errors.As(err, &se) {
+			t.Fatalf("%q: want SyntaxError, got %v", c.in, err)
+		}
+		if se.Pos != c.pos {
+			t.Fatalf("%q: pos %d, want %d (%v)", c.in, se.Pos, c.pos, err)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	sets := map[string]Set{
+		"S": set("A", "B", "C", "D", "E"),
+		"U": set("A", "B", "C"),
+		"C": set("A", "D"),
+		"H": set("A", "B"),
+	}
+	cases := []struct {
+		expr string
+		want []string
+	}{
+		{"S & U & C & H", []string{"A"}},
+		{"S & U & !(C | H)", []string{"C"}},
+		{"S & (C | H) & !U", []string{"D"}},
+		{"S - U - C", []string{"E"}},
+		{"S & !U", []string{"D", "E"}},
+		{"U | C", []string{"A", "B", "C", "D"}},
+	}
+	for _, c := range cases {
+		e, err := Parse(c.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := e.Eval(sets, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got.Sorted(), c.want) {
+			t.Fatalf("%s = %v, want %v", c.expr, got.Sorted(), c.want)
+		}
+	}
+
+	e, _ := Parse("S & X")
+	if _, err := e.Eval(sets, nil); !errors.Is(err, ErrUnknownSet) {
+		t.Fatalf("unknown set: %v", err)
+	}
+	if got := e.Names(); !slices.Equal(got, []string{"S", "X"}) {
+		t.Fatalf("names: %v", got)
+	}
+}
@@ -0,0 +1,147 @@
+package symbols
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Notation — как отображать символ инструмента наружу.
+type Notation string
+
+const (
+	// как на бирже: "PEPEUSDT", "PEPE-USDT-SWAP", "KRW-PEPE"
+	NotationRaw Notation = "raw"
+	// TradingView: "BINANCE:PEPEUSDT", "BYBIT:PEPEUSDT.P"
+	NotationTradingView Notation = "tradingview"
+	// CCXT unified: "PEPE/USDT", "PEPE/USDT:USDT"
+	NotationCCXT Notation = "ccxt"
+)
+
+var ErrUnknownNotation = errors.New("unknown notation")
+
+// ParseNotation: "" → raw; "tv" — короткий алиас для tradingview.
+func ParseNotation(s string) (Notation, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "raw":
+		return NotationRaw, nil
+	case "tradingview", "tv":
+		return NotationTradingView, nil
+	case "ccxt":
+		return NotationCCXT, nil
+	}
+	return "", ErrUnknownNotation
+}
+
+// Instrument — всё, что нужно знать о символе для форматирования.
+// Base/Quote могут быть пустыми (рынок уже архивирован) — тогда угадываем по Symbol.
+type Instrument struct {
+	Exchange string // slug биржи: binance, bybit, okx, ...
+	Symbol   string // сырой символ биржи
+	Base     string
+	Quote    string
+	Perp     bool      // бессрочный фьючерс
+	Settle   string    // валюта расчёта; "" → Quote (линейный контракт)
+	Expiry   time.Time // срочный фьючерс; zero — нет
+}
+
+func (in Instrument) delivery() bool { return !in.Expiry.IsZero() }
+
+// Format отдаёт символ в нужной нотации.
+func Format(n Notation, in Instrument) string {
+	switch n {
+	case NotationTradingView:
+		return TradingView(in)
+	case NotationCCXT:
+		return CCXT(in)
+	default:
+		return in.Symbol
+	}
+}
+
+// префиксы бирж в TradingView
+var tvPrefix = map[string]string{
+	"binance":   "BINANCE",
+	"bybit":     "BYBIT",
+	"okx":       "OKX",
+	"coinbase":  "COINBASE",
+	"upbit":     "UPBIT",
+	"bithumb":   "BITHUMB",
+	"robinhood": "ROBINHOOD",
+}
+
+// TradingView: "<EXCHANGE>:<BASE><QUOTE>", у перпов суффикс ".P".
+func TradingView(in Instrument) string {
+	base, quote := baseQuote(in)
+	ticker := base + quote
+	if ticker == "" || in.delivery() {
+		// у срочных дата зашита в символ биржи
+		ticker = stripSeps(in.Symbol)
+	}
+	if in.Perp && !in.delivery() {
+		ticker += ".P"
+	}
+	ex := tvPrefix[strings.ToLower(in.Exchange)]
+	if ex == "" {
+		ex = strings.ToUpper(in.Exchange)
+	}
+	if ex == "" {
+		return ticker
+	}
+	return ex + ":" + ticker
+}
+
+// CCXT: спот "BASE/QUOTE", деривативы "BASE/QUOTE:SETTLE", срочные "BASE/QUOTE:SETTLE-YYMMDD".
+func CCXT(in Instrument) string {
+	base, quote := baseQuote(in)
+	if base == "" || quote == "" {
+		return in.Symbol
+	}
+	out := base + "/" + quote
+	if in.Perp || in.delivery() {
+		settle := strings.ToUpper(in.Settle)
+		if settle == "" {
+			settle = quote
+		}
+		out += ":" + settle
+	}
+	if in.delivery() {
+		out += "-" + in.Expiry.UTC().Format("060102")
+	}
+	return out
+}
+
+func baseQuote(in Instrument) (string, string) {
+	base, quote := strings.ToUpper(in.Base), strings.ToUpper(in.Quote)
+	if base != "" && quote != "" {
+		return base, quote
+	}
+	sym := in.Symbol
+	if in.Perp {
+		sym = trimPerp(sym)
+	}
+	style := StyleConcat
+	if strings.EqualFold(in.Exchange, "upbit") {
+		style = StyleQuoteSepBase
+	} else if strings.ContainsAny(sym, "-_/") {
+		style = StyleBaseSepQuote
+	}
+	if b, q, ok := Split(sym, style); ok {
+		return b, q
+	}
+	return "", ""
+}
+
+func trimPerp(s string) string {
+	u := strings.ToUpper(s)
+	for _, suf := range []string{"-SWAP", "-PERP", "_PERP", "PERP"} {
+		if strings.HasSuffix(u, suf) {
+			return s[:len(s)-len(suf)]
+		}
+	}
+	return s
+}
+
+func stripSeps(s string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", "_", "", "/", "").Replace(s))
+}
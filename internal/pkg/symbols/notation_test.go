@@ -0,0 +1,68 @@
+package symbols_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/pkg/symbols"
+)
+
+func TestFormat_TradingView(t *testing.T) {
+	cases := []struct {
+		in   symbols.Instrument
+		want string
+	}{
+		{symbols.Instrument{Exchange: "binance", Symbol: "PEPEUSDT", Base: "PEPE", Quote: "USDT"}, "BINANCE:PEPEUSDT"},
+		{symbols.Instrument{Exchange: "bybit", Symbol: "PEPEUSDT", Base: "PEPE", Quote: "USDT", Perp: true}, "BYBIT:PEPEUSDT.P"},
+		{symbols.Instrument{Exchange: "okx", Symbol: "PEPE-USDT-SWAP", Base: "PEPE", Quote: "USDT", Perp: true}, "OKX:PEPEUSDT.P"},
+		{symbols.Instrument{Exchange: "upbit", Symbol: "KRW-PEPE"}, "UPBIT:PEPEKRW"},
+		// base/quote неизвестны — угадываем по символу
+		{symbols.Instrument{Exchange: "okx", Symbol: "ARB-USDT-SWAP", Perp: true}, "OKX:ARBUSDT.P"},
+		// срочный: дата — из символа биржи, без ".P"
+		{symbols.Instrument{Exchange: "binance", Symbol: "BTCUSDT_250926", Base: "BTC", Quote: "USDT",
+			Expiry: time.Date(2025, 9, 26, 8, 0, 0, 0, time.UTC)}, "BINANCE:BTCUSDT250926"},
+	}
+	for _, tc := range cases {
+		if got := symbols.Format(symbols.NotationTradingView, tc.in); got != tc.want {
+			t.Fatalf("%+v: got=%q want=%q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestFormat_CCXT(t *testing.T) {
+	cases := []struct {
+		in   symbols.Instrument
+		want string
+	}{
+		{symbols.Instrument{Exchange: "binance", Symbol: "PEPEUSDT", Base: "PEPE", Quote: "USDT"}, "PEPE/USDT"},
+		{symbols.Instrument{Exchange: "binance", Symbol: "1000PEPEUSDT", Base: "1000PEPE", Quote: "USDT", Perp: true}, "1000PEPE/USDT:USDT"},
+		{symbols.Instrument{Exchange: "okx", Symbol: "BTC-USD-SWAP", Base: "BTC", Quote: "USD", Perp: true, Settle: "BTC"}, "BTC/USD:BTC"},
+		{symbols.Instrument{Exchange: "bybit", Symbol: "ETHUSDT", Perp: true}, "ETH/USDT:USDT"},
+		{symbols.Instrument{Exchange: "x", Symbol: "???"}, "???"},
+		{symbols.Instrument{Exchange: "binance", Symbol: "BTCUSD_250926", Base: "BTC", Quote: "USD", Settle: "BTC",
+			Expiry: time.Date(2025, 9, 26, 8, 0, 0, 0, time.UTC)}, "BTC/USD:BTC-250926"},
+	}
+	for _, tc := range cases {
+		if got := symbols.Format(symbols.NotationCCXT, tc.in); got != tc.want {
+			t.Fatalf("%+v: got=%q want=%q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestParseNotation(t *testing.T) {
+	for in, want := range map[string]symbols.Notation{
+		"":            symbols.NotationRaw,
+		"RAW":         symbols.NotationRaw,
+		"tv":          symbols.NotationTradingView,
+		"tradingview": symbols.NotationTradingView,
+		"ccxt":        symbols.NotationCCXT,
+	} {
+		got, err := symbols.ParseNotation(in)
+		if err != nil || got != want {
+			t.Fatalf("%q: got=%q err=%v", in, got, err)
+		}
+	}
+	if _, err := symbols.ParseNotation("bloomberg"); err == nil {
+		t.Fatal("expected error")
+	}
+}
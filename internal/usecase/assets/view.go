@@ -0,0 +1,77 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ad "github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	ldef "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// Viewer собирает карточку актива из markets и list_items.
+type Viewer struct {
+	Markets dm.QueryRepo
+	Lists   ldef.MembershipRepo
+	Aliases ad.AliasRepo // опционально: MATIC и POL — один актив
+}
+
+// View: base может быть и каноническим активом, и его глобальным алиасом (MATIC → POL).
+// Фьючерсы с множителем (1000PEPE) относятся к базе без префикса.
+func (v *Viewer) View(ctx context.Context, base string) (ad.View, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	if base == "" {
+		return ad.View{}, ad.ErrAssetNotFound
+	}
+
+	var res ad.Resolver
+	if v.Aliases != nil {
+		as, err := v.Aliases.ListAliases(ctx)
+		if err != nil {
+			return ad.View{}, fmt.Errorf("load aliases: %w", err)
+		}
+		res = ad.NewResolver(as)
+	}
+
+	asset := res.Canon(0, base)
+	view := ad.View{Asset: asset, Tickers: res.Tickers(asset)}
+
+	bases := append([]string(nil), view.Tickers...)
+	for _, t := range view.Tickers {
+		bases = append(bases, dm.MultiplierVariants(t)...)
+	}
+
+	f := dm.Filter{Bases: bases, Sort: dm.SortID, Limit: 1000}
+	for {
+		page, err := v.Markets.FindMarkets(ctx, f)
+		if err != nil {
+			return ad.View{}, err
+		}
+		for _, m := range page.Items {
+			// алиас одной биржи не переносим на другие; 1000SATS на споте — свой токен
+			if res.Canon(m.ExchangeID, m.Underlying()) == asset {
+				view.Markets = append(view.Markets, m)
+			}
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		f.Cursor = page.NextCursor
+	}
+
+	ms, err := v.Lists.MembershipsByBases(ctx, view.Tickers)
+	if err != nil {
+		return ad.View{}, err
+	}
+	for _, m := range ms {
+		if res.Canon(m.SourceID, m.Base) == asset {
+			view.Lists = append(view.Lists, m)
+		}
+	}
+
+	if len(view.Markets) == 0 && len(view.Lists) == 0 {
+		return ad.View{}, ad.ErrAssetNotFound
+	}
+	return view, nil
+}
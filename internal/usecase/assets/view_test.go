@@ -0,0 +1,133 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	ad "github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	ldef "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+type fakeMarkets struct {
+	dm.QueryRepo
+	ms []dm.Market
+}
+
+// FindMarkets: фильтр по Bases, по одной строке на страницу — чтобы проверить обход курсора
+func (f fakeMarkets) FindMarkets(ctx context.Context, flt dm.Filter) (dm.Page, error) {
+	var hits []dm.Market
+	for _, m := range f.ms {
+		if slices.Contains(flt.Bases, m.Base) {
+			hits = append(hits, m)
+		}
+	}
+	i := 0
+	if flt.Cursor != "" {
+		i = int(flt.Cursor[0] - '0')
+	}
+	if i >= len(hits) {
+		return dm.Page{}, nil
+	}
+	page := dm.Page{Items: hits[i : i+1]}
+	if i+1 < len(hits) {
+		page.NextCursor = string(rune('0' + i + 1))
+	}
+	return page, nil
+}
+
+type fakeMemberships []ldef.Membership
+
+func (f fakeMemberships) MembershipsByBases(ctx context.Context, bases []string) ([]ldef.Membership, error) {
+	var out []ldef.Membership
+	for _, m := range f {
+		if slices.Contains(bases, m.Base) {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+type fakeAliases []ad.Alias
+
+func (f fakeAliases) ListAliases(ctx context.Context) ([]ad.Alias, error) { return f, nil }
+func (f fakeAliases) UpsertAlias(ctx context.Context, exchange, ticker, asset string) (ad.Alias, error) {
+	return ad.Alias{}, nil
+}
+func (f fakeAliases) DeleteAlias(ctx context.Context, exchange, ticker string) (bool, error) {
+	return false, nil
+}
+
+func market(exID int16, ex string, t dm.Type, sym, base string, mult int64) dm.Market {
+	return dm.Market{Exchange: ex, Item: dm.Item{
+		ExchangeID: exID, Type: t, Symbol: sym, Base: base, Quote: "USDT", Multiplier: mult,
+	}}
+}
+
+func symbols(ms []dm.Market) []string {
+	var out []string
+	for _, m := range ms {
+		out = append(out, m.Exchange+":"+m.Symbol)
+	}
+	return out
+}
+
+func TestViewer_View(t *testing.T) {
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	v := &Viewer{
+		Markets: fakeMarkets{ms: []dm.Market{
+			market(1, "binance", dm.TypeSpot, "PEPEUSDT", "PEPE", 1),
+			market(1, "binance", dm.TypeFutures, "1000PEPEUSDT", "1000PEPE", 1000),
+			market(2, "bybit", dm.TypeSpot, "1000PEPEUSDT", "1000PEPE", 1), // на споте — отдельный токен
+			market(1, "binance", dm.TypeSpot, "MATICUSDT", "MATIC", 1),
+			market(2, "bybit", dm.TypeSpot, "MATICUSDT", "MATIC", 1), // алиас только для binance
+			market(3, "okx", dm.TypeSpot, "POL-USDT", "POL", 1),
+		}},
+		Lists: fakeMemberships{
+			{Slug: "binance_upbit", SourceID: 1, Base: "MATIC", Spot: "MATICUSDT", Since: since},
+			{Slug: "bybit_upbit", SourceID: 2, Base: "MATIC", Spot: "MATICUSDT", Since: since},
+			{Slug: "binance_seg1", SourceID: 1, Base: "PEPE", Spot: "PEPEUSDT", Since: since},
+		},
+		Aliases: fakeAliases{{ExchangeID: 1, Exchange: "binance", Ticker: "MATIC", Asset: "POL"}},
+	}
+
+	t.Run("multiplier", func(t *testing.T) {
+		got, err := v.View(context.Background(), "pepe")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"binance:PEPEUSDT", "binance:1000PEPEUSDT"}
+		if got.Asset != "PEPE" || !slices.Equal(symbols(got.Markets), want) {
+			t.Fatalf("got %s %v, want %v", got.Asset, symbols(got.Markets), want)
+		}
+		if len(got.Lists) != 1 || got.Lists[0].Slug != "binance_seg1" {
+			t.Fatalf("lists: %+v", got.Lists)
+		}
+	})
+
+	t.Run("alias per exchange", func(t *testing.T) {
+		got, err := v.View(context.Background(), "POL")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got.Tickers, []string{"MATIC", "POL"}) {
+			t.Fatalf("tickers: %v", got.Tickers)
+		}
+		want := []string{"binance:MATICUSDT", "okx:POL-USDT"}
+		if !slices.Equal(symbols(got.Markets), want) {
+			t.Fatalf("markets: %v, want %v", symbols(got.Markets), want)
+		}
+		if len(got.Lists) != 1 || got.Lists[0].Slug != "binance_upbit" {
+			t.Fatalf("lists: %+v", got.Lists)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		if _, err := v.View(context.Background(), "NOPE"); !errors.Is(err, ad.ErrAssetNotFound) {
+			t.Fatalf("err = %v", err)
+		}
+	})
+}
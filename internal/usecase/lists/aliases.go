@@ -0,0 +1,61 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// aliasedMarkets — markets-репозиторий, который отдаёт Base уже в канонической форме
+// (MATIC → POL и т.п.), поэтому presence/source-индексы склеивают переименованные монеты.
+type aliasedMarkets struct {
+	dm.Repo
+	res assets.Resolver
+}
+
+func (m aliasedMarkets) LoadActiveByExchange(ctx context.Context, exchangeID int16) ([]dm.Item, error) {
+	items, err := m.Repo.LoadActiveByExchange(ctx, exchangeID)
+	if err != nil {
+		return nil, err
+	}
+	return CanonItems(m.res, items), nil
+}
+
+// CanonItems переписывает Base через алиасы (символы не трогаем).
+func CanonItems(res assets.Resolver, items []dm.Item) []dm.Item {
+	if res.Empty() {
+		return items
+	}
+	out := make([]dm.Item, len(items))
+	for i, it := range items {
+		it.Base = res.Canon(it.ExchangeID, it.Base)
+		out[i] = it
+	}
+	return out
+}
+
+// markets — репозиторий рынков с учётом алиасов (если они подключены).
+func (uc *Interactor) markets(ctx context.Context) (dm.Repo, error) {
+	if uc.Aliases == nil {
+		return uc.Markets, nil
+	}
+	res, err := uc.resolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return aliasedMarkets{Repo: uc.Markets, res: res}, nil
+}
+
+// resolver — алиасы тикеров; без Aliases — пустой (ничего не переименовывает).
+func (uc *Interactor) resolver(ctx context.Context) (assets.Resolver, error) {
+	if uc.Aliases == nil {
+		return assets.Resolver{}, nil
+	}
+	al, err := uc.Aliases.ListAliases(ctx)
+	if err != nil {
+		return assets.Resolver{}, fmt.Errorf("load asset aliases: %w", err)
+	}
+	return assets.NewResolver(al), nil
+}
@@ -0,0 +1,80 @@
+package lists
+
+import (
+	"context"
+	"testing"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+type mapMarkets map[int16][]dm.Item
+
+func (m mapMarkets) SyncSnapshot(ctx context.Context, ex int16, types []dm.Type, items []dm.Item) (int, int, int, error) {
+	return 0, 0, 0, nil
+}
+func (m mapMarkets) LoadActiveByExchange(ctx context.Context, ex int16) ([]dm.Item, error) {
+	return m[ex], nil
+}
+
+func item(ex int16, t dm.Type, base, quote, sym string) dm.Item {
+	return dm.Item{ExchangeID: ex, Type: t, Base: base, Quote: quote, Symbol: sym, Active: true}
+}
+
+func TestResolver_ExchangeOverridesGlobal(t *testing.T) {
+	res := assets.NewResolver([]assets.Alias{
+		{Ticker: "matic", Asset: "pol"},
+		{ExchangeID: ExUpbit, Ticker: "BTT", Asset: "BTTC"},
+	})
+	if got := res.Canon(ExBinance, "Matic"); got != "POL" {
+		t.Fatalf("global alias: %s", got)
+	}
+	if got := res.Canon(ExUpbit, "BTT"); got != "BTTC" {
+		t.Fatalf("exchange alias: %s", got)
+	}
+	if got := res.Canon(ExBinance, "BTT"); got != "BTT" {
+		t.Fatalf("alias must not leak to other exchanges: %s", got)
+	}
+}
+
+func TestBuildSets_AliasesMergeRenamedCoin(t *testing.T) {
+	raw := mapMarkets{
+		// Binance ещё торгует под старым тикером, Upbit уже под новым
+		ExBinance: {
+			item(ExBinance, dm.TypeSpot, "MATIC", "USDT", "MATICUSDT"),
+			item(ExBinance, dm.TypeFutures, "MATIC", "USDT", "MATICUSDT"),
+		},
+		ExUpbit: {item(ExUpbit, dm.TypeSpot, "POL", "KRW", "KRW-POL")},
+	}
+	quotes := config.QuotesConfig{
+		SourceSpotQuote:     "USDT",
+		TargetAllowedQuotes: map[string]struct{}{"USDT": {}, "USD": {}, "KRW": {}},
+	}
+
+	// без алиасов — две разные монеты, MATIC не «на Upbit»
+	sets, err := BuildSets(context.Background(), raw, quotes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sets.Upbit["MATIC"]; ok {
+		t.Fatalf("unexpected MATIC on upbit without aliases")
+	}
+
+	res := assets.NewResolver([]assets.Alias{{Ticker: "MATIC", Asset: "POL"}})
+	sets, err = BuildSets(context.Background(), aliasedMarkets{Repo: raw, res: res}, quotes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	si, ok := sets.Binance["POL"]
+	if !ok || si.SpotSymbol != "MATICUSDT" || si.FuturesSymbol != "MATICUSDT" {
+		t.Fatalf("binance POL: %+v ok=%v", si, ok)
+	}
+	if _, ok := sets.Upbit["POL"]; !ok {
+		t.Fatalf("upbit POL presence missing")
+	}
+	segs, _ := BuildSegmentsForSource(sets, "binance")
+	if len(segs.Seg4) != 1 || segs.Seg4[0].Spot != "MATICUSDT" {
+		t.Fatalf("MATICUSDT must land in seg4 (upbit only): %+v", segs)
+	}
+}
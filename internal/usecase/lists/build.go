@@ -17,21 +17,7 @@ func BuildListRows(source []dm.Item, target []dm.Item, mode string) []Row {
 
 	rows := make([]Row, 0, len(srcIdx))
 	for base, si := range srcIdx {
-		pr := tgtPr[base]
-		exclude := false
-
-		switch mode {
-		case "upbit", "bithumb":
-			// считаем присутствием только пары НЕ BTC и НЕ USDT
-			exclude = pr.HasNonBTC
-		case "coinbase":
-			exclude = pr.HasAnySpot
-		case "binance":
-			exclude = pr.HasAnySpot && !pr.HasFutures
-		default:
-			exclude = pr.HasAnySpot
-		}
-		if exclude {
+		if exclude, _ := targetRule(mode, tgtPr[base]); exclude {
 			continue
 		}
 
@@ -63,6 +49,32 @@ func FormatAsText(rows []Row) string {
 	return b.String()
 }
 
+// targetRule — исключает ли присутствие на цели монету из списка, и почему (для explain).
+func targetRule(mode string, pr presence) (bool, string) {
+	switch mode {
+	case "upbit", "bithumb":
+		// считаем присутствием только пары НЕ BTC и НЕ USDT
+		if pr.HasNonBTC {
+			return true, "excluded: target has a spot pair in a quote other than BTC/USDT"
+		}
+		if pr.HasAnySpot {
+			return false, "included: target lists it only against BTC/USDT, which does not count"
+		}
+	case "binance":
+		if pr.HasAnySpot && !pr.HasFutures {
+			return true, "excluded: target has spot but no futures"
+		}
+		if pr.HasAnySpot {
+			return false, "included: target has spot, but also futures"
+		}
+	default: // coinbase и прочие — любое присутствие
+		if pr.HasAnySpot {
+			return true, "excluded: target has a spot pair"
+		}
+	}
+	return false, "included: no spot pair on target"
+}
+
 // ===== AUXILIARY (local, no export) =====
 type presence struct {
 	HasAnySpot bool
@@ -76,6 +88,9 @@ func buildPresence(items []dm.Item) map[string]presence {
 	m := make(map[string]presence)
 	for _, it := range items {
 		base := strings.ToUpper(it.Base)
+		if it.Type == dm.TypeFutures {
+			base = it.Underlying() // 1000PEPE-перп — это присутствие PEPE
+		}
 		pr := m[base]
 		switch it.Type {
 		case dm.TypeSpot:
@@ -103,7 +118,7 @@ type srcInfo struct {
 
 func buildSourceIndex(items []dm.Item) map[string]srcInfo {
 	spot := make(map[string]map[string]string)
-	futs := make(map[string]string)
+	futs := futPicks{}
 
 	for _, it := range items {
 		base := strings.ToUpper(it.Base)
@@ -114,10 +129,7 @@ func buildSourceIndex(items []dm.Item) map[string]srcInfo {
 			}
 			spot[base][strings.ToUpper(it.Quote)] = it.Symbol
 		case dm.TypeFutures:
-			// save the first futures ticker for the coin
-			if _, ok := futs[base]; !ok && it.Symbol != "" {
-				futs[base] = it.Symbol
-			}
+			futs.add(it)
 		}
 	}
 
@@ -142,8 +154,25 @@ func buildSourceIndex(items []dm.Item) map[string]srcInfo {
 		}
 		out[base] = srcInfo{
 			SpotSymbol:    chosen,
-			FuturesSymbol: futs[base], // can be ""
+			FuturesSymbol: futs.symbol(base), // can be ""
 		}
 	}
 	return out
 }
+
+// futPicks — фьючерс на каждую базу: ключ — Underlying (1000PEPE → PEPE),
+// при нескольких контрактах — см. Item.Preferred (перп раньше срочного, меньший множитель), при равных — первый.
+type futPicks map[string]dm.Item
+
+func (p futPicks) add(it dm.Item) {
+	if it.Symbol == "" {
+		return
+	}
+	key := it.Underlying()
+	if cur, ok := p[key]; ok && !it.Preferred(cur) {
+		return
+	}
+	p[key] = it
+}
+
+func (p futPicks) symbol(base string) string { return p[base].Symbol }
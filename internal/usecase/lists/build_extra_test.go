@@ -1,9 +1,12 @@
 package lists
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+
 	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
 )
 
@@ -51,3 +54,43 @@ func TestBuildListRows_Upbit_BTCOnlyIsKept_CaseInsensitive(t *testing.T) {
 	want := []Row{{Spot:"AAA-USDT", Futures:"none"}}
 	if !reflect.DeepEqual(got, want) { t.Fatalf("got=%v want=%v", got, want) }
 }
+
+func TestBuildListRows_MultiplierFuturesAttachToSpotBase(t *testing.T) {
+	pepe := f("1000PEPE", "USDT", "1000PEPEUSDT")
+	pepe.Multiplier = 1000
+	src := []dm.Item{s("PEPE", "USDT", "PEPEUSDT"), pepe}
+
+	got := BuildListRows(src, nil, "upbit")
+	want := []Row{{Spot: "PEPEUSDT", Futures: "1000PEPEUSDT"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got=%v want=%v", got, want)
+	}
+
+	// на цели-Binance перп 1000PEPE считается фьючерсом PEPE
+	tgt := []dm.Item{s("PEPE", "USDT", "PEPEUSDT"), pepe}
+	if got := BuildListRows(src, tgt, "binance"); len(got) != 1 {
+		t.Fatalf("binance mode must keep PEPE (spot+futures on target), got=%v", got)
+	}
+}
+
+func TestBuildSets_PrefersPlainContractOverMultiplier(t *testing.T) {
+	big := f("1000PEPE", "USDT", "1000PEPEUSDT")
+	big.Multiplier = 1000
+	raw := mapMarkets{
+		ExBybit: {
+			big,
+			s("PEPE", "USDT", "PEPEUSDT"),
+			f("PEPE", "USDT", "PEPEPERP"),
+		},
+	}
+	sets, err := BuildSets(context.Background(), raw, config.QuotesConfig{SourceSpotQuote: "USDT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := sets.Bybit["PEPE"].FuturesSymbol; got != "PEPEPERP" {
+		t.Fatalf("want 1x contract, got %q", got)
+	}
+	if _, ok := sets.Bybit["1000PEPE"]; ok {
+		t.Fatalf("phantom 1000PEPE base")
+	}
+}
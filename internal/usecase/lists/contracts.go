@@ -0,0 +1,37 @@
+package lists
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// contractMarkets — markets-репозиторий, который оставляет только фьючерсы нужных видов
+// (list_defs.futures_kinds): так BuildSets/индексы не знают про inverse/delivery.
+type contractMarkets struct {
+	dm.Repo
+	kinds []dm.ContractKind
+}
+
+func (m contractMarkets) LoadActiveByExchange(ctx context.Context, exchangeID int16) ([]dm.Item, error) {
+	items, err := m.Repo.LoadActiveByExchange(ctx, exchangeID)
+	if err != nil {
+		return nil, err
+	}
+	return dm.FilterContracts(items, m.kinds), nil
+}
+
+// kindsKey — ключ набора видов без учёта порядка; nil и {linear_perp} совпадают.
+func kindsKey(kinds []dm.ContractKind) string {
+	if len(kinds) == 0 {
+		kinds = dm.DefaultContractKinds
+	}
+	ss := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		ss = append(ss, string(k))
+	}
+	slices.Sort(ss)
+	return strings.Join(slices.Compact(ss), ",")
+}
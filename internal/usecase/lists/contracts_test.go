@@ -0,0 +1,124 @@
+package lists
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	ldef "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+func TestBuildSets_FuturesKindsChooseColumn(t *testing.T) {
+	exp := time.Date(2025, 9, 26, 8, 0, 0, 0, time.UTC)
+	fut := func(sym string, kind dm.ContractKind, settle string) dm.Item {
+		it := item(ExBinance, dm.TypeFutures, "BTC", "USD", sym)
+		it.Contract, it.Settle = kind, settle
+		if kind == dm.ContractDelivery {
+			it.Expiry = &exp
+		}
+		return it
+	}
+	raw := mapMarkets{
+		ExBinance: {
+			item(ExBinance, dm.TypeSpot, "BTC", "USDT", "BTCUSDT"),
+			item(ExBinance, dm.TypeSpot, "ETH", "USDT", "ETHUSDT"),
+			fut("BTCUSD_250926", dm.ContractDelivery, "BTC"),
+			fut("BTCUSD_PERP", dm.ContractInversePerp, "BTC"),
+			// у ETH только срочный
+			{ExchangeID: ExBinance, Type: dm.TypeFutures, Base: "ETH", Quote: "USDT", Symbol: "ETHUSDT_250926",
+				Contract: dm.ContractDelivery, Expiry: &exp},
+		},
+	}
+	quotes := config.QuotesConfig{SourceSpotQuote: "USDT"}
+
+	cases := []struct {
+		kinds    []dm.ContractKind
+		btc, eth string
+	}{
+		{nil, "", ""}, // по умолчанию только linear_perp
+		{[]dm.ContractKind{dm.ContractInversePerp}, "BTCUSD_PERP", ""},
+		{[]dm.ContractKind{dm.ContractDelivery}, "BTCUSD_250926", "ETHUSDT_250926"},
+		// перп предпочтительнее срочного
+		{[]dm.ContractKind{dm.ContractDelivery, dm.ContractInversePerp}, "BTCUSD_PERP", "ETHUSDT_250926"},
+	}
+	for _, tc := range cases {
+		sets, err := BuildSets(context.Background(), contractMarkets{Repo: raw, kinds: tc.kinds}, quotes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := sets.Binance["BTC"].FuturesSymbol; got != tc.btc {
+			t.Fatalf("%v BTC: got=%q want=%q", tc.kinds, got, tc.btc)
+		}
+		if got := sets.Binance["ETH"].FuturesSymbol; got != tc.eth {
+			t.Fatalf("%v ETH: got=%q want=%q", tc.kinds, got, tc.eth)
+		}
+	}
+}
+
+// Правило binance: фьючерс на цели засчитывается только тех видов, что в def.FuturesKinds.
+func TestBuildTargetRows_TargetFuturesKinds(t *testing.T) {
+	inv := item(ExBinance, dm.TypeFutures, "DOGE", "USD", "DOGEUSD_PERP")
+	inv.Contract = dm.ContractInversePerp
+	mr := mapMarkets{
+		ExOKX: {item(ExOKX, dm.TypeSpot, "DOGE", "USDT", "DOGE-USDT")},
+		// на Binance у DOGE спот и только COIN-M перп
+		ExBinance: {item(ExBinance, dm.TypeSpot, "DOGE", "USDT", "DOGEUSDT"), inv},
+	}
+	def := func(kinds ...dm.ContractKind) ldef.Def {
+		return ldef.Def{SourceID: ExOKX, SourceSlug: "okx", TargetID: ExBinance, TargetSlug: "binance", FuturesKinds: kinds}
+	}
+
+	rows, err := buildTargetRows(context.Background(), mr, def(), "binance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 0 {
+		t.Fatalf("linear_perp: inverse perp on target must not count as futures: %+v", rows)
+	}
+	rows, err = buildTargetRows(context.Background(), mr, def(dm.ContractInversePerp), "binance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Spot != "DOGE-USDT" {
+		t.Fatalf("inverse_perp: %+v", rows)
+	}
+}
+
+func TestKindsKey(t *testing.T) {
+	if kindsKey(nil) != kindsKey([]dm.ContractKind{dm.ContractLinearPerp}) {
+		t.Fatal("nil must equal default kinds")
+	}
+	a := kindsKey([]dm.ContractKind{dm.ContractDelivery, dm.ContractLinearPerp})
+	b := kindsKey([]dm.ContractKind{dm.ContractLinearPerp, dm.ContractDelivery, dm.ContractDelivery})
+	if a != b {
+		t.Fatalf("order/dups must not matter: %q vs %q", a, b)
+	}
+}
+
+// Query и матрица присутствия — с видами по умолчанию, как сегменты: инверсный перп не фьючерс базы.
+func TestQueryAndPresence_DefaultContractKinds(t *testing.T) {
+	inv := item(ExBinance, dm.TypeFutures, "BTC", "USD", "BTCUSD_PERP")
+	inv.Contract, inv.Settle = dm.ContractInversePerp, "BTC"
+	uc := &Interactor{Markets: mapMarkets{
+		ExBinance: {item(ExBinance, dm.TypeSpot, "BTC", "USDT", "BTCUSDT"), inv},
+	}}
+	ctx := context.Background()
+
+	q, err := uc.Query(ctx, "S", "binance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(q.Items) != 1 || q.Items[0].Spot != "BTCUSDT" || q.Items[0].Futures != "" {
+		t.Fatalf("query: %+v", q.Items)
+	}
+
+	m, err := uc.PresenceMatrix(ctx, []string{"binance"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Rows) != 1 || m.Rows[0].Cells["binance"].Futures != "" {
+		t.Fatalf("presence: %+v", m.Rows)
+	}
+}
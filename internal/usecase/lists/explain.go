@@ -0,0 +1,350 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	ldef "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// ExplainMarket — рынок источника, который рассматривался для базы.
+type ExplainMarket struct {
+	Symbol     string
+	Type       dm.Type
+	Quote      string
+	Kind       dm.ContractKind // только фьючерсы
+	Multiplier int64
+	Chosen     bool
+	Note       string // почему не выбран
+}
+
+// ExplainQuote — спот-пара базы на цели (или на U/H/C для сегментов).
+type ExplainQuote struct {
+	Exchange string
+	Quote    string
+	Symbol   string
+	Counted  bool // считается присутствием по правилу
+}
+
+// Explanation — почему база попала (или не попала) в список/сегмент.
+type Explanation struct {
+	Slug   string
+	Kind   string // target | segment
+	Base   string
+	Source string
+	Target string // target-списки
+	Mode   string // target-списки: правило присутствия
+	Expr   string // сегменты
+
+	SourceMarkets []ExplainMarket
+	Spot          string
+	SpotReason    string
+	Futures       string // "" — нет
+	FuturesReason string
+
+	TargetQuotes []ExplainQuote
+	TargetHasFut bool            // target-списки: есть фьючерс на цели (важно для mode=binance)
+	Flags        map[string]bool // сегменты: S, U, H, C
+
+	Included bool
+	Rule     string
+	Stored   bool // база есть в сохранённом списке (мог устареть до следующей пересборки)
+	Disabled bool // target-список выключен (биржа источника или цели неактивна): пересборка его не трогает
+}
+
+// Explain разбирает решение по базе теми же функциями, что и сборка (BuildListRows / BuildSets + EvalSegment).
+func (uc *Interactor) Explain(ctx context.Context, slug, base string) (Explanation, error) {
+	base = strings.ToUpper(strings.TrimSpace(base))
+	res, err := uc.resolver(ctx)
+	if err != nil {
+		return Explanation{}, err
+	}
+	base = res.Canon(0, base) // MATIC → POL: рынки ниже уже приведены к активу
+	var mr dm.Repo = aliasedMarkets{Repo: uc.Markets, res: res}
+
+	var out Explanation
+	defs, err := uc.Defs.Find(ctx, nil, nil)
+	if err != nil {
+		return Explanation{}, err
+	}
+	if i := slices.IndexFunc(defs, func(d ldef.Def) bool { return d.Slug == slug }); i >= 0 {
+		out, err = explainTarget(ctx, mr, defs[i], base)
+	} else {
+		segs, err2 := uc.Defs.SegmentDefs(ctx)
+		if err2 != nil {
+			return Explanation{}, err2
+		}
+		if i := slices.IndexFunc(segs, func(d ldef.SegmentDef) bool { return d.Slug == slug }); i >= 0 {
+			out, err = explainSegment(ctx, mr, segs[i], base)
+		} else {
+			out, err = uc.explainDisabled(ctx, mr, slug, base)
+		}
+	}
+	if err != nil {
+		return Explanation{}, err
+	}
+
+	stored, err := uc.Lists.GetRowsBySlug(ctx, slug)
+	if err != nil {
+		return Explanation{}, fmt.Errorf("load %s: %w", slug, err)
+	}
+	out.Stored = out.Spot != "" && slices.ContainsFunc(stored, func(r ldef.Row) bool { return r.Spot == out.Spot })
+	return out, nil
+}
+
+// explainDisabled — target-список, которого нет в Find (там только активные биржи): объясняем по тем же
+// правилам и помечаем выключенным, а не отвечаем 404 на существующий slug.
+func (uc *Interactor) explainDisabled(ctx context.Context, mr dm.Repo, slug, base string) (Explanation, error) {
+	ids, err := uc.Defs.IDsBySlugs(ctx, []string{slug})
+	if err != nil {
+		return Explanation{}, err
+	}
+	id, ok := ids[slug]
+	if !ok {
+		return Explanation{}, fmt.Errorf("%w: %s", ldef.ErrNotFound, slug)
+	}
+	def, err := uc.Defs.GetByID(ctx, id)
+	if err != nil {
+		return Explanation{}, err
+	}
+	out, err := explainTarget(ctx, mr, def, base)
+	out.Disabled = true
+	return out, err
+}
+
+func explainTarget(ctx context.Context, mr dm.Repo, def ldef.Def, base string) (Explanation, error) {
+	mode := modeForTarget(def.TargetSlug)
+	out := Explanation{Slug: def.Slug, Kind: "target", Base: base, Source: def.SourceSlug, Target: def.TargetSlug, Mode: mode}
+
+	source, err := mr.LoadActiveByExchange(ctx, def.SourceID)
+	if err != nil {
+		return Explanation{}, fmt.Errorf("load source(%s): %w", def.SourceSlug, err)
+	}
+	target, err := mr.LoadActiveByExchange(ctx, def.TargetID)
+	if err != nil {
+		return Explanation{}, fmt.Errorf("load target(%s): %w", def.TargetSlug, err)
+	}
+
+	filtered := dm.FilterContracts(source, def.FuturesKinds)
+	si, ok := buildSourceIndex(filtered)[base]
+	out.Spot, out.Futures = si.SpotSymbol, si.FuturesSymbol
+	out.SourceMarkets = explainSourceMarkets(source, def.FuturesKinds, base, si.SpotSymbol, si.FuturesSymbol)
+	out.SpotReason = spotReasonByPref(out.SourceMarkets, si.SpotSymbol)
+	out.FuturesReason = futuresReason(out.SourceMarkets, def.FuturesKinds, si.FuturesSymbol)
+
+	pr := buildPresence(dm.FilterContracts(target, def.FuturesKinds))[base]
+	out.TargetHasFut = pr.HasFutures
+	for _, it := range target {
+		if it.Type != dm.TypeSpot || strings.ToUpper(it.Base) != base {
+			continue
+		}
+		q := strings.ToUpper(it.Quote)
+		counted := true
+		if mode == "upbit" || mode == "bithumb" {
+			counted = q != "BTC" && q != "USDT"
+		}
+		out.TargetQuotes = append(out.TargetQuotes, ExplainQuote{Exchange: def.TargetSlug, Quote: q, Symbol: it.Symbol, Counted: counted})
+	}
+	sortQuotes(out.TargetQuotes)
+
+	if !ok {
+		out.Rule = "excluded: no spot market on " + def.SourceSlug
+		return out, nil
+	}
+	exclude, rule := targetRule(mode, pr)
+	out.Included, out.Rule = !exclude, rule
+	return out, nil
+}
+
+func explainSegment(ctx context.Context, mr dm.Repo, def ldef.SegmentDef, base string) (Explanation, error) {
+	out := Explanation{Slug: def.Slug, Kind: "segment", Base: base, Source: def.SourceSlug, Expr: def.Expr}
+	e, err := ValidateSegmentExpr(def.Expr)
+	if err != nil {
+		return Explanation{}, fmt.Errorf("segment %s: %w", def.Slug, err)
+	}
+	srcID, ok := exchangeID(def.SourceSlug)
+	if !ok {
+		return Explanation{}, fmt.Errorf("%w: %s", ErrUnknownSource, def.SourceSlug)
+	}
+
+	quotes := config.LoadQuotes()
+	sets, err := BuildSets(ctx, contractMarkets{Repo: mr, kinds: def.FuturesKinds}, quotes)
+	if err != nil {
+		return Explanation{}, fmt.Errorf("build sets: %w", err)
+	}
+	S, ok := sourceIndex(sets, def.SourceSlug)
+	if !ok {
+		return Explanation{}, fmt.Errorf("%w: %s", ErrUnknownSource, def.SourceSlug)
+	}
+	b, err := SetsBindings(sets, def.SourceSlug)
+	if err != nil {
+		return Explanation{}, err
+	}
+	in := func(name string) bool { _, ok := b[name][base]; return ok }
+	out.Flags = map[string]bool{"S": in("S"), "U": in("U"), "H": in("H"), "C": in("C")}
+	for _, n := range e.Names() { // выражение может ссылаться и на slug-и бирж
+		out.Flags[n] = in(n)
+	}
+
+	source, err := mr.LoadActiveByExchange(ctx, srcID)
+	if err != nil {
+		return Explanation{}, fmt.Errorf("load source(%s): %w", def.SourceSlug, err)
+	}
+	si := S[base]
+	out.Spot, out.Futures = si.SpotSymbol, si.FuturesSymbol
+	out.SourceMarkets = explainSourceMarkets(source, def.FuturesKinds, base, si.SpotSymbol, si.FuturesSymbol)
+	srcQuote := strings.ToUpper(quotes.SourceSpotQuote)
+	for i, m := range out.SourceMarkets {
+		if m.Type == dm.TypeSpot && !m.Chosen && m.Quote != srcQuote {
+			out.SourceMarkets[i].Note = "quote is not " + srcQuote
+		}
+	}
+	if si.SpotSymbol != "" {
+		out.SpotReason = "spot in the source quote " + srcQuote
+	} else {
+		out.SpotReason = "no spot in the source quote " + srcQuote
+	}
+	out.FuturesReason = futuresReason(out.SourceMarkets, def.FuturesKinds, si.FuturesSymbol)
+
+	for _, t := range []struct {
+		slug string
+		id   int16
+	}{{"upbit", ExUpbit}, {"bithumb", ExBithumb}, {"coinbase", ExCoinbase}} {
+		items, err := mr.LoadActiveByExchange(ctx, t.id)
+		if err != nil {
+			return Explanation{}, fmt.Errorf("load %s: %w", t.slug, err)
+		}
+		counted := countedQuote(t.id, quotes)
+		for _, it := range items {
+			if it.Type == dm.TypeSpot && strings.ToUpper(it.Base) == base {
+				q := strings.ToUpper(it.Quote)
+				out.TargetQuotes = append(out.TargetQuotes, ExplainQuote{Exchange: t.slug, Quote: q, Symbol: it.Symbol, Counted: counted(q)})
+			}
+		}
+	}
+	sortQuotes(out.TargetQuotes)
+
+	if !out.Flags["S"] {
+		out.Rule = "excluded: not in S (no " + srcQuote + " spot on " + def.SourceSlug + ")"
+		return out, nil
+	}
+	res, err := e.Eval(b, nil)
+	if err != nil {
+		return Explanation{}, err
+	}
+	_, out.Included = res[base]
+	verdict := "excluded"
+	if out.Included {
+		verdict = "included"
+	}
+	vals := make([]string, 0, len(e.Names()))
+	for _, n := range e.Names() {
+		vals = append(vals, fmt.Sprintf("%s=%d", n, b01(out.Flags[n])))
+	}
+	out.Rule = fmt.Sprintf("%s: %s with %s", verdict, e.String(), strings.Join(vals, " "))
+	return out, nil
+}
+
+func b01(v bool) int {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// explainSourceMarkets — спот базы и фьючерсы на её underlying (1000PEPE-перп — это PEPE).
+func explainSourceMarkets(items []dm.Item, kinds []dm.ContractKind, base, spot, fut string) []ExplainMarket {
+	if len(kinds) == 0 {
+		kinds = dm.DefaultContractKinds
+	}
+	var out []ExplainMarket
+	for _, it := range items {
+		m := ExplainMarket{Symbol: it.Symbol, Type: it.Type, Quote: strings.ToUpper(it.Quote), Multiplier: it.Mult()}
+		switch it.Type {
+		case dm.TypeSpot:
+			if strings.ToUpper(it.Base) != base {
+				continue
+			}
+			m.Chosen = it.Symbol == spot
+			if !m.Chosen {
+				m.Note = "lower quote priority"
+			}
+		case dm.TypeFutures:
+			if it.Underlying() != base {
+				continue
+			}
+			m.Kind = it.Kind()
+			m.Chosen = it.Symbol == fut
+			switch {
+			case m.Chosen:
+			case !slices.Contains(kinds, m.Kind):
+				m.Note = "contract kind not in futures_kinds"
+			default:
+				m.Note = "another contract preferred (kind, then multiplier, then expiry)"
+			}
+		}
+		out = append(out, m)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Type != out[j].Type {
+			return out[i].Type == dm.TypeSpot
+		}
+		return out[i].Symbol < out[j].Symbol
+	})
+	return out
+}
+
+// spotReasonByPref — выбор спота в target-списках: первая котировка по quotePref.
+func spotReasonByPref(ms []ExplainMarket, spot string) string {
+	if spot == "" {
+		return "no spot market on source"
+	}
+	i := slices.IndexFunc(ms, func(m ExplainMarket) bool { return m.Chosen && m.Type == dm.TypeSpot })
+	if i >= 0 && slices.Contains(quotePref, ms[i].Quote) {
+		return "quote " + ms[i].Quote + " is first available by priority " + strings.Join(quotePref, " > ")
+	}
+	return "no quote from " + strings.Join(quotePref, " > ") + "; took any"
+}
+
+func futuresReason(ms []ExplainMarket, kinds []dm.ContractKind, fut string) string {
+	if len(kinds) == 0 {
+		kinds = dm.DefaultContractKinds
+	}
+	var all, allowed int
+	for _, m := range ms {
+		if m.Type != dm.TypeFutures {
+			continue
+		}
+		all++
+		if slices.Contains(kinds, m.Kind) {
+			allowed++
+		}
+	}
+	ks := make([]string, len(kinds))
+	for i, k := range kinds {
+		ks[i] = string(k)
+	}
+	switch {
+	case fut == "" && all == 0:
+		return "no futures on source"
+	case fut == "":
+		return "no contracts of kinds " + strings.Join(ks, ",")
+	case allowed > 1:
+		return fmt.Sprintf("preferred among %d contracts of kinds %s (kind, then multiplier, then expiry)", allowed, strings.Join(ks, ","))
+	}
+	return "the only contract of kinds " + strings.Join(ks, ",")
+}
+
+func sortQuotes(qs []ExplainQuote) {
+	sort.Slice(qs, func(i, j int) bool {
+		if qs[i].Exchange != qs[j].Exchange {
+			return qs[i].Exchange < qs[j].Exchange
+		}
+		return qs[i].Quote < qs[j].Quote
+	})
+}
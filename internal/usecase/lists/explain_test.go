@@ -0,0 +1,167 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+type fakeExplainDefs struct {
+	fakeSegDefs
+	targets  []listsdom.Def
+	disabled []listsdom.Def // есть в list_defs, но Find их не отдаёт (неактивная биржа)
+}
+
+func (f fakeExplainDefs) Find(ctx context.Context, src, tgt *string) ([]listsdom.Def, error) {
+	return f.targets, nil
+}
+
+func (f fakeExplainDefs) IDsBySlugs(ctx context.Context, slugs []string) (map[string]int16, error) {
+	out := map[string]int16{}
+	for _, d := range append(f.targets, f.disabled...) {
+		if slices.Contains(slugs, d.Slug) {
+			out[d.Slug] = d.ID
+		}
+	}
+	return out, nil
+}
+
+func (f fakeExplainDefs) GetByID(ctx context.Context, id int16) (listsdom.Def, error) {
+	for _, d := range append(f.targets, f.disabled...) {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return listsdom.Def{}, listsdom.ErrNotFound
+}
+
+func explainUC() *Interactor {
+	inv := item(ExBinance, dm.TypeFutures, "PEPE", "USD", "PEPEUSD_PERP")
+	inv.Contract = dm.ContractInversePerp
+	thousand := item(ExBinance, dm.TypeFutures, "1000PEPE", "USDT", "1000PEPEUSDT")
+	thousand.Multiplier = 1000
+	return &Interactor{
+		Defs: fakeExplainDefs{
+			fakeSegDefs: fakeSegDefs{defs: []listsdom.SegmentDef{
+				{ID: 2, Slug: "binance_seg4", SourceSlug: "binance", Segment: "seg4", Expr: DefaultSegmentExprs[Seg4]},
+			}},
+			targets: []listsdom.Def{
+				{ID: 1, Slug: "binance_to_upbit", SourceID: ExBinance, SourceSlug: "binance", TargetID: ExUpbit, TargetSlug: "upbit"},
+			},
+		},
+		Markets: mapMarkets{
+			ExBinance: {
+				item(ExBinance, dm.TypeSpot, "PEPE", "BTC", "PEPEBTC"),
+				item(ExBinance, dm.TypeSpot, "PEPE", "USDT", "PEPEUSDT"),
+				thousand, inv,
+				item(ExBinance, dm.TypeSpot, "XRP", "USDT", "XRPUSDT"),
+			},
+			ExUpbit: {
+				item(ExUpbit, dm.TypeSpot, "PEPE", "USDT", "USDT-PEPE"),
+				item(ExUpbit, dm.TypeSpot, "XRP", "KRW", "KRW-XRP"),
+			},
+		},
+		Lists: storedLists{rows: map[string][]listsdom.Row{
+			"binance_to_upbit": {{Spot: "PEPEUSDT"}},
+		}},
+	}
+}
+
+func TestExplain_Target(t *testing.T) {
+	uc := explainUC()
+	ctx := context.Background()
+
+	ex, err := uc.Explain(ctx, "binance_to_upbit", "pepe")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ex.Included || !ex.Stored || ex.Kind != "target" || ex.Mode != "upbit" {
+		t.Fatalf("verdict: %+v", ex)
+	}
+	if ex.Spot != "PEPEUSDT" || !strings.Contains(ex.SpotReason, "USDT") {
+		t.Fatalf("spot: %s (%s)", ex.Spot, ex.SpotReason)
+	}
+	// 1000PEPE-перп — это PEPE; инверсный не входит в linear_perp по умолчанию
+	if ex.Futures != "1000PEPEUSDT" || len(ex.SourceMarkets) != 4 {
+		t.Fatalf("futures: %s %+v", ex.Futures, ex.SourceMarkets)
+	}
+	for _, m := range ex.SourceMarkets {
+		if m.Symbol == "PEPEUSD_PERP" && (m.Chosen || !strings.Contains(m.Note, "futures_kinds")) {
+			t.Fatalf("inverse: %+v", m)
+		}
+	}
+	if len(ex.TargetQuotes) != 1 || ex.TargetQuotes[0].Counted {
+		t.Fatalf("USDT on Upbit must not count: %+v", ex.TargetQuotes)
+	}
+
+	ex, err = uc.Explain(ctx, "binance_to_upbit", "XRP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ex.Included || ex.Stored || !strings.HasPrefix(ex.Rule, "excluded") || !ex.TargetQuotes[0].Counted {
+		t.Fatalf("xrp: %+v", ex)
+	}
+
+	ex, err = uc.Explain(ctx, "binance_to_upbit", "DOGE")
+	if err != nil || ex.Included || !strings.Contains(ex.Rule, "no spot market") {
+		t.Fatalf("doge: %+v %v", ex, err)
+	}
+
+	if _, err := uc.Explain(ctx, "nope", "PEPE"); !errors.Is(err, listsdom.ErrNotFound) {
+		t.Fatalf("unknown slug: %v", err)
+	}
+}
+
+// Выключенный список (неактивная биржа) — не 404: объяснение по тем же правилам с пометкой.
+func TestExplain_DisabledTarget(t *testing.T) {
+	uc := explainUC()
+	defs := uc.Defs.(fakeExplainDefs)
+	defs.disabled = []listsdom.Def{{ID: 9, Slug: "binance_to_robinhood", SourceID: ExBinance, SourceSlug: "binance",
+		TargetID: ExRobinhood, TargetSlug: "robinhood"}}
+	uc.Defs = defs
+
+	ex, err := uc.Explain(context.Background(), "binance_to_robinhood", "PEPE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ex.Disabled || ex.Kind != "target" || ex.Target != "robinhood" || ex.Spot != "PEPEUSDT" {
+		t.Fatalf("disabled: %+v", ex)
+	}
+	if ex, err = uc.Explain(context.Background(), "binance_to_upbit", "PEPE"); err != nil || ex.Disabled {
+		t.Fatalf("enabled list marked disabled: %+v %v", ex, err)
+	}
+}
+
+func TestExplain_Segment(t *testing.T) {
+	config.SetActive(nil)
+	t.Setenv("TARGET_ALLOWED_QUOTES", "USDT,USD,KRW")
+	uc := explainUC()
+	uc.Aliases = fakeAliases{{Ticker: "RIPPLE", Asset: "XRP"}}
+
+	// ticker через алиас приводится к активу
+	ex, err := uc.Explain(context.Background(), "binance_seg4", "ripple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ex.Base != "XRP" || !ex.Included || !ex.Flags["S"] || !ex.Flags["U"] || ex.Flags["C"] {
+		t.Fatalf("xrp: %+v", ex)
+	}
+	if !strings.Contains(ex.Rule, "S=1") || !strings.Contains(ex.Rule, "U=1") || !strings.Contains(ex.Rule, "H=0") {
+		t.Fatalf("rule: %s", ex.Rule)
+	}
+
+	ex, err = uc.Explain(context.Background(), "binance_seg4", "PEPE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// USDT-PEPE на Upbit не считается → U=0 → не в seg4
+	if ex.Included || ex.Flags["U"] || len(ex.TargetQuotes) != 1 || ex.TargetQuotes[0].Counted {
+		t.Fatalf("pepe: %+v", ex)
+	}
+}
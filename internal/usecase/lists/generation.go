@@ -0,0 +1,89 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+
+	ldef "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+)
+
+// RebuildSpec — что пересобрать в одном поколении (как у POST /update).
+type RebuildSpec struct {
+	Targets        bool
+	Source, Target *string // фильтры target-списков (nil — все)
+	Segments       bool
+	SegmentSources []string // фильтр сегментов по источнику (пусто — все)
+
+	// Changed — биржи, изменившиеся при синке (marketsuc.ChangedExchanges); строятся только зависящие от них
+	// списки. nil — строить все. В обоих случаях совпавшие с сохранёнными списки не переписываются.
+	Changed map[int16]bool
+}
+
+type RebuildResult struct {
+	Generation ldef.Generation // нулевое, если писать было нечего
+	Lists      map[string]int  // slug → строк (только переписанные)
+	Segments   map[string]int
+	Skipped    map[string]string // slug → SkipNoChanges | SkipUnchanged
+}
+
+// RebuildAll строит target-списки и сегменты из одного среза рынков (параллельно, не более Workers)
+// и публикует изменившиеся одним поколением.
+func (uc *Interactor) RebuildAll(ctx context.Context, spec RebuildSpec) (RebuildResult, error) {
+	plan, err := uc.newPlan(ctx, spec.Changed)
+	if err != nil {
+		return RebuildResult{}, fmt.Errorf("load current lists: %w", err)
+	}
+	res := RebuildResult{Skipped: plan.skipped}
+	var jobs []buildJob
+	if spec.Targets {
+		res.Lists = map[string]int{}
+		js, err := uc.targetJobs(ctx, spec.Source, spec.Target, plan)
+		if err != nil {
+			return RebuildResult{}, err
+		}
+		jobs = append(jobs, js...)
+	}
+	if spec.Segments {
+		res.Segments = map[string]int{}
+		js, err := uc.segmentJobs(ctx, spec.SegmentSources, plan)
+		if err != nil {
+			return RebuildResult{}, err
+		}
+		jobs = append(jobs, js...)
+	}
+	if len(jobs) == 0 {
+		uc.done(plan)
+		return res, nil // все списки пропущены — рынки не читаем
+	}
+
+	mr, err := uc.snapshotWith(ctx, plan.res, jobsExchanges(jobs))
+	if err != nil {
+		return RebuildResult{}, err
+	}
+	built, err := runJobs(ctx, mr, jobs, uc.workers())
+	if err != nil {
+		return RebuildResult{}, err
+	}
+
+	items := map[int16][]ldef.Item{}
+	for i, j := range jobs {
+		if !plan.keep(j.id, j.slug, built[i]) {
+			continue
+		}
+		items[j.id] = built[i]
+		if j.segment {
+			res.Segments[j.slug] = len(built[i])
+		} else {
+			res.Lists[j.slug] = len(built[i])
+		}
+	}
+	if len(items) == 0 {
+		uc.done(plan)
+		return res, nil
+	}
+	if res.Generation, err = uc.Lists.Publish(ctx, items); err != nil {
+		return RebuildResult{}, fmt.Errorf("publish generation: %w", err)
+	}
+	uc.done(plan)
+	return res, nil
+}
@@ -0,0 +1,136 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// countingMarkets считает обращения к репозиторию рынков.
+type countingMarkets struct {
+	mapMarkets
+	loads, snapshots int
+	read             []int16 // биржи последнего снимка
+}
+
+func (m *countingMarkets) LoadActiveByExchange(ctx context.Context, ex int16) ([]dm.Item, error) {
+	m.loads++
+	return m.mapMarkets[ex], nil
+}
+
+func (m *countingMarkets) LoadActiveByExchanges(ctx context.Context, ids ...int16) (map[int16][]dm.Item, error) {
+	m.snapshots++
+	m.read = ids
+	out := map[int16][]dm.Item{}
+	for _, id := range ids {
+		out[id] = m.mapMarkets[id]
+	}
+	return out, nil
+}
+
+func TestRebuildAll_OneSnapshotOneGeneration(t *testing.T) {
+	t.Setenv("TARGET_ALLOWED_QUOTES", "KRW")
+	mr := &countingMarkets{mapMarkets: mapMarkets{
+		ExBinance: {
+			item(ExBinance, dm.TypeSpot, "PEPE", "USDT", "PEPEUSDT"),
+			item(ExBinance, dm.TypeSpot, "ARB", "USDT", "ARBUSDT"),
+		},
+		ExUpbit: {item(ExUpbit, dm.TypeSpot, "PEPE", "KRW", "KRW-PEPE")},
+	}}
+	lists := &recLists{saved: map[int16][]listsdom.Item{}}
+	uc := &Interactor{
+		Defs: fakeExplainDefs{
+			fakeSegDefs: fakeSegDefs{defs: []listsdom.SegmentDef{
+				{ID: 2, Slug: "binance_seg4", SourceSlug: "binance", Segment: "seg4", Expr: DefaultSegmentExprs[Seg4]},
+				{ID: 3, Slug: "binance_seg0", SourceSlug: "binance", Segment: "seg0", Expr: DefaultSegmentExprs[Seg0]},
+			}},
+			targets: []listsdom.Def{
+				{ID: 1, Slug: "binance_to_upbit", SourceID: ExBinance, SourceSlug: "binance", TargetID: ExUpbit, TargetSlug: "upbit"},
+			},
+		},
+		Markets: mr,
+		Lists:   lists,
+	}
+
+	res, err := uc.RebuildAll(context.Background(), RebuildSpec{Targets: true, Segments: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mr.snapshots != 1 || mr.loads != 0 {
+		t.Fatalf("markets read %d snapshots, %d per-exchange loads; want one snapshot", mr.snapshots, mr.loads)
+	}
+	if want := []int16{ExBinance, ExBybit, ExOKX, ExCoinbase, ExUpbit, ExBithumb}; !reflect.DeepEqual(mr.read, want) {
+		t.Fatalf("snapshot read %v, want %v", mr.read, want)
+	}
+	if lists.published != 1 || res.Generation.ID != 1 {
+		t.Fatalf("published %d generations, res %+v", lists.published, res.Generation)
+	}
+	if res.Lists["binance_to_upbit"] != 1 || res.Segments["binance_seg4"] != 1 || res.Segments["binance_seg0"] != 1 {
+		t.Fatalf("res: %+v", res)
+	}
+	if len(lists.saved) != 3 || lists.saved[1][0].Spot != "ARBUSDT" || lists.saved[2][0].Spot != "PEPEUSDT" {
+		t.Fatalf("saved: %+v", lists.saved)
+	}
+
+	// только target-список: читаются лишь его источник и цель
+	if _, err := uc.RebuildAll(context.Background(), RebuildSpec{Targets: true}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []int16{ExBinance, ExUpbit}; !reflect.DeepEqual(mr.read, want) {
+		t.Fatalf("snapshot read %v, want %v", mr.read, want)
+	}
+
+	// без SnapshotLoader срез собирается по биржам — каждая один раз, тоже одно поколение
+	cm := &countingMarkets{mapMarkets: mr.mapMarkets}
+	uc.Markets = struct{ dm.Repo }{cm}
+	if _, err := uc.RebuildAll(context.Background(), RebuildSpec{Targets: true, Segments: true}); err != nil {
+		t.Fatal(err)
+	}
+	if lists.published != 3 || cm.loads != len(setsExchanges) {
+		t.Fatalf("published %d, loads %d", lists.published, cm.loads)
+	}
+}
+
+func TestRunJobs_BoundedAndOrdered(t *testing.T) {
+	var cur, peak int32
+	jobs := make([]buildJob, 20)
+	for i := range jobs {
+		jobs[i] = buildJob{build: func(ctx context.Context, mr dm.Repo) ([]listsdom.Item, error) {
+			n := atomic.AddInt32(&cur, 1)
+			defer atomic.AddInt32(&cur, -1)
+			for {
+				p := atomic.LoadInt32(&peak)
+				if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return []listsdom.Item{{Spot: strconv.Itoa(i)}}, nil
+		}}
+	}
+	out, err := runJobs(context.Background(), mapMarkets{}, jobs, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if peak > 3 || peak < 2 {
+		t.Fatalf("peak concurrency %d, want 2..3", peak)
+	}
+	for i, items := range out {
+		if items[0].Spot != strconv.Itoa(i) {
+			t.Fatalf("out[%d] = %+v", i, items)
+		}
+	}
+
+	boom := errors.New("boom")
+	jobs[5].build = func(ctx context.Context, mr dm.Repo) ([]listsdom.Item, error) { return nil, boom }
+	if _, err := runJobs(context.Background(), mapMarkets{}, jobs, 3); !errors.Is(err, boom) {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,164 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	ldef "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	"github.com/berezovskyivalerii/tickersvc/internal/pkg/setexpr"
+)
+
+// Причины, по которым список не переписан (RebuildResult.Skipped).
+const (
+	SkipNoChanges = "no market changes" // ни одна биржа, от которой зависит список, не изменилась
+	SkipUnchanged = "unchanged"         // пересобран, строки совпали с сохранёнными
+)
+
+// rebuildPlan решает, какие списки строить и какие из построенных писать.
+type rebuildPlan struct {
+	changed map[int16]bool        // nil — считаем изменившимися все биржи
+	stored  map[int16][]ldef.Item // текущее поколение; nil — репозиторий не умеет CurrentItems, пишем всё
+	skipped map[string]string     // slug → причина
+
+	res     assets.Resolver  // алиасы прогона: ими же канонизируются рынки снимка
+	aliases string           // отпечаток алиасов
+	inputs  map[int16]string // list id → отпечаток правил (def, алиасы, котировки) в этом прогоне
+	prev    map[int16]string // то же при прошлой удачной пересборке этим Interactor
+}
+
+// fingerprints — отпечатки правил списков, с которыми они последний раз собраны (см. rebuildPlan.need).
+// Живут в памяти: после рестарта первый прогон строит всё, а keep не даёт переписать совпавшее.
+type fingerprints struct {
+	mu sync.Mutex
+	m  map[int16]string
+}
+
+func (uc *Interactor) newPlan(ctx context.Context, changed map[int16]bool) (*rebuildPlan, error) {
+	p := &rebuildPlan{changed: changed, skipped: map[string]string{}, inputs: map[int16]string{}}
+	if cr, ok := uc.Lists.(ldef.CurrentReader); ok {
+		stored, err := cr.CurrentItems(ctx)
+		if err != nil {
+			return nil, err
+		}
+		p.stored = stored
+	}
+	if uc.Aliases != nil {
+		al, err := uc.Aliases.ListAliases(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("load asset aliases: %w", err)
+		}
+		p.res, p.aliases = assets.NewResolver(al), aliasesKey(al)
+	}
+	uc.built.mu.Lock()
+	p.prev = maps.Clone(uc.built.m)
+	uc.built.mu.Unlock()
+	return p, nil
+}
+
+// done запоминает отпечатки прогона — вызывать только после удачной публикации.
+func (uc *Interactor) done(p *rebuildPlan) {
+	uc.built.mu.Lock()
+	defer uc.built.mu.Unlock()
+	if uc.built.m == nil {
+		uc.built.m = map[int16]string{}
+	}
+	maps.Copy(uc.built.m, p.inputs)
+}
+
+// need — строить ли список с отпечатком правил key, зависящий от бирж deps. Строим всегда список,
+// которого нет в текущем поколении, и список, у которого с прошлой пересборки сменились правила:
+// futures_kinds/segment_expr в list_defs, алиасы, котировки (SIGHUP).
+func (p *rebuildPlan) need(id int16, slug, key string, deps ...int16) bool {
+	p.inputs[id] = key
+	if p.changed == nil || p.stored == nil {
+		return true
+	}
+	if _, ok := p.stored[id]; !ok {
+		return true
+	}
+	if p.prev[id] != key {
+		return true
+	}
+	for _, ex := range deps {
+		if p.changed[ex] {
+			return true
+		}
+	}
+	p.skipped[slug] = SkipNoChanges
+	return false
+}
+
+func (p *rebuildPlan) targetKey(d ldef.Def) string {
+	return fmt.Sprintf("t|%d|%d|%s|%s", d.SourceID, d.TargetID, kindsKey(d.FuturesKinds), p.aliases)
+}
+
+func (p *rebuildPlan) segmentKey(d ldef.SegmentDef, q config.QuotesConfig) string {
+	allowed := slices.Sorted(maps.Keys(q.TargetAllowedQuotes))
+	return fmt.Sprintf("s|%s|%s|%s|%s|%s|%s", d.SourceSlug, d.Expr, kindsKey(d.FuturesKinds),
+		q.SourceSpotQuote, strings.Join(allowed, ","), p.aliases)
+}
+
+func aliasesKey(al []assets.Alias) string {
+	ss := make([]string, 0, len(al))
+	for _, a := range al {
+		ss = append(ss, fmt.Sprintf("%d:%s=%s", a.ExchangeID, strings.ToUpper(a.Ticker), strings.ToUpper(a.Asset)))
+	}
+	slices.Sort(ss)
+	return strings.Join(ss, ",")
+}
+
+// keep — писать ли построенный список: совпавший с сохранённым не переписываем.
+func (p *rebuildPlan) keep(id int16, slug string, items []ldef.Item) bool {
+	if p.stored == nil || !sameItems(p.stored[id], items) {
+		return true
+	}
+	p.skipped[slug] = SkipUnchanged
+	return false
+}
+
+// sameItems — одинаковый набор (spot, futures) без учёта порядка.
+func sameItems(a, b []ldef.Item) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	key := func(items []ldef.Item) []string {
+		out := make([]string, len(items))
+		for i, it := range items {
+			out[i] = it.Spot + "\x00"
+			if it.Futures != nil {
+				out[i] += *it.Futures
+			}
+		}
+		sort.Strings(out)
+		return out
+	}
+	ka, kb := key(a), key(b)
+	for i := range ka {
+		if ka[i] != kb[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// segmentDeps — биржи, от которых зависит сегмент: источник и все множества выражения.
+func segmentDeps(source string, e *setexpr.Expr) []int16 {
+	alias := map[string]string{"S": source, "U": "upbit", "H": "bithumb", "C": "coinbase"}
+	var out []int16
+	for _, n := range append(e.Names(), "S") {
+		if a, ok := alias[n]; ok {
+			n = a
+		}
+		if id, ok := exchangeID(n); ok {
+			out = append(out, id)
+		}
+	}
+	return out
+}
@@ -0,0 +1,173 @@
+package lists
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// currentLists — recLists, который отдаёт текущее поколение (пустые списки не возвращаются, как в БД).
+type currentLists struct{ *recLists }
+
+func (c currentLists) CurrentItems(ctx context.Context) (map[int16][]listsdom.Item, error) {
+	out := map[int16][]listsdom.Item{}
+	for id, items := range c.saved {
+		if len(items) > 0 {
+			out[id] = items
+		}
+	}
+	return out, nil
+}
+
+func TestRebuildAll_Incremental(t *testing.T) {
+	t.Setenv("TARGET_ALLOWED_QUOTES", "KRW")
+	mr := &countingMarkets{mapMarkets: mapMarkets{
+		ExBinance: {
+			item(ExBinance, dm.TypeSpot, "PEPE", "USDT", "PEPEUSDT"),
+			item(ExBinance, dm.TypeSpot, "ARB", "USDT", "ARBUSDT"),
+		},
+		ExUpbit:    {item(ExUpbit, dm.TypeSpot, "PEPE", "KRW", "KRW-PEPE")},
+		ExCoinbase: {item(ExCoinbase, dm.TypeSpot, "DOGE", "USD", "DOGE-USD")},
+	}}
+	rec := &recLists{saved: map[int16][]listsdom.Item{}}
+	uc := &Interactor{
+		Defs: fakeExplainDefs{
+			fakeSegDefs: fakeSegDefs{defs: []listsdom.SegmentDef{
+				{ID: 2, Slug: "binance_seg4", SourceSlug: "binance", Segment: "seg4", Expr: DefaultSegmentExprs[Seg4]},
+				{ID: 3, Slug: "binance_only_upbit", SourceSlug: "binance", Segment: "u", Expr: "S & upbit"},
+			}},
+			targets: []listsdom.Def{
+				{ID: 1, Slug: "binance_to_upbit", SourceID: ExBinance, SourceSlug: "binance", TargetID: ExUpbit, TargetSlug: "upbit"},
+			},
+		},
+		Markets: mr,
+		Lists:   currentLists{rec},
+	}
+	spec := func(changed ...int16) RebuildSpec {
+		s := RebuildSpec{Targets: true, Segments: true, Changed: map[int16]bool{}}
+		for _, ex := range changed {
+			s.Changed[ex] = true
+		}
+		return s
+	}
+	ctx := context.Background()
+
+	// 1) пустое хранилище: строим всё, хотя синк ничего не изменил
+	res, err := uc.RebuildAll(ctx, spec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.published != 1 || len(res.Skipped) != 0 || res.Lists["binance_to_upbit"] != 1 {
+		t.Fatalf("first: published %d, res %+v", rec.published, res)
+	}
+
+	// 2) изменений нет: ничего не строим и не читаем рынки
+	mr.snapshots = 0
+	res, err = uc.RebuildAll(ctx, spec())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"binance_to_upbit": SkipNoChanges, "binance_seg4": SkipNoChanges, "binance_only_upbit": SkipNoChanges}
+	if rec.published != 1 || mr.snapshots != 0 || res.Generation.ID != 0 || !reflect.DeepEqual(res.Skipped, want) {
+		t.Fatalf("idle: published %d, snapshots %d, res %+v", rec.published, mr.snapshots, res)
+	}
+
+	// 3) изменился Coinbase: от него зависит только seg4 (через C), и его строки те же
+	res, err = uc.RebuildAll(ctx, spec(ExCoinbase))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = map[string]string{"binance_to_upbit": SkipNoChanges, "binance_seg4": SkipUnchanged, "binance_only_upbit": SkipNoChanges}
+	if rec.published != 1 || !reflect.DeepEqual(res.Skipped, want) {
+		t.Fatalf("coinbase: published %d, res %+v", rec.published, res)
+	}
+
+	// 4) на Upbit листинг ARB: переписываются только изменившиеся списки
+	mr.mapMarkets[ExUpbit] = append(mr.mapMarkets[ExUpbit], item(ExUpbit, dm.TypeSpot, "ARB", "KRW", "KRW-ARB"))
+	res, err = uc.RebuildAll(ctx, spec(ExUpbit))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.published != 2 || res.Generation.ID != 2 {
+		t.Fatalf("upbit: published %d, res %+v", rec.published, res)
+	}
+	if len(res.Lists) != 1 || len(res.Segments) != 2 || len(res.Skipped) != 0 || len(rec.saved[1]) != 0 || len(rec.saved[3]) != 2 {
+		t.Fatalf("upbit: res %+v, saved %+v", res, rec.saved)
+	}
+}
+
+// Рынки не менялись, но сменились правила списка: выражение сегмента, алиасы, котировки (SIGHUP).
+func TestRebuildAll_RulesChanged(t *testing.T) {
+	t.Setenv("TARGET_ALLOWED_QUOTES", "KRW")
+	mr := mapMarkets{
+		ExBinance: {
+			item(ExBinance, dm.TypeSpot, "PEPE", "USDT", "PEPEUSDT"),
+			item(ExBinance, dm.TypeSpot, "MATIC", "USDT", "MATICUSDT"),
+			item(ExBinance, dm.TypeSpot, "ARB", "USDT", "ARBUSDT"),
+		},
+		ExUpbit: {item(ExUpbit, dm.TypeSpot, "PEPE", "KRW", "KRW-PEPE"), item(ExUpbit, dm.TypeSpot, "POL", "KRW", "KRW-POL")},
+	}
+	segs := []listsdom.SegmentDef{{ID: 3, Slug: "binance_only_upbit", SourceSlug: "binance", Segment: "u", Expr: "S & upbit"}}
+	targets := []listsdom.Def{{ID: 1, Slug: "binance_to_upbit", SourceID: ExBinance, SourceSlug: "binance", TargetID: ExUpbit, TargetSlug: "upbit"}}
+	rec := &recLists{saved: map[int16][]listsdom.Item{}}
+	uc := &Interactor{
+		Defs:    fakeExplainDefs{fakeSegDefs: fakeSegDefs{defs: segs}, targets: targets},
+		Markets: mr,
+		Lists:   currentLists{rec},
+		Aliases: fakeAliases{},
+	}
+	run := func(step string) RebuildResult {
+		t.Helper()
+		res, err := uc.RebuildAll(context.Background(), RebuildSpec{Targets: true, Segments: true, Changed: map[int16]bool{}})
+		if err != nil {
+			t.Fatalf("%s: %v", step, err)
+		}
+		return res
+	}
+
+	run("first")
+	want := map[string]string{"binance_to_upbit": SkipNoChanges, "binance_only_upbit": SkipNoChanges}
+	if res := run("idle"); !reflect.DeepEqual(res.Skipped, want) {
+		t.Fatalf("idle: %+v", res)
+	}
+
+	// 1) новое выражение сегмента: PEPE → MATIC, ARB
+	segs[0].Expr = "S & !upbit"
+	uc.Defs = fakeExplainDefs{fakeSegDefs: fakeSegDefs{defs: segs}, targets: targets}
+	if res := run("expr"); res.Segments["binance_only_upbit"] != 2 || res.Skipped["binance_to_upbit"] != SkipNoChanges {
+		t.Fatalf("expr: %+v", res)
+	}
+
+	// 2) алиас MATIC → POL: MATIC теперь есть на Upbit — оба списка без него
+	uc.Aliases = fakeAliases{{Ticker: "MATIC", Asset: "POL"}}
+	if res := run("aliases"); res.Lists["binance_to_upbit"] != 1 || res.Segments["binance_only_upbit"] != 1 {
+		t.Fatalf("aliases: %+v", res)
+	}
+
+	// 3) другие котировки: сегмент пересобирается (строки те же), target-списки от них не зависят
+	t.Setenv("TARGET_ALLOWED_QUOTES", "KRW,USDT")
+	want = map[string]string{"binance_to_upbit": SkipNoChanges, "binance_only_upbit": SkipUnchanged}
+	if res := run("quotes"); !reflect.DeepEqual(res.Skipped, want) {
+		t.Fatalf("quotes: %+v", res)
+	}
+	if res := run("idle again"); res.Skipped["binance_only_upbit"] != SkipNoChanges {
+		t.Fatalf("idle again: %+v", res)
+	}
+}
+
+func TestSegmentDeps(t *testing.T) {
+	e, err := ValidateSegmentExpr("S & U - binance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[int16]bool{}
+	for _, id := range segmentDeps("okx", e) {
+		got[id] = true
+	}
+	if want := map[int16]bool{ExOKX: true, ExUpbit: true, ExBinance: true}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("deps: %v", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
 	ldef "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
 	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
 )
@@ -13,6 +14,10 @@ type Interactor struct {
 	Defs   ldef.DefsRepo
 	Markets dm.Repo   // LoadActiveByExchange
 	Lists   ldef.Repo // ReplaceByListID / ReplaceBySlug (внутри — транзакция)
+	Aliases assets.AliasRepo // опционально: склейка переименованных тикеров
+	Workers int              // сколько списков строить параллельно; 0 — GOMAXPROCS
+
+	built fingerprints // отпечатки правил последней пересборки (RebuildAll)
 }
 
 func modeForTarget(targetSlug string) string {
@@ -53,40 +58,54 @@ func (uc *Interactor) BuildAndSaveBySlug(ctx context.Context, slug string) (int,
 	return 0, fmt.Errorf("list slug not found: %s", slug)
 }
 
-// BuildAndSaveFiltered — собрать и записать все списки по фильтрам source/target (nil => все).
-// Возвращает map[slug]inserted.
+// BuildAndSaveFiltered — собрать и записать все списки по фильтрам source/target (nil => все)
+// одним поколением из одного среза рынков. Возвращает map[slug]inserted.
 func (uc *Interactor) BuildAndSaveFiltered(ctx context.Context, sourceSlug, targetSlug *string) (map[string]int, error) {
+	res, err := uc.RebuildAll(ctx, RebuildSpec{Targets: true, Source: sourceSlug, Target: targetSlug})
+	if err != nil {
+		return nil, err
+	}
+	return res.Lists, nil
+}
+
+// targetJobs — задания на target-списки по фильтрам; списки, которые plan пропускает, в задания не попадают.
+func (uc *Interactor) targetJobs(ctx context.Context, sourceSlug, targetSlug *string, plan *rebuildPlan) ([]buildJob, error) {
 	defs, err := uc.Defs.Find(ctx, sourceSlug, targetSlug)
 	if err != nil {
 		return nil, err
 	}
-	out := make(map[string]int, len(defs))
+	var jobs []buildJob
 	for _, d := range defs {
-		n, err := uc.buildAndSave(ctx, d)
-		if err != nil {
-			return nil, err
+		if !plan.need(d.ID, d.Slug, plan.targetKey(d), d.SourceID, d.TargetID) {
+			continue
 		}
-		out[d.Slug] = n
+		jobs = append(jobs, buildJob{
+			id: d.ID, slug: d.Slug, reads: []int16{d.SourceID, d.TargetID},
+			build: func(ctx context.Context, mr dm.Repo) ([]ldef.Item, error) {
+				rows, err := buildTargetRows(ctx, mr, d, modeForTarget(d.TargetSlug))
+				if err != nil {
+					return nil, err
+				}
+				return RowsToItems(rows), nil
+			},
+		})
 	}
-	return out, nil
+	return jobs, nil
 }
 
 // --- внутреннее: общий путь сборки + запись ---
 func (uc *Interactor) buildAndSave(ctx context.Context, def ldef.Def) (int, error) {
-	// 1) тянем рынки
-	source, err := uc.Markets.LoadActiveByExchange(ctx, def.SourceID)
+	// 1) тянем рынки источника и цели (Base уже канонический, если заданы алиасы)
+	mr, err := uc.snapshot(ctx, []int16{def.SourceID, def.TargetID})
 	if err != nil {
-		return 0, fmt.Errorf("load source(%s): %w", def.SourceSlug, err)
+		return 0, err
 	}
-	target, err := uc.Markets.LoadActiveByExchange(ctx, def.TargetID)
+	// 2) строим список по правилам
+	rows, err := buildTargetRows(ctx, mr, def, modeForTarget(def.TargetSlug))
 	if err != nil {
-		return 0, fmt.Errorf("load target(%s): %w", def.TargetSlug, err)
+		return 0, err
 	}
 
-	// 2) строим список по правилам
-	mode := modeForTarget(def.TargetSlug)
-	rows := BuildListRows(source, target, mode)
-
 	// 3) сохраняем транзакционно (репозиторий внутри делает DELETE+INSERT в tx)
 	items := RowsToItems(rows)
 	n, err := uc.Lists.ReplaceByListID(ctx, def.ID, items)
@@ -95,3 +114,19 @@ func (uc *Interactor) buildAndSave(ctx context.Context, def ldef.Def) (int, erro
 	}
 	return n, nil
 }
+
+// buildTargetRows — строки target-списка (общая часть buildAndSave и Preview).
+func buildTargetRows(ctx context.Context, mr dm.Repo, def ldef.Def, mode string) ([]Row, error) {
+	source, err := mr.LoadActiveByExchange(ctx, def.SourceID)
+	if err != nil {
+		return nil, fmt.Errorf("load source(%s): %w", def.SourceSlug, err)
+	}
+	target, err := mr.LoadActiveByExchange(ctx, def.TargetID)
+	if err != nil {
+		return nil, fmt.Errorf("load target(%s): %w", def.TargetSlug, err)
+	}
+	// в колонку фьючерсов — только контракты из def.FuturesKinds; фьючерс на цели (правило binance) — тех же видов
+	source = dm.FilterContracts(source, def.FuturesKinds)
+	target = dm.FilterContracts(target, def.FuturesKinds)
+	return BuildListRows(source, target, mode), nil
+}
@@ -59,7 +59,7 @@ func BuildPresence(items []dm.Item) map[string]Presence {
 func BuildSourceIndex(items []dm.Item) map[string]SourceInfo {
 	// base -> (quote->symbol)
 	spot := make(map[string]map[string]string)
-	futs := make(map[string]string)
+	futs := futPicks{}
 
 	for _, it := range items {
 		base := strings.ToUpper(it.Base)
@@ -70,10 +70,8 @@ func BuildSourceIndex(items []dm.Item) map[string]SourceInfo {
 			}
 			spot[base][strings.ToUpper(it.Quote)] = it.Symbol
 		case dm.TypeFutures:
-			// we store “any” futures symbol for this coin
-			if _, ok := futs[base]; !ok {
-				futs[base] = it.Symbol
-			}
+			// any futures symbol for this coin (1x preferred over 1000x)
+			futs.add(it)
 		}
 	}
 
@@ -91,7 +89,7 @@ func BuildSourceIndex(items []dm.Item) map[string]SourceInfo {
 			for _, s := range q2sym { chosen = s; break }
 		}
 		var fs *string
-		if sym, ok := futs[base]; ok && sym != "" {
+		if sym := futs.symbol(base); sym != "" {
 			fs = &sym
 		}
 		out[base] = SourceInfo{SpotSymbol: chosen, FuturesSymbol: fs}
@@ -0,0 +1,156 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+var ErrUnknownExchange = errors.New("unknown exchange")
+
+type PresenceExchange struct {
+	ID   int16
+	Slug string
+}
+
+// PresenceExchanges — колонки матрицы присутствия (порядок = порядок колонок).
+var PresenceExchanges = []PresenceExchange{
+	{ExBinance, "binance"}, {ExBybit, "bybit"}, {ExOKX, "okx"},
+	{ExUpbit, "upbit"}, {ExBithumb, "bithumb"}, {ExCoinbase, "coinbase"}, {ExRobinhood, "robinhood"},
+}
+
+// PresenceCell — база на одной бирже.
+type PresenceCell struct {
+	Spot    []string        // спот-символы базы, по алфавиту
+	Quotes  []string        // их котировки, по алфавиту
+	Futures string          // фьючерс, который попал бы в списки (см. Item.Preferred); "" — нет
+	Kind    dm.ContractKind // вид этого фьючерса
+	InSet   bool            // база входит в множество биржи для сегментов (S/U/H/C) — после фильтра котировок
+}
+
+type PresenceRow struct {
+	Base  string
+	Cells map[string]PresenceCell // slug биржи → ячейка; биржи, где базы нет, отсутствуют
+}
+
+// PresenceMatrix — база × биржа по активным рынкам (с учётом алиасов).
+type PresenceMatrix struct {
+	Exchanges []string
+	Rows      []PresenceRow // по Base
+}
+
+// countedQuote — при какой котировке спот биржи входит в её множество (правила BuildSets):
+// источники — только SourceSpotQuote; Upbit/Bithumb — разрешённые, кроме USDT/BTC; Coinbase — разрешённые.
+func countedQuote(ex int16, quotes config.QuotesConfig) func(q string) bool {
+	allowed := func(q string) bool { _, ok := quotes.TargetAllowedQuotes[q]; return ok }
+	switch ex {
+	case ExBinance, ExBybit, ExOKX:
+		src := strings.ToUpper(quotes.SourceSpotQuote)
+		return func(q string) bool { return q == src }
+	case ExUpbit, ExBithumb:
+		return func(q string) bool { return allowed(q) && q != "USDT" && q != "BTC" }
+	case ExCoinbase:
+		return allowed
+	default:
+		return func(string) bool { return true }
+	}
+}
+
+// PresenceColumns: slug-и → колонки в порядке PresenceExchanges (пусто — все).
+func PresenceColumns(slugs []string) ([]PresenceExchange, error) {
+	if len(slugs) == 0 {
+		return PresenceExchanges, nil
+	}
+	want := map[string]bool{}
+	for _, s := range slugs {
+		want[strings.ToLower(strings.TrimSpace(s))] = true
+	}
+	var out []PresenceExchange
+	for _, e := range PresenceExchanges {
+		if want[e.Slug] {
+			out = append(out, e)
+			delete(want, e.Slug)
+		}
+	}
+	for s := range want {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownExchange, s)
+	}
+	return out, nil
+}
+
+// PresenceMatrix строит матрицу по выбранным биржам (пусто — все PresenceExchanges).
+func (uc *Interactor) PresenceMatrix(ctx context.Context, exchanges []string) (PresenceMatrix, error) {
+	cols, err := PresenceColumns(exchanges)
+	if err != nil {
+		return PresenceMatrix{}, err
+	}
+	mr, err := uc.markets(ctx)
+	if err != nil {
+		return PresenceMatrix{}, err
+	}
+	// как у списков по умолчанию: инверсные и квартальные контракты не дают «есть фьючерс»
+	mr = contractMarkets{Repo: mr, kinds: dm.DefaultContractKinds}
+	raw := make([][]dm.Item, len(cols))
+	for i, e := range cols {
+		if raw[i], err = mr.LoadActiveByExchange(ctx, e.ID); err != nil {
+			return PresenceMatrix{}, fmt.Errorf("load %s: %w", e.Slug, err)
+		}
+	}
+	return BuildPresenceMatrix(cols, raw, config.LoadQuotes()), nil
+}
+
+// BuildPresenceMatrix — raw[i] — рынки биржи cols[i]. Фьючерсы с множителем кладём в строку базы (1000PEPE → PEPE).
+func BuildPresenceMatrix(cols []PresenceExchange, raw [][]dm.Item, quotes config.QuotesConfig) PresenceMatrix {
+	out := PresenceMatrix{Exchanges: make([]string, 0, len(cols))}
+	rows := map[string]map[string]PresenceCell{}
+	cell := func(base, ex string) PresenceCell {
+		if rows[base] == nil {
+			rows[base] = map[string]PresenceCell{}
+		}
+		return rows[base][ex]
+	}
+
+	for i, e := range cols {
+		out.Exchanges = append(out.Exchanges, e.Slug)
+		counted := countedQuote(e.ID, quotes)
+		futs := futPicks{}
+		for _, it := range raw[i] {
+			switch it.Type {
+			case dm.TypeSpot:
+				base, q := strings.ToUpper(it.Base), strings.ToUpper(it.Quote)
+				c := cell(base, e.Slug)
+				c.Spot = append(c.Spot, it.Symbol)
+				if !slices.Contains(c.Quotes, q) {
+					c.Quotes = append(c.Quotes, q)
+				}
+				c.InSet = c.InSet || counted(q)
+				rows[base][e.Slug] = c
+			case dm.TypeFutures:
+				futs.add(it)
+			}
+		}
+		for base, it := range futs {
+			c := cell(base, e.Slug)
+			c.Futures, c.Kind = it.Symbol, it.Kind()
+			rows[base][e.Slug] = c
+		}
+	}
+
+	out.Rows = make([]PresenceRow, 0, len(rows))
+	for base, cells := range rows {
+		for ex, c := range cells {
+			sort.Strings(c.Spot)
+			sort.Strings(c.Quotes)
+			cells[ex] = c
+		}
+		out.Rows = append(out.Rows, PresenceRow{Base: base, Cells: cells})
+	}
+	sort.Slice(out.Rows, func(i, j int) bool { return out.Rows[i].Base < out.Rows[j].Base })
+	return out
+}
@@ -0,0 +1,98 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+func TestBuildPresenceMatrix(t *testing.T) {
+	perp := item(ExBinance, dm.TypeFutures, "1000PEPE", "USDT", "1000PEPEUSDT")
+	perp.Multiplier = 1000
+	raw := [][]dm.Item{
+		{ // binance
+			item(ExBinance, dm.TypeSpot, "PEPE", "USDT", "PEPEUSDT"),
+			item(ExBinance, dm.TypeSpot, "PEPE", "FDUSD", "PEPEFDUSD"),
+			item(ExBinance, dm.TypeSpot, "ARB", "BTC", "ARBBTC"),
+			perp,
+		},
+		{ // upbit
+			item(ExUpbit, dm.TypeSpot, "PEPE", "KRW", "KRW-PEPE"),
+			item(ExUpbit, dm.TypeSpot, "ARB", "USDT", "USDT-ARB"), // USDT у Upbit в множество не идёт
+		},
+	}
+	cols := []PresenceExchange{{ExBinance, "binance"}, {ExUpbit, "upbit"}}
+	quotes := config.QuotesConfig{
+		SourceSpotQuote:     "USDT",
+		TargetAllowedQuotes: map[string]struct{}{"USDT": {}, "KRW": {}},
+	}
+
+	m := BuildPresenceMatrix(cols, raw, quotes)
+	if !slices.Equal(m.Exchanges, []string{"binance", "upbit"}) || len(m.Rows) != 2 {
+		t.Fatalf("matrix: %+v", m)
+	}
+	arb, pepe := m.Rows[0], m.Rows[1]
+	if arb.Base != "ARB" || pepe.Base != "PEPE" {
+		t.Fatalf("rows order: %s, %s", arb.Base, pepe.Base)
+	}
+
+	bp := pepe.Cells["binance"]
+	if !slices.Equal(bp.Spot, []string{"PEPEFDUSD", "PEPEUSDT"}) || !slices.Equal(bp.Quotes, []string{"FDUSD", "USDT"}) ||
+		bp.Futures != "1000PEPEUSDT" || bp.Kind != dm.ContractLinearPerp || !bp.InSet {
+		t.Fatalf("binance PEPE: %+v", bp)
+	}
+	if c := pepe.Cells["upbit"]; !c.InSet || c.Futures != "" {
+		t.Fatalf("upbit PEPE: %+v", c)
+	}
+	if c := arb.Cells["binance"]; c.InSet {
+		t.Fatalf("binance ARB/BTC must not count as source spot: %+v", c)
+	}
+	if c, ok := arb.Cells["upbit"]; !ok || c.InSet {
+		t.Fatalf("upbit ARB/USDT present but not in set: %+v", c)
+	}
+}
+
+func TestPresenceColumns(t *testing.T) {
+	cols, err := PresenceColumns([]string{"upbit", "Binance"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cols) != 2 || cols[0].Slug != "binance" || cols[1].Slug != "upbit" {
+		t.Fatalf("cols: %+v", cols)
+	}
+	if _, err := PresenceColumns([]string{"kraken"}); !errors.Is(err, ErrUnknownExchange) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+type fakeAliases []assets.Alias
+
+func (f fakeAliases) ListAliases(ctx context.Context) ([]assets.Alias, error) { return f, nil }
+func (f fakeAliases) UpsertAlias(ctx context.Context, exchange, ticker, asset string) (assets.Alias, error) {
+	return assets.Alias{}, nil
+}
+func (f fakeAliases) DeleteAlias(ctx context.Context, exchange, ticker string) (bool, error) {
+	return false, nil
+}
+
+func TestInteractor_PresenceMatrix_Aliases(t *testing.T) {
+	uc := &Interactor{
+		Markets: mapMarkets{
+			ExBinance: {item(ExBinance, dm.TypeSpot, "MATIC", "USDT", "MATICUSDT")},
+			ExOKX:     {item(ExOKX, dm.TypeSpot, "POL", "USDT", "POL-USDT")},
+		},
+		Aliases: fakeAliases{{Ticker: "MATIC", Asset: "POL"}},
+	}
+	m, err := uc.PresenceMatrix(context.Background(), []string{"binance", "okx"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Rows) != 1 || m.Rows[0].Base != "POL" || len(m.Rows[0].Cells) != 2 {
+		t.Fatalf("rows: %+v", m.Rows)
+	}
+}
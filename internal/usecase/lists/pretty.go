@@ -0,0 +1,51 @@
+package lists
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+func ansi(code string, s string) string { return "\x1b[" + code + "m" + s + "\x1b[0m" }
+
+func green(s string) string { return ansi("32", s) }
+func dim(s string) string   { return ansi("2", s) }
+
+// FormatRebuild печатает таблицу итога RebuildAll: LIST | KIND | ROWS | STATUS (как marketsuc.FormatSummary).
+// Переписанные списки — с числом строк, пропущенные — с причиной (SkipNoChanges / SkipUnchanged).
+func FormatRebuild(res RebuildResult) string {
+	type row struct {
+		slug, kind, rows, status string
+		written                  bool
+	}
+	var rows []row
+	for slug, n := range res.Lists {
+		rows = append(rows, row{slug, "target", fmt.Sprint(n), "updated", true})
+	}
+	for slug, n := range res.Segments {
+		rows = append(rows, row{slug, "segment", fmt.Sprint(n), "updated", true})
+	}
+	for slug, why := range res.Skipped {
+		rows = append(rows, row{slug, "", "-", why, false})
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].slug < rows[j].slug })
+
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(w, "LIST\tKIND\tROWS\tSTATUS")
+	for _, r := range rows {
+		st := dim(r.status)
+		if r.written {
+			st = green(r.status)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.slug, r.kind, r.rows, st)
+	}
+	gen := "not published (nothing changed)"
+	if res.Generation.ID != 0 {
+		gen = fmt.Sprint(res.Generation.ID)
+	}
+	fmt.Fprintf(w, "%s\t\t\t%s\n", dim("GENERATION"), dim(gen))
+	_ = w.Flush()
+	return b.String()
+}
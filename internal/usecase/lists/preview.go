@@ -0,0 +1,168 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	ldef "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+var (
+	ErrBadPreview  = errors.New("bad preview spec")
+	ErrUnknownMode = errors.New("unknown target mode")
+)
+
+// PreviewSpec — правило списка, которое хотим посмотреть до записи в list_defs.
+// Ровно одно из Target / Segment.
+type PreviewSpec struct {
+	Source       string
+	Target       string // target-список: source → target
+	Mode         string // правило присутствия на цели (upbit|bithumb|coinbase|binance); "" — по Target
+	Segment      string // выражение сегмента: "S & U & !(C | H)"
+	FuturesKinds []dm.ContractKind
+	Quotes       *config.QuotesConfig // только для сегментов (с Target — ErrBadPreview); nil — из env
+	Slug         string               // если задан — diff с сохранённым списком
+}
+
+// RowChange — у спота сменился фьючерс.
+type RowChange struct {
+	Spot string
+	From string
+	To   string
+}
+
+type PreviewDiff struct {
+	Added   []Row
+	Removed []Row
+	Changed []RowChange
+}
+
+type Preview struct {
+	Rows []Row
+	Diff *PreviewDiff // nil, если Slug не задан
+}
+
+func exchangeID(slug string) (int16, bool) {
+	for _, e := range PresenceExchanges {
+		if e.Slug == slug {
+			return e.ID, true
+		}
+	}
+	return 0, false
+}
+
+// Preview собирает строки тем же путём, что buildAndSave / RebuildSegments, но ничего не пишет.
+func (uc *Interactor) Preview(ctx context.Context, spec PreviewSpec) (Preview, error) {
+	if (spec.Target == "") == (spec.Segment == "") {
+		return Preview{}, fmt.Errorf("%w: exactly one of target or segment is required", ErrBadPreview)
+	}
+	// target-списки котировки не фильтруют — молча проигнорированные quotes выдали бы тот же список
+	if spec.Target != "" && spec.Quotes != nil {
+		return Preview{}, fmt.Errorf("%w: quotes apply to segments only", ErrBadPreview)
+	}
+	srcID, ok := exchangeID(spec.Source)
+	if !ok {
+		return Preview{}, fmt.Errorf("%w: %s", ErrUnknownExchange, spec.Source)
+	}
+	// опечатка в slug дала бы diff «всё добавлено» — неизвестный список сразу ErrNotFound
+	if spec.Slug != "" {
+		ids, err := uc.Defs.IDsBySlugs(ctx, []string{spec.Slug})
+		if err != nil {
+			return Preview{}, err
+		}
+		if _, ok := ids[spec.Slug]; !ok {
+			return Preview{}, fmt.Errorf("%w: %s", ldef.ErrNotFound, spec.Slug)
+		}
+	}
+
+	mr, err := uc.markets(ctx)
+	if err != nil {
+		return Preview{}, err
+	}
+
+	var rows []Row
+	if spec.Target != "" {
+		tgtID, ok := exchangeID(spec.Target)
+		if !ok {
+			return Preview{}, fmt.Errorf("%w: %s", ErrUnknownExchange, spec.Target)
+		}
+		mode := spec.Mode
+		switch mode {
+		case "":
+			mode = modeForTarget(spec.Target)
+		case "upbit", "bithumb", "coinbase", "binance":
+		default:
+			return Preview{}, fmt.Errorf("%w: %s", ErrUnknownMode, mode)
+		}
+		def := ldef.Def{
+			SourceID: srcID, SourceSlug: spec.Source,
+			TargetID: tgtID, TargetSlug: spec.Target,
+			FuturesKinds: spec.FuturesKinds,
+		}
+		if rows, err = buildTargetRows(ctx, mr, def, mode); err != nil {
+			return Preview{}, err
+		}
+	} else {
+		e, err := ValidateSegmentExpr(spec.Segment)
+		if err != nil {
+			return Preview{}, err
+		}
+		quotes := config.LoadQuotes()
+		if spec.Quotes != nil {
+			quotes = *spec.Quotes
+		}
+		sets, err := BuildSets(ctx, contractMarkets{Repo: mr, kinds: spec.FuturesKinds}, quotes)
+		if err != nil {
+			return Preview{}, fmt.Errorf("build sets: %w", err)
+		}
+		seg, err := EvalSegment(sets, spec.Source, e)
+		if err != nil {
+			return Preview{}, err
+		}
+		rows = FromDomainRows(seg)
+	}
+
+	out := Preview{Rows: rows}
+	if spec.Slug != "" {
+		stored, err := uc.Lists.GetRowsBySlug(ctx, spec.Slug)
+		if err != nil {
+			return Preview{}, fmt.Errorf("load %s: %w", spec.Slug, err)
+		}
+		d := DiffRows(FromDomainRows(stored), rows)
+		out.Diff = &d
+	}
+	return out, nil
+}
+
+// DiffRows: что изменится в списке, если old заменить на new (ключ — спот-символ).
+func DiffRows(old, new []Row) PreviewDiff {
+	was := make(map[string]string, len(old))
+	for _, r := range old {
+		was[r.Spot] = r.Futures
+	}
+	d := PreviewDiff{Added: []Row{}, Removed: []Row{}, Changed: []RowChange{}}
+	seen := make(map[string]bool, len(new))
+	for _, r := range new {
+		seen[r.Spot] = true
+		f, ok := was[r.Spot]
+		switch {
+		case !ok:
+			d.Added = append(d.Added, r)
+		case f != r.Futures:
+			d.Changed = append(d.Changed, RowChange{Spot: r.Spot, From: f, To: r.Futures})
+		}
+	}
+	for _, r := range old {
+		if !seen[r.Spot] {
+			d.Removed = append(d.Removed, r)
+		}
+	}
+	sort.Slice(d.Added, func(i, j int) bool { return d.Added[i].Spot < d.Added[j].Spot })
+	sort.Slice(d.Removed, func(i, j int) bool { return d.Removed[i].Spot < d.Removed[j].Spot })
+	sort.Slice(d.Changed, func(i, j int) bool { return d.Changed[i].Spot < d.Changed[j].Spot })
+	return d
+}
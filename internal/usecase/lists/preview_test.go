@@ -0,0 +1,96 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// storedLists — list_items только на чтение; запись в превью — ошибка теста
+type storedLists struct {
+	listsdom.Repo
+	rows map[string][]listsdom.Row
+}
+
+func (s storedLists) GetRowsBySlug(ctx context.Context, slug string) ([]listsdom.Row, error) {
+	return s.rows[slug], nil
+}
+
+func TestPreview(t *testing.T) {
+	perp := "PEPEUSDT"
+	uc := &Interactor{
+		Defs: fakeExplainDefs{targets: []listsdom.Def{{ID: 1, Slug: "binance_to_upbit"}}},
+		Markets: mapMarkets{
+			ExBinance: {
+				item(ExBinance, dm.TypeSpot, "PEPE", "USDT", "PEPEUSDT"),
+				item(ExBinance, dm.TypeFutures, "PEPE", "USDT", "PEPEUSDT"),
+				item(ExBinance, dm.TypeSpot, "ARB", "USDT", "ARBUSDT"),
+				item(ExBinance, dm.TypeSpot, "XRP", "USDT", "XRPUSDT"),
+			},
+			ExUpbit: {
+				item(ExUpbit, dm.TypeSpot, "XRP", "KRW", "KRW-XRP"),
+				item(ExUpbit, dm.TypeSpot, "ARB", "USDT", "USDT-ARB"),
+			},
+		},
+		Lists: storedLists{rows: map[string][]listsdom.Row{
+			"binance_to_upbit": {{Spot: "ARBUSDT", Futures: &perp}, {Spot: "DOGEUSDT"}},
+		}},
+	}
+	ctx := context.Background()
+
+	t.Run("target", func(t *testing.T) {
+		p, err := uc.Preview(ctx, PreviewSpec{Source: "binance", Target: "upbit", Slug: "binance_to_upbit"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// USDT-ARB на Upbit не считается присутствием — ARB остаётся, XRP (KRW) выпадает
+		want := []Row{{Spot: "ARBUSDT", Futures: "none"}, {Spot: "PEPEUSDT", Futures: "PEPEUSDT"}}
+		if !reflect.DeepEqual(p.Rows, want) {
+			t.Fatalf("rows: %+v", p.Rows)
+		}
+		wantDiff := PreviewDiff{
+			Added:   []Row{{Spot: "PEPEUSDT", Futures: "PEPEUSDT"}},
+			Removed: []Row{{Spot: "DOGEUSDT", Futures: "none"}},
+			Changed: []RowChange{{Spot: "ARBUSDT", From: "PEPEUSDT", To: "none"}},
+		}
+		if p.Diff == nil || !reflect.DeepEqual(*p.Diff, wantDiff) {
+			t.Fatalf("diff: %+v", p.Diff)
+		}
+	})
+
+	t.Run("segment with quotes", func(t *testing.T) {
+		quotes := config.QuotesConfig{SourceSpotQuote: "USDT", TargetAllowedQuotes: map[string]struct{}{"KRW": {}}}
+		p, err := uc.Preview(ctx, PreviewSpec{Source: "binance", Segment: "S & U", Quotes: &quotes})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(p.Rows) != 1 || p.Rows[0].Spot != "XRPUSDT" || p.Diff != nil {
+			t.Fatalf("preview: %+v", p)
+		}
+	})
+
+	for name, spec := range map[string]PreviewSpec{
+		"both":          {Source: "binance", Target: "upbit", Segment: "S"},
+		"neither":       {Source: "binance"},
+		"bad source":    {Source: "kraken", Target: "upbit"},
+		"bad mode":      {Source: "binance", Target: "upbit", Mode: "kraken"},
+		"target quotes": {Source: "binance", Target: "upbit", Quotes: &config.QuotesConfig{SourceSpotQuote: "USDC"}},
+		"bad segment":   {Source: "binance", Segment: "S &"},
+	} {
+		if _, err := uc.Preview(ctx, spec); err == nil {
+			t.Fatalf("%s: want error", name)
+		}
+	}
+	// опечатка в slug — не diff «всё добавлено», а ErrNotFound
+	if _, err := uc.Preview(ctx, PreviewSpec{Source: "binance", Target: "upbit", Slug: "binance_to_upbt"}); !errors.Is(err, listsdom.ErrNotFound) {
+		t.Fatalf("unknown slug: want ErrNotFound, got %v", err)
+	}
+	if _, err := uc.Preview(ctx, PreviewSpec{Source: "binance"}); !errors.Is(err, ErrBadPreview) {
+		t.Fatalf("want ErrBadPreview, got %v", err)
+	}
+}
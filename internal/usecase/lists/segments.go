@@ -1,11 +1,8 @@
 package lists
 
 import (
-	"fmt"
-	"sort"
-	"strings"
-
 	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	"github.com/berezovskyivalerii/tickersvc/internal/pkg/setexpr"
 )
 
 type SegmentKind string
@@ -26,76 +23,44 @@ type Segments struct {
 	Seg4 []listsdom.Row
 }
 
-func BuildSegmentsForSource(sets Sets, source string) (Segments, error) {
-    var S map[string]SourceInf
-    switch source {
-    case "binance":
-        S = sets.Binance
-    case "bybit":
-        S = sets.Bybit
-    case "okx":
-        S = sets.OKX
-    default:
-        return Segments{}, fmt.Errorf("unknown source %q", source)
-    }
-
-    // presence-множества уже подготовлены с нужной фильтрацией в BuildSets
-    up, hi, ci := sets.Upbit, sets.Bithumb, sets.Coinbase
-    in := func(m map[string]struct{}, k string) bool { _, ok := m[strings.ToUpper(k)]; return ok }
-
-    seg0 := map[string]struct{}{} // ◯ S \ (U ∪ H ∪ C) — только binance
-    seg1 := map[string]struct{}{} // 🟢 S ∩ ((C ∪ H) \ U)
-    seg2 := map[string]struct{}{} // 🟠 S ∩ ( ((U∩H)\C) ∪ ((U∩C)\H) )
-    seg3 := map[string]struct{}{} // 🔴 S ∩ U ∩ C ∩ H
-    seg4 := map[string]struct{}{} // 🔵 S ∩ (U \ (C ∪ H))
-
-    for base := range S {
-        u, h, c := in(up, base), in(hi, base), in(ci, base)
-
-        if source == "binance" && !u && !h && !c {
-            seg0[base] = struct{}{}
-            continue
-        }
-        if u && c && h {
-            seg3[base] = struct{}{}
-            continue
-        }
-        if u && !c && !h {
-            seg4[base] = struct{}{}
-            continue
-        }
-        if (c || h) && !u {
-            seg1[base] = struct{}{}
-            continue
-        }
-        if u && ((h && !c) || (c && !h)) {
-            seg2[base] = struct{}{}
-            continue
-        }
-    }
-
-    toRows := func(bases map[string]struct{}) []listsdom.Row {
-        out := make([]listsdom.Row, 0, len(bases))
-        for base := range bases {
-            si := S[base]
-            var fut *string
-            if si.FuturesSymbol != "" {
-                f := si.FuturesSymbol
-                fut = &f
-            }
-            out = append(out, listsdom.Row{Spot: si.SpotSymbol, Futures: fut})
-        }
-        sort.Slice(out, func(i, j int) bool { return out[i].Spot < out[j].Spot })
-        return out
-    }
+// DefaultSegmentExprs — выражения стандартных сегментов (ими же засеян list_defs.segment_expr).
+// S — спот источника, U/H/C — Upbit/Bithumb/Coinbase; язык см. в pkg/setexpr.
+var DefaultSegmentExprs = map[SegmentKind]string{
+	Seg0: "S & !(U | H | C)",
+	Seg1: "S & (C | H) & !U",
+	Seg2: "S & U & (H & !C | C & !H)",
+	Seg3: "S & U & C & H",
+	Seg4: "S & U & !(C | H)",
+}
 
-    return Segments{
-        Seg0: toRows(seg0),
-        Seg1: toRows(seg1),
-        Seg2: toRows(seg2),
-        Seg3: toRows(seg3),
-        Seg4: toRows(seg4),
-    }, nil
+func BuildSegmentsForSource(sets Sets, source string) (Segments, error) {
+	eval := func(k SegmentKind) ([]listsdom.Row, error) {
+		e, err := setexpr.Parse(DefaultSegmentExprs[k])
+		if err != nil {
+			return nil, err
+		}
+		return EvalSegment(sets, source, e)
+	}
+	var out Segments
+	for _, seg := range []struct {
+		kind SegmentKind
+		dst  *[]listsdom.Row
+	}{{Seg1, &out.Seg1}, {Seg2, &out.Seg2}, {Seg3, &out.Seg3}, {Seg4, &out.Seg4}} {
+		rows, err := eval(seg.kind)
+		if err != nil {
+			return Segments{}, err
+		}
+		*seg.dst = rows
+	}
+	// ◯ seg0 — только для binance
+	if source == "binance" {
+		rows, err := eval(Seg0)
+		if err != nil {
+			return Segments{}, err
+		}
+		out.Seg0 = rows
+	}
+	return out, nil
 }
 
 func BuildAllSegments(sets Sets) map[string][]listsdom.Row {
@@ -0,0 +1,161 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	"github.com/berezovskyivalerii/tickersvc/internal/pkg/setexpr"
+)
+
+var (
+	ErrUnknownSource  = errors.New("unknown source")
+	ErrSourceRequired = errors.New("expression uses S: source is required")
+)
+
+// sourceIndex — спот-индекс источника для S (BuildSets собирает его только для binance/bybit/okx).
+func sourceIndex(sets Sets, source string) (map[string]SourceInf, bool) {
+	switch source {
+	case "binance":
+		return sets.Binance, true
+	case "bybit":
+		return sets.Bybit, true
+	case "okx":
+		return sets.OKX, true
+	}
+	return nil, false
+}
+
+// SetsBindings — имена множеств для выражений:
+// S — источник (если задан), U/H/C — Upbit/Bithumb/Coinbase, плюс slug-и всех бирж (binance, upbit, ...).
+func SetsBindings(sets Sets, source string) (map[string]setexpr.Set, error) {
+	keys := func(m map[string]SourceInf) setexpr.Set {
+		out := make(setexpr.Set, len(m))
+		for k := range m {
+			out[k] = struct{}{}
+		}
+		return out
+	}
+	out := map[string]setexpr.Set{
+		"binance":  keys(sets.Binance),
+		"bybit":    keys(sets.Bybit),
+		"okx":      keys(sets.OKX),
+		"upbit":    setexpr.Set(sets.Upbit),
+		"bithumb":  setexpr.Set(sets.Bithumb),
+		"coinbase": setexpr.Set(sets.Coinbase),
+	}
+	out["U"], out["H"], out["C"] = out["upbit"], out["bithumb"], out["coinbase"]
+	if source != "" {
+		if _, ok := sourceIndex(sets, source); !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownSource, source)
+		}
+		out["S"] = out[source]
+	}
+	return out, nil
+}
+
+// ValidateSegmentExpr разбирает выражение и проверяет имена множеств (S, U, H, C, slug-и бирж).
+func ValidateSegmentExpr(expr string) (*setexpr.Expr, error) {
+	e, err := setexpr.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	known, _ := SetsBindings(Sets{}, "binance")
+	if err := e.Validate(func(n string) bool { _, ok := known[n]; return ok }); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// EvalSegment — строки сегмента источника. Базы вне S отбрасываются: у них нет спот-символа источника.
+func EvalSegment(sets Sets, source string, e *setexpr.Expr) ([]listsdom.Row, error) {
+	S, ok := sourceIndex(sets, source)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSource, source)
+	}
+	b, err := SetsBindings(sets, source)
+	if err != nil {
+		return nil, err
+	}
+	bases, err := e.Eval(b, nil)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]listsdom.Row, 0, len(bases))
+	for base := range bases {
+		si, ok := S[base]
+		if !ok {
+			continue
+		}
+		var fut *string
+		if si.FuturesSymbol != "" {
+			f := si.FuturesSymbol
+			fut = &f
+		}
+		out = append(out, listsdom.Row{Spot: si.SpotSymbol, Futures: fut})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Spot < out[j].Spot })
+	return out, nil
+}
+
+// QueryItem — база из результата; Spot/Futures — символы источника, если он задан и база в S.
+type QueryItem struct {
+	Base    string
+	Spot    string
+	Futures string
+}
+
+type QueryResult struct {
+	Expr   string // каноническая запись
+	Source string
+	Items  []QueryItem // по Base
+}
+
+// Query вычисляет выражение на живых данных (фьючерсы — DefaultContractKinds, как у сегментов по умолчанию).
+func (uc *Interactor) Query(ctx context.Context, expr, source string) (QueryResult, error) {
+	e, err := ValidateSegmentExpr(expr)
+	if err != nil {
+		return QueryResult{}, err
+	}
+	if source == "" {
+		for _, n := range e.Names() {
+			if n == "S" {
+				return QueryResult{}, ErrSourceRequired
+			}
+		}
+	} else if _, ok := sourceIndex(Sets{}, source); !ok {
+		return QueryResult{}, fmt.Errorf("%w: %s", ErrUnknownSource, source)
+	}
+
+	mr, err := uc.markets(ctx)
+	if err != nil {
+		return QueryResult{}, err
+	}
+	sets, err := BuildSets(ctx, contractMarkets{Repo: mr, kinds: dm.DefaultContractKinds}, config.LoadQuotes())
+	if err != nil {
+		return QueryResult{}, fmt.Errorf("build sets: %w", err)
+	}
+	b, err := SetsBindings(sets, source)
+	if err != nil {
+		return QueryResult{}, err
+	}
+	bases, err := e.Eval(b, nil)
+	if err != nil {
+		return QueryResult{}, err
+	}
+
+	S, _ := sourceIndex(sets, source)
+	out := QueryResult{Expr: e.String(), Source: source, Items: make([]QueryItem, 0, len(bases))}
+	for _, base := range bases.Sorted() {
+		it := QueryItem{Base: base}
+		if si, ok := S[base]; ok {
+			it.Spot, it.Futures = si.SpotSymbol, si.FuturesSymbol
+		}
+		out.Items = append(out.Items, it)
+	}
+	return out, nil
+}
@@ -0,0 +1,167 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+	"github.com/berezovskyivalerii/tickersvc/internal/pkg/setexpr"
+)
+
+// legacySegment — прежняя if-цепочка из BuildSegmentsForSource (до выражений).
+func legacySegment(source string, u, h, c bool) SegmentKind {
+	switch {
+	case source == "binance" && !u && !h && !c:
+		return Seg0
+	case u && c && h:
+		return Seg3
+	case u && !c && !h:
+		return Seg4
+	case (c || h) && !u:
+		return Seg1
+	case u && ((h && !c) || (c && !h)):
+		return Seg2
+	}
+	return ""
+}
+
+// Выражения по умолчанию дают те же сегменты, что и старая логика, на всех 8 комбинациях U/H/C.
+func TestDefaultSegmentExprs_MatchLegacy(t *testing.T) {
+	sets := Sets{Binance: map[string]SourceInf{}, Upbit: map[string]struct{}{}, Bithumb: map[string]struct{}{}, Coinbase: map[string]struct{}{}}
+	want := map[string]SegmentKind{}
+	for i := 0; i < 8; i++ {
+		u, h, c := i&1 != 0, i&2 != 0, i&4 != 0
+		base := string(rune('A' + i))
+		sets.Binance[base] = SourceInf{Base: base, SpotSymbol: base + "USDT"}
+		if u {
+			sets.Upbit[base] = struct{}{}
+		}
+		if h {
+			sets.Bithumb[base] = struct{}{}
+		}
+		if c {
+			sets.Coinbase[base] = struct{}{}
+		}
+		want[base+"USDT"] = legacySegment("binance", u, h, c)
+	}
+
+	segs, err := BuildSegmentsForSource(sets, "binance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]SegmentKind{}
+	for kind, rows := range map[SegmentKind][]listsdom.Row{Seg0: segs.Seg0, Seg1: segs.Seg1, Seg2: segs.Seg2, Seg3: segs.Seg3, Seg4: segs.Seg4} {
+		for _, r := range rows {
+			if prev, dup := got[r.Spot]; dup {
+				t.Fatalf("%s in %s and %s", r.Spot, prev, kind)
+			}
+			got[r.Spot] = kind
+		}
+	}
+	for spot, k := range want {
+		if got[spot] != k {
+			t.Fatalf("%s: got %q, want %q", spot, got[spot], k)
+		}
+	}
+}
+
+func TestEvalSegment_Custom(t *testing.T) {
+	sets := Sets{
+		Bybit: map[string]SourceInf{
+			"PEPE": {Base: "PEPE", SpotSymbol: "PEPEUSDT", FuturesSymbol: "1000PEPEUSDT"},
+			"ARB":  {Base: "ARB", SpotSymbol: "ARBUSDT"},
+		},
+		Binance:  map[string]SourceInf{"ARB": {}},
+		Upbit:    map[string]struct{}{"PEPE": {}, "ARB": {}, "XRP": {}},
+		Bithumb:  map[string]struct{}{},
+		Coinbase: map[string]struct{}{},
+	}
+	// на Bybit и Upbit, но не на Binance; XRP не в S — отбрасывается
+	e, err := ValidateSegmentExpr("S & upbit - binance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := EvalSegment(sets, "bybit", e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].Spot != "PEPEUSDT" || rows[0].Futures == nil || *rows[0].Futures != "1000PEPEUSDT" {
+		t.Fatalf("rows: %+v", rows)
+	}
+	if _, err := EvalSegment(sets, "robinhood", e); !errors.Is(err, ErrUnknownSource) {
+		t.Fatalf("unknown source: %v", err)
+	}
+}
+
+func TestValidateSegmentExpr(t *testing.T) {
+	if _, err := ValidateSegmentExpr("S & kraken"); !errors.Is(err, setexpr.ErrUnknownSet) {
+		t.Fatalf("unknown set: %v", err)
+	}
+	var se *setexpr.SyntaxError
+	if _, err := ValidateSegmentExpr("S &"); !errors.As(err, &se) {
+		t.Fatalf("syntax: %v", err)
+	}
+}
+
+type fakeSegDefs struct {
+	listsdom.DefsRepo
+	defs []listsdom.SegmentDef
+}
+
+func (f fakeSegDefs) SegmentDefs(ctx context.Context) ([]listsdom.SegmentDef, error) { return f.defs, nil }
+
+type recLists struct {
+	listsdom.Repo
+	saved     map[int16][]listsdom.Item
+	published int // сколько поколений опубликовано
+}
+
+func (r *recLists) ReplaceByListID(ctx context.Context, id int16, items []listsdom.Item) (int, error) {
+	r.saved[id] = items
+	return len(items), nil
+}
+
+func (r *recLists) Publish(ctx context.Context, lists map[int16][]listsdom.Item) (listsdom.Generation, error) {
+	for id, items := range lists {
+		r.saved[id] = items
+	}
+	r.published++
+	return listsdom.Generation{ID: int64(r.published)}, nil
+}
+
+func TestRebuildSegments_FromDefs(t *testing.T) {
+	lists := &recLists{saved: map[int16][]listsdom.Item{}}
+	uc := &Interactor{
+		Defs: fakeSegDefs{defs: []listsdom.SegmentDef{
+			{ID: 1, Slug: "binance_seg4", SourceSlug: "binance", Segment: "seg4", Expr: DefaultSegmentExprs[Seg4]},
+			{ID: 2, Slug: "binance_krw", SourceSlug: "binance", Segment: "krw", Expr: "S & (U | H)"},
+			{ID: 3, Slug: "okx_seg3", SourceSlug: "okx", Segment: "seg3", Expr: DefaultSegmentExprs[Seg3]},
+		}},
+		Markets: mapMarkets{
+			ExBinance: {
+				item(ExBinance, dm.TypeSpot, "PEPE", "USDT", "PEPEUSDT"),
+				item(ExBinance, dm.TypeSpot, "ARB", "USDT", "ARBUSDT"),
+			},
+			ExUpbit:   {item(ExUpbit, dm.TypeSpot, "PEPE", "KRW", "KRW-PEPE")},
+			ExBithumb: {item(ExBithumb, dm.TypeSpot, "ARB", "KRW", "ARB_KRW")},
+		},
+		Lists: lists,
+	}
+	t.Setenv("TARGET_ALLOWED_QUOTES", "KRW")
+
+	res, err := uc.RebuildSegments(context.Background(), "binance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 2 || res["binance_seg4"] != 1 || res["binance_krw"] != 2 {
+		t.Fatalf("res: %v", res)
+	}
+	if got := lists.saved[1]; len(got) != 1 || got[0].Spot != "PEPEUSDT" {
+		t.Fatalf("seg4: %+v", got)
+	}
+	if _, ok := lists.saved[3]; ok {
+		t.Fatalf("okx filtered out by sources")
+	}
+}
@@ -3,79 +3,83 @@ package lists
 import (
 	"context"
 	"fmt"
-	"slices"
 	"strings"
+	"sync"
 
-	listsdom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
 	"github.com/berezovskyivalerii/tickersvc/internal/config"
+	ldef "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
 )
 
+// RebuildSegments пересобирает сегменты из list_defs по их выражениям (segment_expr) одним поколением.
+// sources — фильтр по источнику (пусто — все).
 func (uc *Interactor) RebuildSegments(ctx context.Context, sources ...string) (map[string]int, error) {
-	// 1) множества
-	quotes := config.LoadQuotes()
-	sets, err := BuildSets(ctx, uc.Markets, quotes)
+	res, err := uc.RebuildAll(ctx, RebuildSpec{Segments: true, SegmentSources: sources})
 	if err != nil {
-		return nil, fmt.Errorf("build sets: %w", err)
+		return nil, err
 	}
+	return res.Segments, nil
+}
 
-	// 2) сегменты
-	all := BuildAllSegments(sets) // map[slug][]listsdom.Row
-
-	// 3) фильтр по источникам (по префиксу slug)
+// segmentJobs — задания на сегменты из list_defs; sources — фильтр по источнику (пусто — все).
+func (uc *Interactor) segmentJobs(ctx context.Context, sources []string, plan *rebuildPlan) ([]buildJob, error) {
+	// 1) сегменты и их выражения
+	defs, err := uc.Defs.SegmentDefs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("load segment defs: %w", err)
+	}
 	allow := map[string]bool{}
-	if len(sources) > 0 {
-		for _, s := range sources {
-			switch strings.ToLower(strings.TrimSpace(s)) {
-			case "binance", "bybit", "okx":
-				allow[s] = true
-			}
-		}
+	for _, s := range sources {
+		allow[strings.ToLower(strings.TrimSpace(s))] = true
 	}
-	filtered := make(map[string][]listsdom.Row, len(all))
-	for slug, rows := range all {
-		if len(allow) == 0 {
-			filtered[slug] = rows
-			continue
-		}
-		if strings.HasPrefix(slug, "binance_") && allow["binance"] {
-			filtered[slug] = rows
-		}
-		if strings.HasPrefix(slug, "bybit_") && allow["bybit"] {
-			filtered[slug] = rows
+
+	// 2) множества; по одному набору на каждый вид колонки фьючерсов (обычно только linear_perp),
+	// общие для всех заданий
+	quotes := config.LoadQuotes()
+	var mu sync.Mutex
+	byKinds := map[string]Sets{}
+	setsFor := func(ctx context.Context, mr dm.Repo, kinds []dm.ContractKind) (Sets, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		key := kindsKey(kinds)
+		if sets, ok := byKinds[key]; ok {
+			return sets, nil
 		}
-		if strings.HasPrefix(slug, "okx_") && allow["okx"] {
-			filtered[slug] = rows
+		sets, err := BuildSets(ctx, contractMarkets{Repo: mr, kinds: kinds}, quotes)
+		if err != nil {
+			return Sets{}, fmt.Errorf("build sets: %w", err)
 		}
+		byKinds[key] = sets
+		return sets, nil
 	}
 
-	// 4) список slug-ов, которые реально хотим сохранять
-	slugs := make([]string, 0, len(filtered))
-	for s := range filtered {
-		slugs = append(slugs, s)
-	}
-	slices.Sort(slugs)
-
-	// 5) найдём id списков по slug через DefsRepo
-	slug2id, err := uc.Defs.IDsBySlugs(ctx, slugs)
-	if err != nil {
-		return nil, fmt.Errorf("load list ids: %w", err)
-	}
-
-	// 6) сохраняем
-	res := make(map[string]int, len(slugs))
-	for _, slug := range slugs {
-		id, ok := slug2id[slug]
-		if !ok {
-			// slug ещё не засеян в list_defs — пропускаем молча
+	// 3) задания
+	var jobs []buildJob
+	for _, d := range defs {
+		if len(allow) > 0 && !allow[d.SourceSlug] {
 			continue
 		}
-		rowsCompat := FromDomainRows(filtered[slug]) // доменные → локальные Row (Futures string)
-		items := RowsToItems(rowsCompat)             // "none" → NULL
-		n, err := uc.Lists.ReplaceByListID(ctx, id, items)
+		e, err := ValidateSegmentExpr(d.Expr)
 		if err != nil {
-			return nil, fmt.Errorf("save %s: %w", slug, err)
+			return nil, fmt.Errorf("segment %s: %w", d.Slug, err)
+		}
+		if !plan.need(d.ID, d.Slug, plan.segmentKey(d, quotes), segmentDeps(d.SourceSlug, e)...) {
+			continue
 		}
-		res[slug] = n
+		jobs = append(jobs, buildJob{
+			id: d.ID, slug: d.Slug, segment: true, reads: setsExchanges,
+			build: func(ctx context.Context, mr dm.Repo) ([]ldef.Item, error) {
+				sets, err := setsFor(ctx, mr, d.FuturesKinds)
+				if err != nil {
+					return nil, err
+				}
+				rows, err := EvalSegment(sets, d.SourceSlug, e)
+				if err != nil {
+					return nil, fmt.Errorf("segment %s: %w", d.Slug, err)
+				}
+				return RowsToItems(FromDomainRows(rows)), nil // "none" → NULL
+			},
+		})
 	}
-	return res, nil
+	return jobs, nil
 }
@@ -15,6 +15,7 @@ const (
 	ExCoinbase int16 = 4 
 	ExUpbit int16 = 5 
 	ExBithumb int16 = 6 
+	ExRobinhood int16 = 7
 	)
 
 type SourceInf struct { 
@@ -114,7 +115,7 @@ func BuildSets(ctx context.Context, mr dm.Repo, quotes config.QuotesConfig) (Set
 				 Coinbase: map[string]struct{}{}, 
 	} 
 	srcQuote := strings.ToUpper(quotes.SourceSpotQuote) 
-	type futMap = map[string]string 
+	type futMap = futPicks
 	futB, futY, futO := futMap{}, futMap{}, futMap{} 
 	// --- источники --- 
 	handleSource := func(items []dm.Item, ex int16, spot map[string]SourceInf, futs futMap) { 
@@ -128,9 +129,7 @@ func BuildSets(ctx context.Context, mr dm.Repo, quotes config.QuotesConfig) (Set
 						spot[base] = SourceInf{Base: base, SpotSymbol: it.Symbol} 
 					} 
 				case dm.TypeFutures: 
-					if _, ok := futs[base]; !ok {
-						futs[base] = it.Symbol 
-					} 
+					futs.add(it) // ключ — Underlying: 1000PEPE → PEPE
 				} 
 			} 
 	} 
@@ -141,21 +140,19 @@ func BuildSets(ctx context.Context, mr dm.Repo, quotes config.QuotesConfig) (Set
 	handleSource(okx, ExOKX, out.OKX, futO) 
 
 	for base, si := range out.Binance { 
-		if f, ok := futB[base]; ok { si.FuturesSymbol = f 
+		if f := futB.symbol(base); f != "" { si.FuturesSymbol = f 
 			out.Binance[base] = si } 
 	} 
 	for base, si := range out.Bybit { 
-		if f, ok := futY[base]; ok { si.FuturesSymbol = f 
+		if f := futY.symbol(base); f != "" { si.FuturesSymbol = f 
 		out.Bybit[base] = si } 
 	} 
 	for base, si := range out.OKX { 
-		if f, ok := futO[base]; ok { 
+		if f := futO.symbol(base); f != "" { 
 			si.FuturesSymbol = f 
 			out.OKX[base] = si 
 		} 
 	} 
-	isAllowed := func(q string) bool { _, ok := quotes.TargetAllowedQuotes[q] 
-		return ok } 
 	handleTarget := func(items []dm.Item, dst map[string]struct{}, allow func(q string) bool) { 
 		for _, it := range items { 
 			if it.Type != dm.TypeSpot { continue } 
@@ -164,14 +161,9 @@ func BuildSets(ctx context.Context, mr dm.Repo, quotes config.QuotesConfig) (Set
 			base := strings.ToUpper(it.Base) 
 			dst[base] = struct{}{} } 
 		} 
-	allowUpOrBithumb := func(q string) bool { 
-		if !isAllowed(q) { return false } 
-		return q != "USDT" && q != "BTC" 
-		} 
-		allowCoinbase := func(q string) bool { return isAllowed(q) } 
-		handleTarget(upb, out.Upbit, allowUpOrBithumb) 
-		handleTarget(bth, out.Bithumb, allowUpOrBithumb) 
-		handleTarget(cnb, out.Coinbase, allowCoinbase) 
+		handleTarget(upb, out.Upbit, countedQuote(ExUpbit, quotes)) 
+		handleTarget(bth, out.Bithumb, countedQuote(ExBithumb, quotes)) 
+		handleTarget(cnb, out.Coinbase, countedQuote(ExCoinbase, quotes)) 
 	return out, nil 
 }
 
@@ -0,0 +1,142 @@
+package lists
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	ldef "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// setsExchanges — биржи, которые читает BuildSets (любой сегмент нуждается во всех).
+var setsExchanges = []int16{ExBinance, ExBybit, ExOKX, ExUpbit, ExBithumb, ExCoinbase}
+
+// snapshotMarkets — рынки одного прогона пересборки: каждая нужная биржа читается один раз
+// (с алиасами) и делится между всеми списками и сегментами. После загрузки не меняется —
+// читать можно из нескольких горутин.
+type snapshotMarkets struct {
+	dm.Repo
+	items map[int16][]dm.Item
+}
+
+func (m snapshotMarkets) LoadActiveByExchange(ctx context.Context, exchangeID int16) ([]dm.Item, error) {
+	items, ok := m.items[exchangeID]
+	if !ok {
+		return nil, fmt.Errorf("exchange %d is not in the markets snapshot", exchangeID)
+	}
+	return items, nil
+}
+
+// snapshot загружает биржи ids. Если репозиторий умеет LoadActiveByExchanges — одним запросом,
+// иначе по бирже (каждая — один раз).
+func (uc *Interactor) snapshot(ctx context.Context, ids []int16) (dm.Repo, error) {
+	res, err := uc.resolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return uc.snapshotWith(ctx, res, ids)
+}
+
+// snapshotWith — snapshot с уже загруженными алиасами (RebuildAll берёт их из плана).
+func (uc *Interactor) snapshotWith(ctx context.Context, res assets.Resolver, ids []int16) (dm.Repo, error) {
+	var err error
+	var all map[int16][]dm.Item
+	if l, ok := uc.Markets.(dm.SnapshotLoader); ok {
+		if all, err = l.LoadActiveByExchanges(ctx, ids...); err != nil {
+			return nil, fmt.Errorf("load markets snapshot: %w", err)
+		}
+	} else {
+		all = make(map[int16][]dm.Item, len(ids))
+		for _, id := range ids {
+			items, err := uc.Markets.LoadActiveByExchange(ctx, id)
+			if err != nil {
+				return nil, fmt.Errorf("load exchange %d: %w", id, err)
+			}
+			all[id] = items
+		}
+	}
+	items := make(map[int16][]dm.Item, len(ids))
+	for _, id := range ids {
+		items[id] = CanonItems(res, all[id]) // у биржи без рынков — пустой срез, а не «не загружено»
+	}
+	return snapshotMarkets{Repo: uc.Markets, items: items}, nil
+}
+
+// buildJob — один список пересборки.
+type buildJob struct {
+	id      int16
+	slug    string
+	segment bool
+	reads   []int16 // биржи, которые читает build
+	build   func(ctx context.Context, mr dm.Repo) ([]ldef.Item, error)
+}
+
+// jobsExchanges — объединение reads всех заданий, по возрастанию.
+func jobsExchanges(jobs []buildJob) []int16 {
+	seen := map[int16]bool{}
+	var out []int16
+	for _, j := range jobs {
+		for _, id := range j.reads {
+			if !seen[id] {
+				seen[id] = true
+				out = append(out, id)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func (uc *Interactor) workers() int {
+	if uc.Workers > 0 {
+		return uc.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// runJobs строит списки не более чем в workers горутин; результат — в порядке jobs.
+// Первая ошибка отменяет оставшиеся задания.
+func runJobs(ctx context.Context, mr dm.Repo, jobs []buildJob, workers int) ([][]ldef.Item, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	out := make([][]ldef.Item, len(jobs))
+	next := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for w := 0; w < min(workers, len(jobs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				items, err := jobs[i].build(ctx, mr)
+				if err != nil {
+					errOnce.Do(func() { firstErr = err; cancel() })
+					continue
+				}
+				out[i] = items
+			}
+		}()
+	}
+feed:
+	for i := range jobs {
+		select {
+		case next <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return out, ctx.Err()
+}
@@ -26,6 +26,7 @@ func (u *Updater) Update(ctx context.Context, sourceSlug, targetSlug *string) (m
 		target, err := u.MRepo.LoadActiveByExchange(ctx, d.TargetID)
 		if err != nil { return nil, err }
 
+		source = dm.FilterContracts(source, d.FuturesKinds)
 		rows := BuildListRows(source, target, modeForTarget(d.TargetSlug))
 		inserted, err := u.Saver.ReplaceBySlug(ctx, d.Slug, RowsToItems(rows))
 		if err != nil { return nil, err }
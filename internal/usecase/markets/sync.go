@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/events"
 	"github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
 )
 
@@ -15,6 +16,9 @@ type Orchestrator struct {
 	Fetchers []markets.Fetcher
 	Timeout  time.Duration
 	Logger   *slog.Logger
+	Events   events.Publisher // nil — без событий о листингах/делистингах
+	// OnVolumes — после удачной записи оборотов (сброс кэша списков: volume_usd есть в строках)
+	OnVolumes func()
 }
 
 func (o *Orchestrator) log() *slog.Logger {
@@ -54,8 +58,13 @@ func (o *Orchestrator) RunAll(ctx context.Context) (map[int16][3]int, error) {
 				return
 			}
 
+			markets.AnnotateMultipliers(spot, fut) // 1000PEPE → PEPE x1000
 			items := append(spot, fut...)
-			a,u,d,err := o.Repo.SyncSnapshot(cctx, f.ExchangeID(), items)
+			var types []markets.Type // что скачалось, то и архивируется
+			if errS == nil { types = append(types, markets.TypeSpot) }
+			if errF == nil { types = append(types, markets.TypeFutures) }
+			prev, tracked := o.before(cctx, f, types, l)
+			a,u,d,err := o.Repo.SyncSnapshot(cctx, f.ExchangeID(), types, items)
 			if err != nil {
 				l.Warn("sync failed", "err", err)
 				mu.Lock(); out[f.ExchangeID()] = [3]int{0,0,0}; mu.Unlock()
@@ -63,6 +72,8 @@ func (o *Orchestrator) RunAll(ctx context.Context) (map[int16][3]int, error) {
 				return
 			}
 			l.Info("sync done", "added", a, "updated", u, "archived", d)
+			o.publishChanges(cctx, f, prev, items, tracked, l) // вернувшиеся из архива считаются в updated
+			o.syncVolumes(cctx, f, spot, l)
 
 			mu.Lock(); out[f.ExchangeID()] = [3]int{a,u,d}; mu.Unlock()
 			okMu.Lock(); ok = true; okMu.Unlock()
@@ -79,3 +90,105 @@ func (o *Orchestrator) RunAll(ctx context.Context) (map[int16][3]int, error) {
 	if !ok && len(errs) > 0 { return out, errors.Join(errs...) }
 	return out, nil
 }
+
+// syncVolumes — 24h-оборот спота в USD, если биржа отдаёт тикеры, а репозиторий умеет их хранить.
+// Ошибка не валит синк: у рынков остаётся прошлое значение (см. volume_at).
+func (o *Orchestrator) syncVolumes(ctx context.Context, f markets.Fetcher, spot []markets.Item, l *slog.Logger) {
+	tf, ok := f.(markets.TickerFetcher)
+	if !ok {
+		return
+	}
+	vr, ok := o.Repo.(markets.VolumeRepo)
+	if !ok {
+		return
+	}
+	tickers, err := tf.FetchSpotTickers(ctx)
+	if err != nil {
+		l.Warn("tickers fetch failed", "err", err)
+		return
+	}
+	n, err := vr.SaveVolumes(ctx, f.ExchangeID(), markets.USDVolumes(tickers, spot), time.Now().UTC())
+	if err != nil {
+		l.Warn("volumes save failed", "err", err)
+		return
+	}
+	l.Info("volumes saved", "markets", n)
+	if n > 0 && o.OnVolumes != nil {
+		o.OnVolumes()
+	}
+}
+
+// before — активные рынки биржи до синка (для событий) и типы, снимок которых получен целиком:
+// если фьючерсы не скачались, их «пропажа» из снимка — не делистинг.
+func (o *Orchestrator) before(ctx context.Context, f markets.Fetcher, types []markets.Type, l *slog.Logger) ([]markets.Item, map[markets.Type]bool) {
+	if o.Events == nil {
+		return nil, nil
+	}
+	prev, err := o.Repo.LoadActiveByExchange(ctx, f.ExchangeID())
+	if err != nil {
+		l.Warn("load active failed, no market events", "err", err)
+		return nil, nil
+	}
+	tracked := make(map[markets.Type]bool, len(types))
+	for _, t := range types {
+		tracked[t] = true
+	}
+	return prev, tracked
+}
+
+// publishChanges — события о рынках, которые синк добавил или архивировал (сравнение снимка с тем,
+// что было активно до него). Пустая биржа (первое наполнение) событий не порождает.
+// Рынки, уже добавленные детектором листингов, в prev есть — повторного события нет.
+func (o *Orchestrator) publishChanges(ctx context.Context, f markets.Fetcher, prev, items []markets.Item, tracked map[markets.Type]bool, l *slog.Logger) {
+	if len(prev) == 0 || tracked == nil {
+		return
+	}
+	type key struct {
+		t   markets.Type
+		sym string
+	}
+	was := make(map[key]bool, len(prev))
+	for _, it := range prev {
+		was[key{it.Type, it.Symbol}] = true
+	}
+	now := make(map[key]bool, len(items))
+	for _, it := range items {
+		now[key{it.Type, it.Symbol}] = true
+	}
+
+	at := time.Now().UTC()
+	ev := func(k events.Kind, it markets.Item) events.Event {
+		return events.Event{
+			Kind: k, Priority: events.PriorityNormal, At: at,
+			Exchange: f.Name(), Market: string(it.Type), Symbol: it.Symbol, Base: it.Base, Quote: it.Quote,
+		}
+	}
+	var out []events.Event
+	for _, it := range items {
+		if !was[key{it.Type, it.Symbol}] {
+			out = append(out, ev(events.KindListing, it))
+		}
+	}
+	for _, it := range prev {
+		if tracked[it.Type] && !now[key{it.Type, it.Symbol}] {
+			out = append(out, ev(events.KindDelisting, it))
+		}
+	}
+	for _, e := range out {
+		if err := o.Events.Publish(ctx, e); err != nil {
+			l.Warn("publish event failed", "kind", e.Kind, "symbol", e.Symbol, "err", err)
+		}
+	}
+}
+
+// ChangedExchanges — биржи, на которых синк что-то добавил, обновил или архивировал.
+// Биржи с ошибкой синка в сводке нулевые — их списки не пересобираются.
+func ChangedExchanges(summary map[int16][3]int) map[int16]bool {
+	out := make(map[int16]bool, len(summary))
+	for id, v := range summary {
+		if v != [3]int{} {
+			out[id] = true
+		}
+	}
+	return out
+}
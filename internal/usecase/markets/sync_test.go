@@ -2,9 +2,12 @@ package marketsuc_test
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/events"
 	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
 	uc "github.com/berezovskyivalerii/tickersvc/internal/usecase/markets"
 )
@@ -22,7 +25,7 @@ func (f fakeFetcher) FetchFutures(ctx context.Context) ([]dm.Item, error) { retu
 
 type fakeRepo struct{ calls int }
 
-func (r *fakeRepo) SyncSnapshot(ctx context.Context, ex int16, items []dm.Item) (int, int, int, error) {
+func (r *fakeRepo) SyncSnapshot(ctx context.Context, ex int16, types []dm.Type, items []dm.Item) (int, int, int, error) {
 	r.calls++
 	return 1, 2, 3, nil
 }
@@ -52,3 +55,173 @@ func TestOrchestrator_RunAll(t *testing.T) {
 		t.Fatalf("summary = %v", got)
 	}
 }
+
+func TestChangedExchanges(t *testing.T) {
+	got := uc.ChangedExchanges(map[int16][3]int{1: {0, 0, 0}, 2: {0, 3, 0}, 5: {0, 0, 1}})
+	if len(got) != 2 || !got[2] || !got[5] {
+		t.Fatalf("changed = %v", got)
+	}
+}
+
+type tickerFetcher struct {
+	fakeFetcher
+	tickers []dm.Ticker
+}
+
+func (f tickerFetcher) FetchSpotTickers(ctx context.Context) ([]dm.Ticker, error) { return f.tickers, nil }
+
+type volumeRepo struct {
+	fakeRepo
+	vols map[string]float64
+}
+
+func (r *volumeRepo) SaveVolumes(ctx context.Context, ex int16, vols map[string]float64, at time.Time) (int, error) {
+	r.vols = vols
+	return len(vols), nil
+}
+
+func TestOrchestrator_RunAll_Volumes(t *testing.T) {
+	f := tickerFetcher{
+		fakeFetcher: fakeFetcher{id: 5, spot: []dm.Item{
+			{ExchangeID: 5, Type: dm.TypeSpot, Symbol: "KRW-USDT", Base: "USDT", Quote: "KRW"},
+			{ExchangeID: 5, Type: dm.TypeSpot, Symbol: "KRW-AAA", Base: "AAA", Quote: "KRW"},
+		}},
+		tickers: []dm.Ticker{
+			{Symbol: "KRW-USDT", Last: 1000, QuoteVolume: 1e9},
+			{Symbol: "KRW-AAA", Last: 10, QuoteVolume: 5e8},
+		},
+	}
+	repo := &volumeRepo{}
+	var flushed int
+	orc := &uc.Orchestrator{Repo: repo, Fetchers: []dm.Fetcher{f}, Timeout: 2 * time.Second, OnVolumes: func() { flushed++ }}
+	if _, err := orc.RunAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if repo.vols["KRW-AAA"] != 5e5 || repo.vols["KRW-USDT"] != 1e6 || flushed != 1 {
+		t.Fatalf("vols = %v flushed=%d", repo.vols, flushed)
+	}
+
+	// без тикеров у биржи — объёмы не пишутся
+	repo = &volumeRepo{}
+	orc.Repo, orc.Fetchers = repo, []dm.Fetcher{f.fakeFetcher}
+	if _, err := orc.RunAll(context.Background()); err != nil || repo.vols != nil || flushed != 1 {
+		t.Fatalf("err=%v vols=%v flushed=%d", err, repo.vols, flushed)
+	}
+}
+
+type snapRepo struct {
+	fakeRepo
+	active []dm.Item
+}
+
+func (r *snapRepo) LoadActiveByExchange(ctx context.Context, ex int16) ([]dm.Item, error) {
+	return r.active, nil
+}
+
+type sink struct{ got []events.Event }
+
+func (s *sink) Publish(ctx context.Context, e events.Event) error {
+	s.got = append(s.got, e)
+	return nil
+}
+
+func TestOrchestrator_RunAll_Events(t *testing.T) {
+	spot := func(sym, base string) dm.Item {
+		return dm.Item{ExchangeID: 1, Type: dm.TypeSpot, Symbol: sym, Base: base, Quote: "USDT"}
+	}
+	f := fakeFetcher{id: 1, spot: []dm.Item{spot("AAAUSDT", "AAA"), spot("NEWUSDT", "NEW")}}
+	repo := &snapRepo{active: []dm.Item{
+		spot("AAAUSDT", "AAA"),
+		spot("OLDUSDT", "OLD"),
+		{ExchangeID: 1, Type: dm.TypeFutures, Symbol: "AAAUSDT", Base: "AAA", Quote: "USDT"},
+	}}
+	s := &sink{}
+	orc := &uc.Orchestrator{Repo: repo, Fetchers: []dm.Fetcher{f}, Events: s}
+
+	if _, err := orc.RunAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]events.Kind{}
+	for _, e := range s.got {
+		if e.Exchange != "fake" || e.Priority != events.PriorityNormal {
+			t.Fatalf("event = %+v", e)
+		}
+		got[e.Market+":"+e.Symbol] = e.Kind
+	}
+	want := map[string]events.Kind{
+		"spot:NEWUSDT":    events.KindListing,
+		"spot:OLDUSDT":    events.KindDelisting,
+		"futures:AAAUSDT": events.KindDelisting,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("events = %v", got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("events = %v", got)
+		}
+	}
+
+	// первое наполнение биржи — без событий
+	repo.active, s.got = nil, nil
+	if _, err := orc.RunAll(context.Background()); err != nil || len(s.got) != 0 {
+		t.Fatalf("first fill: %v %v", s.got, err)
+	}
+}
+
+// memRepo — активные рынки в памяти; архивирует только переданные типы, как MarketsRepo.
+type memRepo struct{ active []dm.Item }
+
+func (r *memRepo) SyncSnapshot(ctx context.Context, ex int16, types []dm.Type, items []dm.Item) (int, int, int, error) {
+	keep := items
+	for _, it := range r.active {
+		if !slices.Contains(types, it.Type) {
+			keep = append(keep, it)
+		}
+	}
+	r.active = keep
+	return 0, 0, 0, nil
+}
+
+func (r *memRepo) LoadActiveByExchange(ctx context.Context, ex int16) ([]dm.Item, error) {
+	return r.active, nil
+}
+
+// flakyFetcher — фьючерсы отдаёт с ошибкой, пока failFut.
+type flakyFetcher struct {
+	fakeFetcher
+	failFut *bool
+}
+
+func (f flakyFetcher) FetchFutures(ctx context.Context) ([]dm.Item, error) {
+	if *f.failFut {
+		return nil, errors.New("futures down")
+	}
+	return f.fut, nil
+}
+
+func TestOrchestrator_RunAll_FuturesOutage(t *testing.T) {
+	spot := dm.Item{ExchangeID: 1, Type: dm.TypeSpot, Symbol: "AAAUSDT", Base: "AAA", Quote: "USDT"}
+	fut := dm.Item{ExchangeID: 1, Type: dm.TypeFutures, Symbol: "AAAUSDT", Base: "AAA", Quote: "USDT"}
+	failFut := true
+	f := flakyFetcher{fakeFetcher: fakeFetcher{id: 1, spot: []dm.Item{spot}, fut: []dm.Item{fut}}, failFut: &failFut}
+	repo := &memRepo{active: []dm.Item{spot, fut}}
+	s := &sink{}
+	orc := &uc.Orchestrator{Repo: repo, Fetchers: []dm.Fetcher{f}, Events: s}
+
+	// сбой фьючерсов: они остаются активными
+	if _, err := orc.RunAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.active) != 2 {
+		t.Fatalf("active after outage = %v", repo.active)
+	}
+	// фьючерсы вернулись — это не листинг
+	failFut = false
+	if _, err := orc.RunAll(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.got) != 0 {
+		t.Fatalf("events = %+v", s.got)
+	}
+}
@@ -0,0 +1,231 @@
+// Package notify — человекочитаемые уведомления о рынках (листинги, делистинги) в чаты и почту:
+// маршрутизация по бирже и типу события, дедупликация, склейка событий за окно в одно сообщение.
+package notify
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/events"
+)
+
+// ErrQueueFull — очередь доставки переполнена (каналы не успевают), событие отброшено.
+var ErrQueueFull = errors.New("notify queue full")
+
+// Channel — канал доставки: Slack, Telegram, SMTP (см. gateway/notify).
+type Channel interface {
+	Name() string
+	Send(ctx context.Context, subject, text string) error
+}
+
+// Route — какие события получает канал; пустые списки не фильтруют.
+type Route struct {
+	Exchanges []string
+	Kinds     []events.Kind
+}
+
+func (r Route) match(e events.Event) bool {
+	if len(r.Exchanges) > 0 && !containsFold(r.Exchanges, e.Exchange) {
+		return false
+	}
+	if len(r.Kinds) == 0 {
+		return true
+	}
+	for _, k := range r.Kinds {
+		if k == e.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Sink — канал со своим маршрутом и шаблонами (nil — шаблоны по умолчанию).
+type Sink struct {
+	Channel Channel
+	Route   Route
+	Text    *template.Template
+	Subject *template.Template
+}
+
+// Notifier — events.Publisher: Publish только кладёт событие в очередь, доставка — в Start.
+// События за Window копятся и уходят одним сообщением на канал; PriorityHigh отправляет накопленное сразу.
+type Notifier struct {
+	Sinks    []Sink
+	Enricher Enricher      // nil — без «где ещё торгуется» и списков
+	Window   time.Duration // 0 — 3s
+	DedupTTL time.Duration // повтор (тип, биржа, рынок, символ) за это время отбрасывается; 0 — 1h
+	Timeout  time.Duration // на отправку в один канал; 0 — 15s
+	Queue    int           // 0 — 1024
+	Logger   *slog.Logger
+
+	initOnce sync.Once
+	queue    chan events.Event
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+var _ events.Publisher = (*Notifier)(nil)
+
+func (n *Notifier) log() *slog.Logger {
+	if n.Logger != nil {
+		return n.Logger
+	}
+	return slog.Default()
+}
+
+func (n *Notifier) init() {
+	n.initOnce.Do(func() {
+		size := n.Queue
+		if size <= 0 {
+			size = 1024
+		}
+		n.queue = make(chan events.Event, size)
+		n.seen = map[string]time.Time{}
+	})
+}
+
+// Publish — дедупликация и постановка в очередь; не блокируется.
+func (n *Notifier) Publish(ctx context.Context, e events.Event) error {
+	n.init()
+	if !n.first(e) {
+		return nil
+	}
+	select {
+	case n.queue <- e:
+		return nil
+	default:
+		n.forget(e) // не доставлено — повтор не должен считаться дублем
+		return ErrQueueFull
+	}
+}
+
+func dedupKey(e events.Event) string {
+	return string(e.Kind) + "|" + strings.ToLower(e.Exchange) + "|" + e.Market + "|" + e.Symbol
+}
+
+// first — событие не встречалось за DedupTTL (детектор листингов и полный синк видят один рынок).
+func (n *Notifier) first(e events.Event) bool {
+	ttl := n.DedupTTL
+	if ttl <= 0 {
+		ttl = time.Hour
+	}
+	now := time.Now()
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for k, at := range n.seen {
+		if now.Sub(at) >= ttl {
+			delete(n.seen, k)
+		}
+	}
+	k := dedupKey(e)
+	if _, ok := n.seen[k]; ok {
+		return false
+	}
+	n.seen[k] = now
+	return true
+}
+
+func (n *Notifier) forget(e events.Event) {
+	n.mu.Lock()
+	delete(n.seen, dedupKey(e))
+	n.mu.Unlock()
+}
+
+// Start запускает доставку; по отмене ctx накопленное отправляется последний раз.
+func (n *Notifier) Start(ctx context.Context) {
+	n.init()
+	window := n.Window
+	if window <= 0 {
+		window = 3 * time.Second
+	}
+	go func() {
+		var batch []events.Event
+		var timer *time.Timer
+		var fire <-chan time.Time
+		flush := func(ctx context.Context) {
+			if timer != nil {
+				timer.Stop()
+			}
+			timer, fire = nil, nil
+			if len(batch) > 0 {
+				n.Deliver(ctx, batch)
+				batch = nil
+			}
+		}
+		for {
+			select {
+			case <-ctx.Done():
+			drain:
+				for {
+					select {
+					case e := <-n.queue:
+						batch = append(batch, e)
+					default:
+						break drain
+					}
+				}
+				flush(context.Background())
+				return
+			case e := <-n.queue:
+				batch = append(batch, e)
+				if e.Priority == events.PriorityHigh {
+					flush(ctx)
+				} else if timer == nil {
+					timer = time.NewTimer(window)
+					fire = timer.C
+				}
+			case <-fire:
+				flush(ctx)
+			}
+		}
+	}()
+}
+
+// Deliver — одно сообщение на каждый канал, которому по маршруту досталось хоть одно событие.
+// Ошибка канала пишется в лог и не мешает остальным.
+func (n *Notifier) Deliver(ctx context.Context, batch []events.Event) {
+	timeout := n.Timeout
+	if timeout <= 0 {
+		timeout = 15 * time.Second
+	}
+	notes := n.enrich(ctx, batch)
+	for _, s := range n.Sinks {
+		var mine []Note
+		for _, nt := range notes {
+			if s.Route.match(nt.Event) {
+				mine = append(mine, nt)
+			}
+		}
+		if len(mine) == 0 {
+			continue
+		}
+		subject, text, err := s.render(mine)
+		if err != nil {
+			n.log().Warn("notify render failed", "channel", s.Channel.Name(), "err", err)
+			continue
+		}
+		cctx, cancel := context.WithTimeout(ctx, timeout)
+		err = s.Channel.Send(cctx, subject, text)
+		cancel()
+		if err != nil {
+			n.log().Warn("notify send failed", "channel", s.Channel.Name(), "events", len(mine), "err", err)
+			continue
+		}
+		n.log().Info("notify sent", "channel", s.Channel.Name(), "events", len(mine))
+	}
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	ad "github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/events"
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+type sent struct{ subject, text string }
+
+type fakeChannel struct {
+	name string
+	mu   sync.Mutex
+	got  []sent
+	ch   chan sent
+}
+
+func newChannel(name string) *fakeChannel {
+	return &fakeChannel{name: name, ch: make(chan sent, 8)}
+}
+
+func (c *fakeChannel) Name() string { return c.name }
+func (c *fakeChannel) Send(ctx context.Context, subject, text string) error {
+	c.mu.Lock()
+	c.got = append(c.got, sent{subject, text})
+	c.mu.Unlock()
+	c.ch <- sent{subject, text}
+	return nil
+}
+
+func (c *fakeChannel) wait(t *testing.T) sent {
+	t.Helper()
+	select {
+	case m := <-c.ch:
+		return m
+	case <-time.After(2 * time.Second):
+		t.Fatalf("%s: nothing sent", c.name)
+		return sent{}
+	}
+}
+
+type fakeViews map[string]ad.View
+
+func (f fakeViews) View(ctx context.Context, base string) (ad.View, error) {
+	v, ok := f[base]
+	if !ok {
+		return ad.View{}, ad.ErrAssetNotFound
+	}
+	return v, nil
+}
+
+func listing(ex, sym, base string, p events.Priority) events.Event {
+	return events.Event{Kind: events.KindListing, Priority: p, Exchange: ex, Market: "spot", Symbol: sym, Base: base, Quote: "KRW"}
+}
+
+func TestDeliver_DefaultTemplateAndRouting(t *testing.T) {
+	gone := time.Now()
+	views := fakeViews{"XYZ": {
+		Asset: "XYZ",
+		Markets: []dm.Market{
+			{Item: dm.Item{Type: dm.TypeSpot}, Exchange: "binance"},
+			{Item: dm.Item{Type: dm.TypeFutures}, Exchange: "binance"},
+			{Item: dm.Item{Type: dm.TypeFutures}, Exchange: "okx", DelistedAt: &gone},
+			{Item: dm.Item{Type: dm.TypeSpot}, Exchange: "upbit"},
+		},
+		Lists: []ldom.Membership{{Slug: "binance_seg4"}, {Slug: "binance_seg4"}},
+	}}
+	all, upbitOnly, delist := newChannel("all"), newChannel("upbit"), newChannel("delist")
+	n := &Notifier{
+		Enricher: views,
+		Sinks: []Sink{
+			{Channel: all},
+			{Channel: upbitOnly, Route: Route{Exchanges: []string{"UPBIT"}}},
+			{Channel: delist, Route: Route{Kinds: []events.Kind{events.KindDelisting}}},
+		},
+	}
+	n.Deliver(context.Background(), []events.Event{
+		listing("upbit", "KRW-XYZ", "XYZ", events.PriorityHigh),
+		listing("bithumb", "ABC_KRW", "ABC", events.PriorityHigh),
+	})
+
+	got := all.wait(t)
+	if got.subject != "2 market events" {
+		t.Fatalf("subject = %q", got.subject)
+	}
+	want := "UPBIT listed KRW-XYZ — on Binance spot+futures, now in binance_seg4\nBITHUMB listed ABC_KRW"
+	if got.text != want {
+		t.Fatalf("text =\n%q\nwant\n%q", got.text, want)
+	}
+	if m := upbitOnly.wait(t); m.subject != "UPBIT listing KRW-XYZ" {
+		t.Fatalf("upbit subject = %q", m.subject)
+	}
+	if len(delist.got) != 0 {
+		t.Fatalf("delist channel got %v", delist.got)
+	}
+}
+
+func TestDeliver_CustomTemplate(t *testing.T) {
+	tpl, err := ParseTemplate("text", `{{range .Notes}}{{title .Exchange}}: {{.Base}}/{{.Quote}}{{end}}`, DefaultText)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := newChannel("c")
+	n := &Notifier{Sinks: []Sink{{Channel: c, Text: tpl}}}
+	n.Deliver(context.Background(), []events.Event{listing("upbit", "KRW-XYZ", "XYZ", events.PriorityNormal)})
+	if m := c.wait(t); m.text != "Upbit: XYZ/KRW" {
+		t.Fatalf("text = %q", m.text)
+	}
+	if _, err := ParseTemplate("bad", `{{range}`, DefaultText); err == nil {
+		t.Fatal("want parse error")
+	}
+}
+
+func TestNotifier_BatchDedupAndPriority(t *testing.T) {
+	c := newChannel("c")
+	n := &Notifier{Sinks: []Sink{{Channel: c}}, Window: 200 * time.Millisecond}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	n.Start(ctx)
+
+	// обычные события склеиваются за окно; повтор того же рынка отбрасывается
+	_ = n.Publish(ctx, listing("okx", "XYZ-USDT", "XYZ", events.PriorityNormal))
+	_ = n.Publish(ctx, listing("okx", "XYZ-USDT", "XYZ", events.PriorityNormal))
+	_ = n.Publish(ctx, listing("okx", "ABC-USDT", "ABC", events.PriorityNormal))
+	if m := c.wait(t); m.text != "OKX listed XYZ-USDT\nOKX listed ABC-USDT" {
+		t.Fatalf("batch = %q", m.text)
+	}
+
+	// высокий приоритет не ждёт окна
+	start := time.Now()
+	_ = n.Publish(ctx, listing("upbit", "KRW-XYZ", "XYZ", events.PriorityHigh))
+	if m := c.wait(t); m.text != "UPBIT listed KRW-XYZ" || time.Since(start) >= 200*time.Millisecond {
+		t.Fatalf("high = %q after %v", m.text, time.Since(start))
+	}
+	// тот же листинг из полного синка — дубль
+	_ = n.Publish(ctx, listing("upbit", "KRW-XYZ", "XYZ", events.PriorityNormal))
+	select {
+	case m := <-c.ch:
+		t.Fatalf("duplicate sent: %q", m.text)
+	case <-time.After(400 * time.Millisecond):
+	}
+}
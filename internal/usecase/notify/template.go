@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"text/template"
+
+	ad "github.com/berezovskyivalerii/tickersvc/internal/domain/assets"
+	"github.com/berezovskyivalerii/tickersvc/internal/domain/events"
+	dm "github.com/berezovskyivalerii/tickersvc/internal/domain/markets"
+)
+
+// Enricher — карточка актива (usecase/assets.Viewer): где ещё торгуется и в каких списках.
+type Enricher interface {
+	View(ctx context.Context, base string) (ad.View, error)
+}
+
+// Note — событие с контекстом для шаблона.
+type Note struct {
+	events.Event
+	Elsewhere []string // активные рынки актива на других биржах: "Binance spot+futures"
+	InLists   []string // списки и сегменты, где актив есть сейчас
+}
+
+// Batch — данные шаблона: события одного сообщения в порядке поступления.
+type Batch struct {
+	Notes []Note
+}
+
+// Шаблоны по умолчанию (text/template над Batch; функции — upper, lower, title, join).
+// Пример строки: «UPBIT listed KRW-XYZ — on Binance spot+futures, now in binance_seg4».
+const (
+	DefaultText = `{{range .Notes}}{{upper .Exchange}} {{if eq .Kind "listing"}}listed{{else}}delisted{{end}} {{.Symbol}}` +
+		`{{if eq .Market "futures"}} (futures){{end}}{{with .Elsewhere}} — on {{join . ", "}}{{end}}` +
+		`{{with .InLists}}, now in {{join . ", "}}{{end}}
+{{end}}`
+	DefaultSubject = `{{if eq (len .Notes) 1}}{{with index .Notes 0}}{{upper .Exchange}} {{.Kind}} {{.Symbol}}{{end}}` +
+		`{{else}}{{len .Notes}} market events{{end}}`
+)
+
+var funcs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"title": title,
+	"join":  strings.Join,
+}
+
+// ParseTemplate — шаблон канала; пустой текст — def.
+func ParseTemplate(name, text, def string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		text = def
+	}
+	return template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+}
+
+var (
+	defaultText    = template.Must(ParseTemplate("text", "", DefaultText))
+	defaultSubject = template.Must(ParseTemplate("subject", "", DefaultSubject))
+)
+
+func (s Sink) render(notes []Note) (subject, text string, err error) {
+	tt, st := s.Text, s.Subject
+	if tt == nil {
+		tt = defaultText
+	}
+	if st == nil {
+		st = defaultSubject
+	}
+	var b strings.Builder
+	if err := st.Execute(&b, Batch{Notes: notes}); err != nil {
+		return "", "", err
+	}
+	subject = strings.TrimSpace(b.String())
+	b.Reset()
+	if err := tt.Execute(&b, Batch{Notes: notes}); err != nil {
+		return "", "", err
+	}
+	return subject, strings.TrimSpace(b.String()), nil
+}
+
+// enrich — одна карточка на базу за батч; ошибка карточки не мешает уведомлению.
+func (n *Notifier) enrich(ctx context.Context, batch []events.Event) []Note {
+	views := map[string]*ad.View{}
+	out := make([]Note, 0, len(batch))
+	for _, e := range batch {
+		nt := Note{Event: e}
+		if n.Enricher != nil && e.Base != "" {
+			v, ok := views[e.Base]
+			if !ok {
+				view, err := n.Enricher.View(ctx, e.Base)
+				if err != nil && !errors.Is(err, ad.ErrAssetNotFound) {
+					n.log().Warn("notify asset view failed", "base", e.Base, "err", err)
+				}
+				if err == nil {
+					v = &view
+				}
+				views[e.Base] = v
+			}
+			if v != nil {
+				nt.Elsewhere, nt.InLists = elsewhere(*v, e.Exchange), listSlugs(*v)
+			}
+		}
+		out = append(out, nt)
+	}
+	return out
+}
+
+func elsewhere(v ad.View, exchange string) []string {
+	types := map[string]map[dm.Type]bool{}
+	for _, m := range v.Markets {
+		if m.DelistedAt != nil || strings.EqualFold(m.Exchange, exchange) {
+			continue
+		}
+		if types[m.Exchange] == nil {
+			types[m.Exchange] = map[dm.Type]bool{}
+		}
+		types[m.Exchange][m.Type] = true
+	}
+	out := make([]string, 0, len(types))
+	for ex, t := range types {
+		kinds := "spot+futures"
+		switch {
+		case !t[dm.TypeFutures]:
+			kinds = "spot"
+		case !t[dm.TypeSpot]:
+			kinds = "futures"
+		}
+		out = append(out, title(ex)+" "+kinds)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func listSlugs(v ad.View) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, m := range v.Lists {
+		if !seen[m.Slug] {
+			seen[m.Slug] = true
+			out = append(out, m.Slug)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
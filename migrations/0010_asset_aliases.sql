@@ -0,0 +1,26 @@
+-- +goose Up
+BEGIN;
+
+-- биржевой тикер → канонический актив (ренеймы, разные тикеры одного проекта)
+-- exchange_id IS NULL — алиас для всех бирж
+CREATE TABLE IF NOT EXISTS asset_aliases (
+  exchange_id SMALLINT    NULL REFERENCES exchanges(id) ON DELETE CASCADE,
+  ticker      TEXT        NOT NULL,
+  asset       TEXT        NOT NULL,
+  updated_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT ck_asset_aliases_upper CHECK (ticker = upper(ticker) AND asset = upper(asset)),
+  CONSTRAINT ck_asset_aliases_self  CHECK (ticker <> asset)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_asset_aliases_ex_ticker
+  ON asset_aliases ((COALESCE(exchange_id, 0)), ticker);
+
+INSERT INTO asset_aliases (exchange_id, ticker, asset) VALUES
+  (NULL, 'MATIC', 'POL')
+ON CONFLICT DO NOTHING;
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+DROP TABLE IF EXISTS asset_aliases;
+COMMIT;
@@ -0,0 +1,20 @@
+-- +goose Up
+BEGIN;
+
+-- множитель перпа: 1000 для 1000PEPEUSDT (база PEPE), 1 — без множителя
+ALTER TABLE markets
+  ADD COLUMN IF NOT EXISTS multiplier BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE incoming_tickers
+  ADD COLUMN IF NOT EXISTS multiplier BIGINT NOT NULL DEFAULT 1;
+
+ALTER TABLE markets
+  ADD CONSTRAINT ck_markets_multiplier CHECK (multiplier >= 1);
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+ALTER TABLE markets DROP CONSTRAINT IF EXISTS ck_markets_multiplier;
+ALTER TABLE markets DROP COLUMN IF EXISTS multiplier;
+ALTER TABLE incoming_tickers DROP COLUMN IF EXISTS multiplier;
+COMMIT;
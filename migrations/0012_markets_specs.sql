@@ -0,0 +1,44 @@
+-- +goose Up
+BEGIN;
+
+-- contract_size был BIGINT: дробные ctVal (OKX "0.1") терялись
+ALTER TABLE markets          ALTER COLUMN contract_size TYPE NUMERIC USING contract_size::numeric;
+ALTER TABLE incoming_tickers ALTER COLUMN contract_size TYPE NUMERIC USING contract_size::numeric;
+
+-- торговые параметры инструмента; NULL — биржа не отдаёт
+ALTER TABLE markets
+  ADD COLUMN IF NOT EXISTS tick_size    NUMERIC,
+  ADD COLUMN IF NOT EXISTS lot_size     NUMERIC,
+  ADD COLUMN IF NOT EXISTS min_qty      NUMERIC,
+  ADD COLUMN IF NOT EXISTS min_notional NUMERIC,
+  ADD COLUMN IF NOT EXISTS max_leverage NUMERIC;
+ALTER TABLE incoming_tickers
+  ADD COLUMN IF NOT EXISTS tick_size    NUMERIC,
+  ADD COLUMN IF NOT EXISTS lot_size     NUMERIC,
+  ADD COLUMN IF NOT EXISTS min_qty      NUMERIC,
+  ADD COLUMN IF NOT EXISTS min_notional NUMERIC,
+  ADD COLUMN IF NOT EXISTS max_leverage NUMERIC;
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+ALTER TABLE markets
+  DROP COLUMN IF EXISTS tick_size,
+  DROP COLUMN IF EXISTS lot_size,
+  DROP COLUMN IF EXISTS min_qty,
+  DROP COLUMN IF EXISTS min_notional,
+  DROP COLUMN IF EXISTS max_leverage;
+ALTER TABLE incoming_tickers
+  DROP COLUMN IF EXISTS tick_size,
+  DROP COLUMN IF EXISTS lot_size,
+  DROP COLUMN IF EXISTS min_qty,
+  DROP COLUMN IF EXISTS min_notional,
+  DROP COLUMN IF EXISTS max_leverage;
+
+-- дробные значения в BIGINT не влезают — обнуляем, как делали адаптеры раньше
+ALTER TABLE markets ALTER COLUMN contract_size TYPE BIGINT
+  USING CASE WHEN contract_size = trunc(contract_size) THEN contract_size::bigint END;
+ALTER TABLE incoming_tickers ALTER COLUMN contract_size TYPE BIGINT
+  USING CASE WHEN contract_size = trunc(contract_size) THEN contract_size::bigint END;
+COMMIT;
@@ -0,0 +1,51 @@
+-- +goose Up
+BEGIN;
+
+-- вид фьючерса: linear_perp | inverse_perp | delivery (у спота NULL)
+ALTER TABLE markets
+  ADD COLUMN IF NOT EXISTS contract_kind TEXT NULL,
+  ADD COLUMN IF NOT EXISTS settle_asset  TEXT NULL,
+  ADD COLUMN IF NOT EXISTS expiry_at     TIMESTAMPTZ NULL;
+ALTER TABLE incoming_tickers
+  ADD COLUMN IF NOT EXISTS contract_kind TEXT NULL,
+  ADD COLUMN IF NOT EXISTS settle_asset  TEXT NULL,
+  ADD COLUMN IF NOT EXISTS expiry_at     TIMESTAMPTZ NULL;
+
+-- до этой миграции тянулись только линейные перпы
+UPDATE markets SET contract_kind = 'linear_perp' WHERE mtype = 'futures' AND contract_kind IS NULL;
+
+ALTER TABLE markets
+  ADD CONSTRAINT ck_markets_contract_kind CHECK (
+    (mtype = 'spot'    AND contract_kind IS NULL)
+    OR
+    (mtype = 'futures' AND contract_kind IN ('linear_perp','inverse_perp','delivery'))
+  );
+
+-- какие виды контрактов заполняют колонку фьючерсов списка
+ALTER TABLE list_defs
+  ADD COLUMN IF NOT EXISTS futures_kinds TEXT[] NOT NULL DEFAULT '{linear_perp}';
+ALTER TABLE list_defs
+  ADD CONSTRAINT ck_list_defs_futures_kinds CHECK (
+    cardinality(futures_kinds) > 0
+    AND futures_kinds <@ ARRAY['linear_perp','inverse_perp','delivery']::text[]
+  );
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+ALTER TABLE list_defs DROP CONSTRAINT IF EXISTS ck_list_defs_futures_kinds;
+ALTER TABLE list_defs DROP COLUMN IF EXISTS futures_kinds;
+
+-- старая схема знает только линейные перпы
+DELETE FROM markets WHERE mtype = 'futures' AND contract_kind <> 'linear_perp';
+ALTER TABLE markets DROP CONSTRAINT IF EXISTS ck_markets_contract_kind;
+ALTER TABLE markets
+  DROP COLUMN IF EXISTS contract_kind,
+  DROP COLUMN IF EXISTS settle_asset,
+  DROP COLUMN IF EXISTS expiry_at;
+ALTER TABLE incoming_tickers
+  DROP COLUMN IF EXISTS contract_kind,
+  DROP COLUMN IF EXISTS settle_asset,
+  DROP COLUMN IF EXISTS expiry_at;
+COMMIT;
@@ -0,0 +1,22 @@
+-- +goose Up
+BEGIN;
+
+-- keyset-пагинация GET /api/markets: (ключ сортировки, id)
+CREATE INDEX IF NOT EXISTS ix_markets_symbol_id   ON markets(symbol, id);
+CREATE INDEX IF NOT EXISTS ix_markets_base_id     ON markets(base_asset, id);
+CREATE INDEX IF NOT EXISTS ix_markets_listed_id   ON markets(listed_at, id);
+CREATE INDEX IF NOT EXISTS ix_markets_delisted_id ON markets((COALESCE(delisted_at, 'infinity'::timestamptz)), id);
+
+-- «какие котировки у биржи X для монеты Y»
+CREATE INDEX IF NOT EXISTS ix_markets_base_quote_ex ON markets(base_asset, quote_asset, exchange_id);
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+DROP INDEX IF EXISTS ix_markets_base_quote_ex;
+DROP INDEX IF EXISTS ix_markets_delisted_id;
+DROP INDEX IF EXISTS ix_markets_listed_id;
+DROP INDEX IF EXISTS ix_markets_base_id;
+DROP INDEX IF EXISTS ix_markets_symbol_id;
+COMMIT;
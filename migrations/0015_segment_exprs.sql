@@ -0,0 +1,50 @@
+-- +goose Up
+BEGIN;
+
+-- сегмент = выражение над множествами присутствия (S, U, H, C, slug-и бирж), см. internal/pkg/setexpr
+ALTER TABLE list_defs
+  ADD COLUMN IF NOT EXISTS segment_expr TEXT NULL;
+
+UPDATE list_defs SET segment_expr = CASE segment
+    WHEN 'seg0' THEN 'S & !(U | H | C)'
+    WHEN 'seg1' THEN 'S & (C | H) & !U'
+    WHEN 'seg2' THEN 'S & U & (H & !C | C & !H)'
+    WHEN 'seg3' THEN 'S & U & C & H'
+    WHEN 'seg4' THEN 'S & U & !(C | H)'
+  END
+WHERE list_kind = 'segment' AND segment_expr IS NULL;
+
+-- имя сегмента теперь произвольное, но выражение обязательно
+ALTER TABLE list_defs
+  DROP CONSTRAINT IF EXISTS ck_list_defs_mode;
+ALTER TABLE list_defs
+  ADD CONSTRAINT ck_list_defs_mode
+  CHECK (
+    (list_kind = 'target'  AND target_exchange IS NOT NULL AND segment IS NULL AND segment_expr IS NULL)
+    OR
+    (list_kind = 'segment' AND target_exchange IS NULL
+       AND segment ~ '^[a-z0-9_]+$' AND btrim(COALESCE(segment_expr, '')) <> '')
+  );
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DELETE FROM list_defs
+WHERE list_kind = 'segment' AND segment NOT IN ('seg0','seg1','seg2','seg3','seg4');
+
+ALTER TABLE list_defs
+  DROP CONSTRAINT IF EXISTS ck_list_defs_mode;
+ALTER TABLE list_defs
+  ADD CONSTRAINT ck_list_defs_mode
+  CHECK (
+    (list_kind = 'target'  AND target_exchange IS NOT NULL AND segment IS NULL)
+    OR
+    (list_kind = 'segment' AND target_exchange IS NULL
+       AND segment IN ('seg0','seg1','seg2','seg3','seg4'))
+  );
+
+ALTER TABLE list_defs DROP COLUMN IF EXISTS segment_expr;
+
+COMMIT;
@@ -0,0 +1,43 @@
+-- +goose Up
+BEGIN;
+
+-- поколение = опубликованный срез всех списков; текущее содержимое list_items становится поколением 1
+CREATE TABLE IF NOT EXISTS list_generations (
+  id         BIGSERIAL   PRIMARY KEY,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+INSERT INTO list_generations (id) VALUES (1);
+SELECT setval(pg_get_serial_sequence('list_generations', 'id'), 1);
+
+-- указатель на опубликованное поколение (одна строка)
+CREATE TABLE IF NOT EXISTS list_state (
+  id         BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+  generation BIGINT  NOT NULL REFERENCES list_generations(id)
+);
+INSERT INTO list_state (generation) VALUES (1);
+
+ALTER TABLE list_items
+  ADD COLUMN IF NOT EXISTS generation BIGINT NOT NULL DEFAULT 1
+  REFERENCES list_generations(id) ON DELETE CASCADE;
+ALTER TABLE list_items ALTER COLUMN generation DROP DEFAULT;
+
+DROP INDEX IF EXISTS ux_list_items_unique;
+CREATE UNIQUE INDEX IF NOT EXISTS ux_list_items_unique ON list_items(generation, list_id, spot_symbol);
+DROP INDEX IF EXISTS ix_list_items_list;
+CREATE INDEX IF NOT EXISTS ix_list_items_list ON list_items(list_id, generation);
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+DELETE FROM list_items WHERE generation <> (SELECT generation FROM list_state);
+DROP INDEX IF EXISTS ix_list_items_list;
+DROP INDEX IF EXISTS ux_list_items_unique;
+ALTER TABLE list_items DROP COLUMN IF EXISTS generation;
+CREATE INDEX IF NOT EXISTS ix_list_items_list ON list_items(list_id);
+CREATE UNIQUE INDEX IF NOT EXISTS ux_list_items_unique ON list_items(list_id, spot_symbol);
+DROP TABLE IF EXISTS list_state;
+DROP TABLE IF EXISTS list_generations;
+
+COMMIT;
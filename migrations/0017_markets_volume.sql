@@ -0,0 +1,16 @@
+-- +goose Up
+BEGIN;
+
+-- последний 24h-оборот спота в USD (из тикеров биржи); NULL — биржа не отдаёт или курс котировки неизвестен
+ALTER TABLE markets
+  ADD COLUMN IF NOT EXISTS volume_usd_24h NUMERIC,
+  ADD COLUMN IF NOT EXISTS volume_at      TIMESTAMPTZ;
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+ALTER TABLE markets
+  DROP COLUMN IF EXISTS volume_usd_24h,
+  DROP COLUMN IF EXISTS volume_at;
+COMMIT;
@@ -0,0 +1,39 @@
+-- +goose Up
+BEGIN;
+
+-- поколение → версия каждого списка: строки list_items пишутся только для изменившихся списков,
+-- остальные поколение берёт у предыдущего. list_items.generation — поколение, записавшее версию.
+CREATE TABLE IF NOT EXISTS list_generation_lists (
+  generation BIGINT   NOT NULL REFERENCES list_generations(id) ON DELETE CASCADE,
+  list_id    SMALLINT NOT NULL REFERENCES list_defs(id) ON DELETE CASCADE,
+  items_gen  BIGINT   NOT NULL,
+  PRIMARY KEY (generation, list_id)
+);
+CREATE INDEX IF NOT EXISTS ix_list_generation_lists_version ON list_generation_lists(list_id, items_gen);
+
+-- у существующих поколений каждая версия своя (полные копии)
+INSERT INTO list_generation_lists (generation, list_id, items_gen)
+SELECT DISTINCT generation, list_id, generation FROM list_items
+ON CONFLICT DO NOTHING;
+
+-- версию удаляет публикация, когда на неё не ссылается ни одно поколение (каскад удалил бы общую)
+ALTER TABLE list_items DROP CONSTRAINT IF EXISTS list_items_generation_fkey;
+
+COMMIT;
+
+-- +goose Down
+BEGIN;
+
+-- обратно к полным копиям: каждое поколение получает строки всех своих списков
+INSERT INTO list_items (generation, list_id, spot_symbol, futures_symbol, created_at)
+SELECT gl.generation, li.list_id, li.spot_symbol, li.futures_symbol, li.created_at
+FROM list_generation_lists gl
+JOIN list_items li ON li.list_id = gl.list_id AND li.generation = gl.items_gen
+WHERE gl.generation <> gl.items_gen;
+DELETE FROM list_items li WHERE NOT EXISTS (SELECT 1 FROM list_generations g WHERE g.id = li.generation);
+ALTER TABLE list_items
+  ADD CONSTRAINT list_items_generation_fkey FOREIGN KEY (generation)
+  REFERENCES list_generations(id) ON DELETE CASCADE;
+DROP TABLE IF EXISTS list_generation_lists;
+
+COMMIT;
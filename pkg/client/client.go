@@ -0,0 +1,240 @@
+// Package client — Go-клиент HTTP API tickersvc: списки и сегменты, списки цели, /update и синк рынков.
+//
+//	c := client.New("http://localhost:8080", os.Getenv("ADMIN_API_KEY"))
+//	l, err := c.List(ctx, "okx_to_upbit", nil)
+//	if errors.Is(err, client.ErrNotFound) { ... }
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Client безопасен для горутин.
+type Client struct {
+	base       string
+	apiKey     string
+	hc         *http.Client
+	retries    int
+	backoffMin time.Duration
+	backoffMax time.Duration
+	userAgent  string
+}
+
+type Option func(*Client)
+
+// WithHTTPClient — свой http.Client (таймауты, транспорт). По умолчанию — Timeout 5m: /update синкает все биржи.
+func WithHTTPClient(hc *http.Client) Option { return func(c *Client) { c.hc = hc } }
+
+// WithRetries — сколько раз повторить запрос (по умолчанию 2). GET повторяется после сетевой ошибки,
+// 429 или 502/503/504; POST (/update, синк) — только если запрос точно не дошёл: соединение
+// не установлено или 429/503 с Retry-After.
+func WithRetries(n int) Option { return func(c *Client) { c.retries = max(n, 0) } }
+
+// WithBackoff — пауза перед повтором: min·2^attempt, не больше max; Retry-After сервера важнее.
+func WithBackoff(min, max time.Duration) Option {
+	return func(c *Client) { c.backoffMin, c.backoffMax = min, max }
+}
+
+func WithUserAgent(ua string) Option { return func(c *Client) { c.userAgent = ua } }
+
+// New — base вида http://host:8080; apiKey уходит в X-API-Key (тот же ADMIN_API_KEY, что у сервера).
+func New(base, apiKey string, opts ...Option) *Client {
+	c := &Client{
+		base:       strings.TrimRight(base, "/"),
+		apiKey:     apiKey,
+		hc:         &http.Client{Timeout: 5 * time.Minute},
+		retries:    2,
+		backoffMin: 200 * time.Millisecond,
+		backoffMax: 3 * time.Second,
+		userAgent:  "tickersvc-client",
+	}
+	for _, o := range opts {
+		o(c)
+	}
+	if c.backoffMax < c.backoffMin {
+		c.backoffMax = c.backoffMin
+	}
+	return c
+}
+
+var (
+	ErrBadRequest = errors.New("bad request") // 400: неверный параметр, курсор, нотация
+	ErrForbidden  = errors.New("forbidden")   // 403: нет или не тот ключ
+	ErrNotFound   = errors.New("not found")   // 404: список, поколение
+)
+
+// Error — ответ не 2xx. errors.Is сопоставляет его с ErrBadRequest/ErrForbidden/ErrNotFound по коду.
+type Error struct {
+	StatusCode int
+	Message    string // поле "error" JSON-ответа или начало тела
+	Body       []byte // тело целиком (у /update при ошибке там и markets_sync)
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return "tickersvc: http " + strconv.Itoa(e.StatusCode)
+	}
+	return fmt.Sprintf("tickersvc: http %d: %s", e.StatusCode, e.Message)
+}
+
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+func apiError(status int, body []byte) *Error {
+	e := &Error{StatusCode: status, Body: body}
+	var v struct {
+		Error string `json:"error"`
+	}
+	if json.Unmarshal(body, &v) == nil && v.Error != "" {
+		e.Message = v.Error
+	} else {
+		e.Message = strings.TrimSpace(string(body))
+		if len(e.Message) > 256 {
+			e.Message = e.Message[:256] + "…"
+		}
+	}
+	return e
+}
+
+// response — успешный ответ: тело и заголовки (X-Generation, X-Total-Count, X-Next-Cursor).
+type response struct {
+	header http.Header
+	body   []byte
+}
+
+func (c *Client) get(ctx context.Context, path string, q url.Values, accept string) (*response, error) {
+	return c.do(ctx, http.MethodGet, path, q, accept)
+}
+
+func (c *Client) post(ctx context.Context, path string, q url.Values) (*response, error) {
+	return c.do(ctx, http.MethodPost, path, q, "application/json")
+}
+
+// do — запрос с повторами. GET идемпотентен: повторяется после сети, 429, 502–504.
+// POST повторяется, только если сервер его точно не выполнял: таймаут или 502 прокси перед долгим /update
+// не значит, что синк не прошёл и поколение не опубликовано. 500 у /update — ошибка синка, повтор её не исправит.
+func (c *Client) do(ctx context.Context, method, path string, q url.Values, accept string) (*response, error) {
+	idempotent := method == http.MethodGet
+	u := c.base + path
+	if len(q) > 0 {
+		u += "?" + q.Encode()
+	}
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-API-Key", c.apiKey)
+		req.Header.Set("Accept", accept)
+		req.Header.Set("User-Agent", c.userAgent)
+
+		resp, err := c.hc.Do(req)
+		var retryAfter string
+		if err == nil {
+			body, rerr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			switch {
+			case rerr != nil:
+				err = rerr
+			case resp.StatusCode >= 200 && resp.StatusCode < 300:
+				return &response{header: resp.Header, body: body}, nil
+			case !retryStatus(resp.StatusCode, resp.Header, idempotent) || attempt >= c.retries:
+				return nil, apiError(resp.StatusCode, body)
+			default:
+				retryAfter = resp.Header.Get("Retry-After")
+			}
+		}
+		if err != nil && (attempt >= c.retries || !retryErr(ctx, err, idempotent)) {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.backoff(attempt, retryAfter)):
+		}
+	}
+}
+
+// retryStatus: не-идемпотентный запрос — только 429/503 с Retry-After (сервер сам отказался его выполнять).
+func retryStatus(code int, h http.Header, idempotent bool) bool {
+	if !idempotent {
+		return (code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable) && h.Get("Retry-After") != ""
+	}
+	switch code {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryErr — транспортные ошибки, но не отмена вызывающим. *url.Error сам по себе не признак:
+// им обёрнута любая ошибка http.Client (TLS, неверная схема), и он реализует net.Error.
+// Неудачный dial — запрос не ушёл, повторять можно всегда; таймауты и обрывы — только идемпотентные.
+func retryErr(ctx context.Context, err error, idempotent bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var op *net.OpError
+	if errors.As(err, &op) && op.Op == "dial" {
+		return true
+	}
+	if !idempotent {
+		return false
+	}
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+	return op != nil || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET)
+}
+
+func (c *Client) backoff(attempt int, retryAfter string) time.Duration {
+	if sec, err := strconv.Atoi(strings.TrimSpace(retryAfter)); err == nil && sec >= 0 {
+		return time.Duration(sec) * time.Second
+	}
+	back := c.backoffMin << attempt
+	if back > c.backoffMax || back <= 0 {
+		back = c.backoffMax
+	}
+	if back <= 1 {
+		return back
+	}
+	// jitter до 50%
+	return back/2 + time.Duration(rand.Int63n(int64(back)/2+1))
+}
+
+func decode(r *response, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(r.body))
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("tickersvc: decode response: %w", err)
+	}
+	return nil
+}
+
+// generation — X-Generation ответа; 0, если сервер его не прислал.
+func generation(h http.Header) int64 {
+	n, _ := strconv.ParseInt(h.Get("X-Generation"), 10, 64)
+	return n
+}
@@ -0,0 +1,399 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/berezovskyivalerii/tickersvc/internal/app"
+	ldom "github.com/berezovskyivalerii/tickersvc/internal/domain/lists"
+	listsuc "github.com/berezovskyivalerii/tickersvc/internal/usecase/lists"
+	"github.com/berezovskyivalerii/tickersvc/pkg/client"
+)
+
+const key = "k1"
+
+func sp(s string) *string   { return &s }
+func fp(f float64) *float64 { return &f }
+
+// fakeLists — одно поколение (7): slug → строки; цель — по slug вида <src>_to_<target>.
+type fakeLists struct {
+	rows map[string][]ldom.Row
+}
+
+func (f *fakeLists) Generation(ctx context.Context, id int64) (ldom.Generation, error) {
+	if id != 0 && id != 7 {
+		return ldom.Generation{}, ldom.ErrGenerationNotFound
+	}
+	return ldom.Generation{ID: 7}, nil
+}
+
+func (f *fakeLists) GetTextBySlug(ctx context.Context, slug string) ([]string, error) {
+	return nil, nil
+}
+func (f *fakeLists) GetTextByTarget(ctx context.Context, t string) (map[string][]string, error) {
+	return nil, nil
+}
+func (f *fakeLists) GetAllText(ctx context.Context) (map[string]map[string][]string, error) {
+	return nil, nil
+}
+func (f *fakeLists) GetRowsBySlug(ctx context.Context, slug string) ([]ldom.Row, error) {
+	return f.rows[slug], nil
+}
+func (f *fakeLists) GetRowsByTarget(ctx context.Context, t string) (map[string][]ldom.Row, error) {
+	out := map[string][]ldom.Row{}
+	for slug, rows := range f.rows {
+		if src, tgt, ok := strings.Cut(slug, "_to_"); ok && tgt == t {
+			out[src] = rows
+		}
+	}
+	return out, nil
+}
+func (f *fakeLists) GetMeta(ctx context.Context, slug string) (ldom.Meta, error) {
+	if _, ok := f.rows[slug]; !ok {
+		return ldom.Meta{}, ldom.ErrNotFound
+	}
+	return ldom.Meta{Slug: slug}, nil
+}
+
+// ListMetas — сегменты по соглашению {source}_{segment}, по ним сервер резолвит /api/segments.
+func (f *fakeLists) ListMetas(ctx context.Context) ([]ldom.Meta, error) {
+	var out []ldom.Meta
+	for slug := range f.rows {
+		src, seg, _ := strings.Cut(slug, "_")
+		if strings.HasPrefix(seg, "seg") {
+			out = append(out, ldom.Meta{Slug: slug, Kind: "segment", SourceSlug: src, Segment: seg})
+		}
+	}
+	return out, nil
+}
+
+// page — курсор = индекс следующей строки.
+func page(rows []ldom.Row, fl ldom.RowsFilter) (ldom.RowsPage, error) {
+	from := 0
+	if fl.Cursor != "" {
+		n, err := strconv.Atoi(fl.Cursor)
+		if err != nil || n > len(rows) {
+			return ldom.RowsPage{}, ldom.ErrBadCursor
+		}
+		from = n
+	}
+	p := ldom.RowsPage{Total: len(rows), Rows: rows[from:]}
+	if fl.Limit > 0 && from+fl.Limit < len(rows) {
+		p.Rows, p.NextCursor = rows[from:from+fl.Limit], strconv.Itoa(from+fl.Limit)
+	}
+	return p, nil
+}
+
+func (f *fakeLists) FindRowsBySlug(ctx context.Context, slug string, fl ldom.RowsFilter) (ldom.RowsPage, error) {
+	return page(f.rows[slug], fl)
+}
+func (f *fakeLists) FindRowsByTarget(ctx context.Context, t string, fl ldom.RowsFilter) (ldom.RowsPage, error) {
+	by, _ := f.GetRowsByTarget(ctx, t)
+	srcs := make([]string, 0, len(by))
+	for s := range by {
+		srcs = append(srcs, s)
+	}
+	sort.Strings(srcs)
+	var all []ldom.Row
+	for _, s := range srcs {
+		for _, r := range by[s] {
+			r.Source = s
+			all = append(all, r)
+		}
+	}
+	return page(all, fl)
+}
+
+type fakeSync struct {
+	calls int
+	err   error
+}
+
+func (f *fakeSync) RunAll(ctx context.Context) (map[int16][3]int, error) {
+	f.calls++
+	return map[int16][3]int{1: {2, 1, 0}, 4: {}}, f.err
+}
+
+// fakeBuilder — только RebuildAll; explain/presence/query/preview клиентом не вызываются.
+type fakeBuilder struct {
+	app.ListsBuilder
+	spec listsuc.RebuildSpec
+}
+
+func (f *fakeBuilder) RebuildAll(ctx context.Context, spec listsuc.RebuildSpec) (listsuc.RebuildResult, error) {
+	f.spec = spec
+	return listsuc.RebuildResult{
+		Generation: ldom.Generation{ID: 8},
+		Lists:      map[string]int{"okx_to_upbit": 2},
+		Segments:   map[string]int{},
+		Skipped:    map[string]string{"binance_seg1": listsuc.SkipUnchanged},
+	}, nil
+}
+
+type env struct {
+	srv     *httptest.Server
+	sync    *fakeSync
+	builder *fakeBuilder
+}
+
+func start(t *testing.T) env {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	e := env{sync: &fakeSync{}, builder: &fakeBuilder{}}
+	router := app.NewRouter(app.Routes{
+		APIKey: func() string { return key },
+		Lists: &fakeLists{rows: map[string][]ldom.Row{
+			"okx_to_upbit": {
+				{Spot: "AAA-USDT", Futures: sp("AAA-USDT-SWAP"), Base: "AAA", Quote: "USDT", VolumeUSD: fp(1500)},
+				{Spot: "BBB-USDT", Base: "BBB", Quote: "USDT"},
+				{Spot: "CCC-USDT", Futures: sp("CCC-USDT-SWAP"), Base: "CCC", Quote: "USDT"},
+			},
+			"binance_to_upbit": {{Spot: "DDDUSDT", Base: "DDD", Quote: "USDT"}},
+			"bybit_seg2":       {{Spot: "EEEUSDT", Futures: sp("EEEUSDT")}},
+		}},
+		Sync:    e.sync,
+		Builder: e.builder,
+	})
+	e.srv = httptest.NewServer(router)
+	t.Cleanup(e.srv.Close)
+	return e
+}
+
+func newClient(url string) *client.Client {
+	return client.New(url, key, client.WithBackoff(time.Millisecond, 5*time.Millisecond))
+}
+
+func TestAuth(t *testing.T) {
+	e := start(t)
+	_, err := client.New(e.srv.URL, "wrong").List(context.Background(), "okx_to_upbit", nil)
+	var apiErr *client.Error
+	if !errors.Is(err, client.ErrForbidden) || !errors.As(err, &apiErr) || apiErr.StatusCode != 403 || apiErr.Message != "forbidden" {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestList(t *testing.T) {
+	e := start(t)
+	c := newClient(e.srv.URL)
+	l, err := c.List(context.Background(), "okx_to_upbit", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Slug != "okx_to_upbit" || l.Generation != 7 || len(l.Items) != 3 || l.Total != nil {
+		t.Fatalf("list = %+v", l)
+	}
+	if it := l.Items[0]; it.Spot != "AAA-USDT" || it.Futures != "AAA-USDT-SWAP" || it.VolumeUSD == nil || *it.VolumeUSD != 1500 {
+		t.Fatalf("item = %+v", it)
+	}
+	if l.Items[1].Futures != "" {
+		t.Fatalf("none must become empty, got %q", l.Items[1].Futures)
+	}
+
+	l, err = c.List(context.Background(), "okx_to_upbit", &client.ListOptions{Notation: "ccxt", Generation: 7})
+	if err != nil || l.Items[0].Spot != "AAA/USDT" {
+		t.Fatalf("ccxt = %+v %v", l, err)
+	}
+
+	// страницы по курсору
+	var spots []string
+	opt := &client.ListOptions{Limit: 2}
+	for {
+		l, err := c.List(context.Background(), "okx_to_upbit", opt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if l.Total == nil || *l.Total != 3 {
+			t.Fatalf("total = %v", l.Total)
+		}
+		for _, it := range l.Items {
+			spots = append(spots, it.Spot)
+		}
+		if l.NextCursor == "" {
+			break
+		}
+		opt.Cursor = l.NextCursor
+	}
+	if strings.Join(spots, " ") != "AAA-USDT BBB-USDT CCC-USDT" {
+		t.Fatalf("pages = %v", spots)
+	}
+
+	for name, tc := range map[string]struct {
+		slug string
+		opt  *client.ListOptions
+		want error
+	}{
+		"bad notation":   {"okx_to_upbit", &client.ListOptions{Notation: "x"}, client.ErrBadRequest},
+		"bad cursor":     {"okx_to_upbit", &client.ListOptions{Cursor: "x"}, client.ErrBadRequest},
+		"old generation": {"okx_to_upbit", &client.ListOptions{Generation: 3}, client.ErrNotFound},
+	} {
+		if _, err := c.List(context.Background(), tc.slug, tc.opt); !errors.Is(err, tc.want) {
+			t.Errorf("%s: err = %v, want %v", name, err, tc.want)
+		}
+	}
+}
+
+func TestSegment(t *testing.T) {
+	e := start(t)
+	c := newClient(e.srv.URL)
+	l, err := c.Segment(context.Background(), "bybit", 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Slug != "bybit_seg2" || len(l.Items) != 1 || l.Items[0].Futures != "EEEUSDT" {
+		t.Fatalf("segment = %+v", l)
+	}
+	if _, err := c.Segment(context.Background(), "bybit", 9, nil); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("seg 9: %v", err)
+	}
+}
+
+func TestTarget(t *testing.T) {
+	e := start(t)
+	c := newClient(e.srv.URL)
+	v, err := c.Target(context.Background(), "upbit", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	by := v.BySource()
+	if v.Generation != 7 || v.Total != nil || len(v.Items) != 4 || len(by["okx"]) != 3 || len(by["binance"]) != 1 {
+		t.Fatalf("target = %+v", v)
+	}
+	if it := by["okx"][0]; it.Source != "okx" || it.Futures != "AAA-USDT-SWAP" || it.VolumeUSD == nil {
+		t.Fatalf("item = %+v", it)
+	}
+
+	v, err = c.Target(context.Background(), "upbit", &client.ListOptions{Limit: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.Total == nil || *v.Total != 4 || v.NextCursor != "3" || len(v.Items) != 3 {
+		t.Fatalf("page = %+v", v)
+	}
+}
+
+func TestUpdateAndSync(t *testing.T) {
+	e := start(t)
+	c := newClient(e.srv.URL)
+	res, err := c.Update(context.Background(), client.UpdateOptions{Mode: "targets", Sources: []string{"okx", "bybit"}, Target: "upbit", Force: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Generation != 8 || res.Lists["okx_to_upbit"] != 2 || res.Segments != nil ||
+		res.Skipped["binance_seg1"] != listsuc.SkipUnchanged || res.MarketsSync[1] != (client.SyncCounts{Added: 2, Updated: 1}) {
+		t.Fatalf("update = %+v", res)
+	}
+	spec := e.builder.spec
+	if !spec.Targets || spec.Segments || *spec.Source != "okx" || *spec.Target != "upbit" || len(spec.SegmentSources) != 2 || spec.Changed != nil {
+		t.Fatalf("spec = %+v", spec)
+	}
+
+	sum, err := c.SyncMarkets(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sum) != 2 || sum[1].Added != 2 || e.sync.calls != 2 {
+		t.Fatalf("sync = %+v calls=%d", sum, e.sync.calls)
+	}
+
+	// 500 с телом: не повторяется, тело доступно
+	e.sync.err = errors.New("binance: boom")
+	_, err = c.SyncMarkets(context.Background())
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 500 || apiErr.Message != "binance: boom" || e.sync.calls != 3 {
+		t.Fatalf("err = %v calls=%d", err, e.sync.calls)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	e := start(t)
+	target, _ := url.Parse(e.srv.URL)
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	var hits atomic.Int32
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	defer flaky.Close()
+
+	l, err := newClient(flaky.URL).List(context.Background(), "okx_to_upbit", nil)
+	if err != nil || len(l.Items) != 3 || hits.Load() != 3 {
+		t.Fatalf("list = %+v err=%v hits=%d", l, err, hits.Load())
+	}
+
+	hits.Store(0)
+	_, err = client.New(flaky.URL, key, client.WithRetries(1), client.WithBackoff(time.Millisecond, time.Millisecond)).
+		List(context.Background(), "okx_to_upbit", nil)
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 503 || hits.Load() != 2 {
+		t.Fatalf("err = %v hits=%d", err, hits.Load())
+	}
+}
+
+// POST (синк, /update) не повторяется после 502/503 без Retry-After: сервер мог его уже выполнить.
+func TestRetries_PostOnlyWhenNotExecuted(t *testing.T) {
+	e := start(t)
+	target, _ := url.Parse(e.srv.URL)
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	var hits atomic.Int32
+	var retryAfter atomic.Bool
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hits.Add(1) == 1 {
+			if retryAfter.Load() {
+				w.Header().Set("Retry-After", "0")
+			}
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		proxy.ServeHTTP(w, r)
+	}))
+	defer flaky.Close()
+
+	_, err := newClient(flaky.URL).SyncMarkets(context.Background())
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 503 || hits.Load() != 1 || e.sync.calls != 0 {
+		t.Fatalf("no Retry-After: err = %v hits=%d calls=%d", err, hits.Load(), e.sync.calls)
+	}
+
+	hits.Store(0)
+	retryAfter.Store(true)
+	if _, err := newClient(flaky.URL).SyncMarkets(context.Background()); err != nil || hits.Load() != 2 || e.sync.calls != 1 {
+		t.Fatalf("Retry-After: err = %v hits=%d calls=%d", err, hits.Load(), e.sync.calls)
+	}
+}
+
+// Ошибка, не связанная с сетью (неверная схема), не повторяется; несостоявшееся соединение — да.
+func TestRetries_TransportErrors(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { hits.Add(1) }))
+	addr := srv.Listener.Addr().String()
+	srv.Close()
+
+	start := time.Now()
+	_, err := client.New("ftp://"+addr, key, client.WithBackoff(time.Second, time.Second)).
+		List(context.Background(), "okx_to_upbit", nil)
+	if err == nil || time.Since(start) >= time.Second {
+		t.Fatalf("bad scheme retried: err = %v after %s", err, time.Since(start))
+	}
+
+	start = time.Now()
+	_, err = client.New("http://"+addr, key, client.WithRetries(1), client.WithBackoff(200*time.Millisecond, 200*time.Millisecond)).
+		SyncMarkets(context.Background())
+	if err == nil || time.Since(start) < 100*time.Millisecond { // джиттер: пауза не короче половины
+		t.Fatalf("refused POST not retried: err = %v after %s", err, time.Since(start))
+	}
+}
@@ -0,0 +1,178 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Item — строка списка. Futures пустой, если фьючерса нет (в API это "none").
+type Item struct {
+	Source    string // только у списков цели
+	Spot      string
+	Futures   string
+	VolumeUSD *float64 // 24h-оборот спота; nil — биржа его не отдаёт
+}
+
+// ListOptions — query-параметры /api/lists. nil или нулевые поля — весь список как есть.
+type ListOptions struct {
+	Generation   int64  // читать это поколение (0 — текущее)
+	Notation     string // raw | tradingview | ccxt
+	Q            string // префикс базы или подстрока символа
+	HasFutures   *bool
+	Quotes       []string
+	MinVolumeUSD float64
+	Sort         string // spot | volume
+	Limit        int    // размер страницы; следующая — Cursor = NextCursor
+	Cursor       string
+}
+
+func (o *ListOptions) values() url.Values {
+	q := url.Values{}
+	if o == nil {
+		return q
+	}
+	set := func(k, v string) {
+		if v != "" {
+			q.Set(k, v)
+		}
+	}
+	if o.Generation > 0 {
+		q.Set("generation", strconv.FormatInt(o.Generation, 10))
+	}
+	set("notation", o.Notation)
+	set("q", o.Q)
+	if o.HasFutures != nil {
+		q.Set("has_futures", strconv.FormatBool(*o.HasFutures))
+	}
+	set("quote", strings.Join(o.Quotes, ","))
+	if o.MinVolumeUSD > 0 {
+		q.Set("min_volume_usd", strconv.FormatFloat(o.MinVolumeUSD, 'f', -1, 64))
+	}
+	set("sort", o.Sort)
+	if o.Limit > 0 {
+		q.Set("limit", strconv.Itoa(o.Limit))
+	}
+	set("cursor", o.Cursor)
+	return q
+}
+
+// List — список или сегмент.
+type List struct {
+	Slug       string
+	Generation int64 // поколение, из которого прочитан список (0 — сервер без поколений)
+	Items      []Item
+	Total      *int   // только с фильтрами/страницей
+	NextCursor string // пусто — страница последняя
+}
+
+// List — GET /api/lists/:slug.
+func (c *Client) List(ctx context.Context, slug string, opt *ListOptions) (*List, error) {
+	if strings.TrimSpace(slug) == "" {
+		return nil, fmt.Errorf("tickersvc: empty slug")
+	}
+	return c.list(ctx, "/api/lists/"+url.PathEscape(slug), slug, opt)
+}
+
+// Segment — GET /api/segments/:source/:seg, то же, что список <source>_seg<seg>; нет такого сегмента — ErrNotFound.
+func (c *Client) Segment(ctx context.Context, source string, seg int, opt *ListOptions) (*List, error) {
+	path := "/api/segments/" + url.PathEscape(source) + "/" + strconv.Itoa(seg)
+	return c.list(ctx, path, strings.ToLower(source)+"_seg"+strconv.Itoa(seg), opt)
+}
+
+func (c *Client) list(ctx context.Context, path, slug string, opt *ListOptions) (*List, error) {
+	r, err := c.get(ctx, path, opt.values(), "application/json")
+	if err != nil {
+		return nil, err
+	}
+	var v struct {
+		Items []struct {
+			SpotSymbol   string   `json:"SpotSymbol"`
+			FutureSymbol string   `json:"FutureSymbol"`
+			VolumeUSD    *float64 `json:"VolumeUSD"`
+		} `json:"items"`
+		Total      *int   `json:"total"`
+		NextCursor string `json:"next_cursor"`
+	}
+	if err := decode(r, &v); err != nil {
+		return nil, err
+	}
+	out := &List{Slug: slug, Generation: generation(r.header), Total: v.Total, NextCursor: v.NextCursor,
+		Items: make([]Item, 0, len(v.Items))}
+	for _, it := range v.Items {
+		out.Items = append(out.Items, Item{Spot: it.SpotSymbol, Futures: futures(it.FutureSymbol), VolumeUSD: it.VolumeUSD})
+	}
+	return out, nil
+}
+
+// TargetView — все списки цели (okx_to_upbit, binance_to_upbit, ...), строки с Source.
+type TargetView struct {
+	Target     string
+	Generation int64
+	Items      []Item // по источникам, а с Sort=volume — общим порядком по обороту
+	Total      *int
+	NextCursor string
+}
+
+// BySource — строки, сгруппированные по бирже-источнику.
+func (v *TargetView) BySource() map[string][]Item {
+	out := map[string][]Item{}
+	for _, it := range v.Items {
+		out[it.Source] = append(out[it.Source], it)
+	}
+	return out
+}
+
+// Target — GET /api/lists?target=. Читается в ndjson: в историческом JSON этой ручки нет оборота.
+func (c *Client) Target(ctx context.Context, target string, opt *ListOptions) (*TargetView, error) {
+	if strings.TrimSpace(target) == "" {
+		return nil, fmt.Errorf("tickersvc: empty target")
+	}
+	q := opt.values()
+	q.Set("target", target)
+	q.Set("format", "ndjson")
+	r, err := c.get(ctx, "/api/lists", q, "application/x-ndjson")
+	if err != nil {
+		return nil, err
+	}
+	out := &TargetView{Target: target, Generation: generation(r.header), NextCursor: r.header.Get("X-Next-Cursor")}
+	if v := r.header.Get("X-Total-Count"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			out.Total = &n
+		}
+	}
+	sc := bufio.NewScanner(bytes.NewReader(r.body))
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var rec struct {
+			Source    string   `json:"source"`
+			Spot      string   `json:"spot"`
+			Futures   string   `json:"futures"`
+			VolumeUSD *float64 `json:"volume_usd"`
+		}
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return nil, fmt.Errorf("tickersvc: decode response: %w", err)
+		}
+		out.Items = append(out.Items, Item{Source: rec.Source, Spot: rec.Spot, Futures: futures(rec.Futures), VolumeUSD: rec.VolumeUSD})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("tickersvc: decode response: %w", err)
+	}
+	return out, nil
+}
+
+func futures(s string) string {
+	if s == "none" {
+		return ""
+	}
+	return s
+}
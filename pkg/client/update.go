@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SyncCounts — итог синка одной биржи (в API — массив [added, updated, archived]).
+type SyncCounts struct {
+	Added, Updated, Archived int
+}
+
+func (s *SyncCounts) UnmarshalJSON(b []byte) error {
+	var v [3]int
+	if err := json.Unmarshal(b, &v); err != nil {
+		return fmt.Errorf("sync counts: %w", err)
+	}
+	s.Added, s.Updated, s.Archived = v[0], v[1], v[2]
+	return nil
+}
+
+// UpdateOptions — query-параметры POST /update.
+type UpdateOptions struct {
+	Mode    string   // all (по умолчанию) | targets | segments
+	Sources []string // target-списки — по первому источнику, сегменты — по всем
+	Target  string
+	Force   bool // пересобрать всё, а не только списки изменившихся бирж
+}
+
+// UpdateResult — ответ /update. Lists/Segments — только переписанные списки (slug → строк).
+type UpdateResult struct {
+	MarketsSync map[int16]SyncCounts `json:"markets_sync"` // exchange id → итог
+	Lists       map[string]int       `json:"lists_updated"`
+	Segments    map[string]int       `json:"segments_updated"`
+	Skipped     map[string]string    `json:"skipped"`    // slug → причина
+	Generation  int64                `json:"generation"` // 0 — писать было нечего
+}
+
+// Update — POST /update: синк рынков и пересборка списков/сегментов одним поколением.
+func (c *Client) Update(ctx context.Context, opt UpdateOptions) (*UpdateResult, error) {
+	q := url.Values{}
+	if opt.Mode != "" {
+		q.Set("mode", opt.Mode)
+	}
+	if len(opt.Sources) > 0 {
+		q.Set("source", strings.Join(opt.Sources, ","))
+	}
+	if opt.Target != "" {
+		q.Set("target", opt.Target)
+	}
+	if opt.Force {
+		q.Set("force", "1")
+	}
+	r, err := c.post(ctx, "/update", q)
+	if err != nil {
+		return nil, err
+	}
+	var out UpdateResult
+	if err := decode(r, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SyncMarkets — POST /admin/markets/sync: только синк рынков, без списков.
+func (c *Client) SyncMarkets(ctx context.Context) (map[int16]SyncCounts, error) {
+	r, err := c.post(ctx, "/admin/markets/sync", nil)
+	if err != nil {
+		return nil, err
+	}
+	var out struct {
+		Summary map[int16]SyncCounts `json:"summary"`
+	}
+	if err := decode(r, &out); err != nil {
+		return nil, err
+	}
+	return out.Summary, nil
+}